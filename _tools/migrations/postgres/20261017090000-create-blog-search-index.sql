@@ -0,0 +1,25 @@
+
+-- +migrate Up
+-- 日本語を含む全文検索のためのn-gramインデックス
+-- documentはアプリケーション側でuni-gram/bi-gramに分割したtsvector
+CREATE TABLE IF NOT EXISTS blog_search_index (
+  blog_id     INT          NOT NULL PRIMARY KEY,
+  document    TSVECTOR     NOT NULL,
+  modified    BIGINT       NOT NULL DEFAULT EXTRACT(EPOCH FROM CURRENT_TIMESTAMP),
+  CONSTRAINT fk_blog_search_index_blog
+    FOREIGN KEY (blog_id)
+    REFERENCES blogs (id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_blog_search_index_document
+  ON blog_search_index USING GIN (document);
+
+CREATE OR REPLACE TRIGGER update_blog_search_index_trigger_mod
+BEFORE UPDATE ON blog_search_index
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS update_blog_search_index_trigger_mod ON blog_search_index;
+DROP TABLE IF EXISTS blog_search_index;
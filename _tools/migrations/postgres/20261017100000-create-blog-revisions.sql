@@ -0,0 +1,43 @@
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS blog_revisions (
+  id                        BIGSERIAL PRIMARY KEY,
+  blog_id                   INT          NOT NULL,
+  revision                  INT          NOT NULL,
+  author_id                 INT          NOT NULL,
+  title                     TEXT         NOT NULL,
+  content                   TEXT         NOT NULL,
+  description               TEXT         NOT NULL,
+  thumbnail_image_file_name TEXT             NULL,
+  is_public                 BOOLEAN      NOT NULL DEFAULT FALSE,
+  tags                      JSONB        NOT NULL DEFAULT '[]',
+  created                   BIGINT       NOT NULL DEFAULT EXTRACT(EPOCH FROM CURRENT_TIMESTAMP),
+  UNIQUE(blog_id, revision),
+  CONSTRAINT fk_blog_revisions_blog
+    FOREIGN KEY (blog_id)
+    REFERENCES blogs (id)
+    ON DELETE CASCADE
+);
+
+-- 既存のブログは現在の内容をリビジョン1として登録する
+INSERT INTO blog_revisions (
+  blog_id, revision, author_id, title, content, description,
+  thumbnail_image_file_name, is_public, tags, created
+)
+SELECT
+  blogs.id, 1, blogs.author_id, blogs.title, blogs.content, blogs.description,
+  blogs.thumbnail_image_file_name, blogs.is_public,
+  COALESCE(
+    (
+      SELECT jsonb_agg(tags.name ORDER BY tags.name)
+      FROM blogs_tags
+      JOIN tags ON blogs_tags.tag_id = tags.id
+      WHERE blogs_tags.blog_id = blogs.id
+    ),
+    '[]'
+  ),
+  blogs.modified
+FROM blogs;
+
+-- +migrate Down
+DROP TABLE IF EXISTS blog_revisions;
@@ -0,0 +1,12 @@
+
+-- +migrate Up
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS publish_at BIGINT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_blogs_publish_at
+  ON blogs (publish_at)
+  WHERE is_public = FALSE AND publish_at IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_blogs_publish_at;
+
+ALTER TABLE blogs DROP COLUMN IF EXISTS publish_at;
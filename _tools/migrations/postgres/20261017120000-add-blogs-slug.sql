@@ -0,0 +1,53 @@
+
+-- +migrate Up
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS slug VARCHAR(255) NULL;
+
+-- 既存のブログはIDによるスラッグ("blog-ID")とする
+UPDATE blogs SET slug = 'blog-' || id::text WHERE slug IS NULL;
+
+ALTER TABLE blogs ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_blogs_slug
+  ON blogs (slug);
+
+-- +migrate StatementBegin
+-- スラッグの指定がない場合は"blog-ID"をスラッグとする
+CREATE OR REPLACE FUNCTION set_blog_slug_default()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.slug IS NULL OR NEW.slug = '' THEN
+        NEW.slug = 'blog-' || NEW.id::text;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER set_blogs_slug_default
+BEFORE INSERT ON blogs
+FOR EACH ROW
+EXECUTE FUNCTION set_blog_slug_default();
+-- +migrate StatementEnd
+
+-- 変更前のスラッグから新しいスラッグへリダイレクトするための履歴
+CREATE TABLE IF NOT EXISTS blog_slug_redirects (
+  slug        VARCHAR(255) PRIMARY KEY,
+  blog_id     INT          NOT NULL,
+  created     BIGINT       NOT NULL DEFAULT EXTRACT(EPOCH FROM CURRENT_TIMESTAMP),
+  CONSTRAINT fk_blog_slug_redirects_blog
+    FOREIGN KEY (blog_id)
+    REFERENCES blogs (id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_blog_slug_redirects_blog_id
+  ON blog_slug_redirects (blog_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS blog_slug_redirects;
+
+DROP TRIGGER IF EXISTS set_blogs_slug_default ON blogs;
+DROP FUNCTION IF EXISTS set_blog_slug_default();
+
+DROP INDEX IF EXISTS idx_blogs_slug;
+
+ALTER TABLE blogs DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,25 @@
+-- +migrate Up
+-- 本文のMarkdownをサーバー側でレンダリングした結果
+-- 保存時にアプリケーション側で生成し、取得時は再レンダリングしない
+CREATE TABLE IF NOT EXISTS blog_renderings (
+  blog_id               INT          NOT NULL PRIMARY KEY,
+  html                  TEXT         NOT NULL,
+  toc                   JSONB        NOT NULL DEFAULT '[]',
+  character_count       INT          NOT NULL DEFAULT 0,
+  word_count            INT          NOT NULL DEFAULT 0,
+  reading_time_minutes  INT          NOT NULL DEFAULT 0,
+  modified              BIGINT       NOT NULL DEFAULT EXTRACT(EPOCH FROM CURRENT_TIMESTAMP),
+  CONSTRAINT fk_blog_renderings_blog
+    FOREIGN KEY (blog_id)
+    REFERENCES blogs (id)
+    ON DELETE CASCADE
+);
+
+CREATE OR REPLACE TRIGGER update_blog_renderings_trigger_mod
+BEFORE UPDATE ON blog_renderings
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS update_blog_renderings_trigger_mod ON blog_renderings;
+DROP TABLE IF EXISTS blog_renderings;
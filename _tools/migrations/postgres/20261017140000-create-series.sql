@@ -0,0 +1,38 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS series (
+  id          SERIAL PRIMARY KEY,
+  author_id   INT          NOT NULL,
+  title       TEXT         NOT NULL,
+  description TEXT         NOT NULL DEFAULT '',
+  created     BIGINT       NOT NULL DEFAULT EXTRACT(EPOCH FROM CURRENT_TIMESTAMP),
+  modified    BIGINT       NOT NULL DEFAULT EXTRACT(EPOCH FROM CURRENT_TIMESTAMP)
+);
+
+CREATE OR REPLACE TRIGGER update_series_trigger_mod
+BEFORE UPDATE ON series
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- ブログは1つのシリーズにのみ所属する
+-- positionはシリーズ内の並び順で、1から連番とする
+CREATE TABLE IF NOT EXISTS series_blogs (
+  series_id   INT          NOT NULL,
+  blog_id     INT          NOT NULL,
+  position    INT          NOT NULL,
+  PRIMARY KEY (series_id, blog_id),
+  UNIQUE (blog_id),
+  UNIQUE (series_id, position) DEFERRABLE INITIALLY DEFERRED,
+  CONSTRAINT fk_series_blogs_series
+    FOREIGN KEY (series_id)
+    REFERENCES series (id)
+    ON DELETE CASCADE,
+  CONSTRAINT fk_series_blogs_blog
+    FOREIGN KEY (blog_id)
+    REFERENCES blogs (id)
+    ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS series_blogs;
+DROP TRIGGER IF EXISTS update_series_trigger_mod ON series;
+DROP TABLE IF EXISTS series;
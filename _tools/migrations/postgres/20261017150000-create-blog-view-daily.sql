@@ -0,0 +1,26 @@
+-- +migrate Up
+-- ブログの日別の閲覧数
+-- 閲覧はKVSで集計し、定期的にこのテーブルへ加算する
+CREATE TABLE IF NOT EXISTS blog_view_daily (
+  blog_id     INT          NOT NULL,
+  view_date   DATE         NOT NULL,
+  views       BIGINT       NOT NULL DEFAULT 0,
+  modified    BIGINT       NOT NULL DEFAULT EXTRACT(EPOCH FROM CURRENT_TIMESTAMP),
+  PRIMARY KEY (blog_id, view_date),
+  CONSTRAINT fk_blog_view_daily_blog
+    FOREIGN KEY (blog_id)
+    REFERENCES blogs (id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_blog_view_daily_view_date
+  ON blog_view_daily (view_date);
+
+CREATE OR REPLACE TRIGGER update_blog_view_daily_trigger_mod
+BEFORE UPDATE ON blog_view_daily
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS update_blog_view_daily_trigger_mod ON blog_view_daily;
+DROP TABLE IF EXISTS blog_view_daily;
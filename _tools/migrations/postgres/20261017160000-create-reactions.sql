@@ -0,0 +1,52 @@
+-- +migrate Up
+-- ブログとコメントへのリアクション
+-- 読者はログインユーザーの場合はuser_id、匿名の場合はclient_idで識別し、絵文字ごとに1件までとする
+CREATE TABLE IF NOT EXISTS blog_reactions (
+  id          BIGSERIAL    NOT NULL PRIMARY KEY,
+  blog_id     INT          NOT NULL,
+  emoji       VARCHAR(64)  NOT NULL,
+  user_id     INT              NULL,
+  client_id   VARCHAR(255)     NULL,
+  created     BIGINT       NOT NULL,
+  CONSTRAINT chk_blog_reactions_reactor
+    CHECK (user_id IS NOT NULL OR client_id IS NOT NULL),
+  CONSTRAINT fk_blog_reactions_blog
+    FOREIGN KEY (blog_id)
+    REFERENCES blogs (id)
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX ux_blog_reactions_user
+  ON blog_reactions (blog_id, emoji, user_id)
+  WHERE user_id IS NOT NULL;
+
+CREATE UNIQUE INDEX ux_blog_reactions_client
+  ON blog_reactions (blog_id, emoji, client_id)
+  WHERE user_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS comment_reactions (
+  id          BIGSERIAL    NOT NULL PRIMARY KEY,
+  comment_id  BIGINT       NOT NULL,
+  emoji       VARCHAR(64)  NOT NULL,
+  user_id     INT              NULL,
+  client_id   VARCHAR(255)     NULL,
+  created     BIGINT       NOT NULL,
+  CONSTRAINT chk_comment_reactions_reactor
+    CHECK (user_id IS NOT NULL OR client_id IS NOT NULL),
+  CONSTRAINT fk_comment_reactions_comment
+    FOREIGN KEY (comment_id)
+    REFERENCES comments (comment_id)
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX ux_comment_reactions_user
+  ON comment_reactions (comment_id, emoji, user_id)
+  WHERE user_id IS NOT NULL;
+
+CREATE UNIQUE INDEX ux_comment_reactions_client
+  ON comment_reactions (comment_id, emoji, client_id)
+  WHERE user_id IS NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS comment_reactions;
+DROP TABLE IF EXISTS blog_reactions;
@@ -0,0 +1,13 @@
+-- +migrate Up
+-- タグの説明と表示色
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+ALTER TABLE tags ADD COLUMN IF NOT EXISTS color VARCHAR(9) NULL;
+
+CREATE INDEX IF NOT EXISTS idx_blogs_tags_tag_id
+  ON blogs_tags (tag_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_blogs_tags_tag_id;
+
+ALTER TABLE tags DROP COLUMN IF EXISTS color;
+ALTER TABLE tags DROP COLUMN IF EXISTS description;
@@ -0,0 +1,36 @@
+-- +migrate Up
+-- ブログの既定の言語
+-- タイトル・概要・本文はこの言語で書かれているものとする
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS default_locale VARCHAR(35) NOT NULL DEFAULT 'ja';
+
+-- ブログのタイトル・概要・本文の言語ごとの翻訳
+-- 既定の言語の翻訳は持たない
+CREATE TABLE IF NOT EXISTS blog_translations (
+  blog_id      INT          NOT NULL,
+  locale       VARCHAR(35)  NOT NULL,
+  title        TEXT         NOT NULL,
+  description  TEXT         NOT NULL,
+  content      TEXT         NOT NULL,
+  created      BIGINT       NOT NULL DEFAULT EXTRACT(EPOCH FROM CURRENT_TIMESTAMP),
+  modified     BIGINT       NOT NULL DEFAULT EXTRACT(EPOCH FROM CURRENT_TIMESTAMP),
+  PRIMARY KEY (blog_id, locale),
+  CONSTRAINT fk_blog_translations_blog
+    FOREIGN KEY (blog_id)
+    REFERENCES blogs (id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_blog_translations_locale
+  ON blog_translations (locale);
+
+CREATE OR REPLACE TRIGGER update_blog_translations_trigger_mod
+BEFORE UPDATE ON blog_translations
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS update_blog_translations_trigger_mod ON blog_translations;
+DROP INDEX IF EXISTS idx_blog_translations_locale;
+DROP TABLE IF EXISTS blog_translations;
+
+ALTER TABLE blogs DROP COLUMN IF EXISTS default_locale;
@@ -0,0 +1,12 @@
+-- +migrate Up
+-- ゴミ箱に移動した日時。NULLの場合はゴミ箱に移動していない
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS deleted_at BIGINT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_blogs_deleted_at
+  ON blogs (deleted_at)
+  WHERE deleted_at IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_blogs_deleted_at;
+
+ALTER TABLE blogs DROP COLUMN IF EXISTS deleted_at;
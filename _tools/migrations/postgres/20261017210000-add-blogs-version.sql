@@ -0,0 +1,25 @@
+-- +migrate Up
+-- 楽観的排他制御に使用するバージョン。更新のたびに1ずつ増やす
+-- 更新日時は秒単位のため、同じ秒に更新された場合に区別できない
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION increment_blog_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.version = OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER increment_blogs_version
+BEFORE UPDATE ON blogs
+FOR EACH ROW
+EXECUTE FUNCTION increment_blog_version();
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS increment_blogs_version ON blogs;
+DROP FUNCTION IF EXISTS increment_blog_version();
+
+ALTER TABLE blogs DROP COLUMN IF EXISTS version;
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/shoet/blog/internal/blogfile"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/usecase/export_blogs"
+	"github.com/spf13/cobra"
+)
+
+var exportDir string
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all blogs as markdown files with front matter",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg, err := config.NewConfig()
+		if err != nil {
+			log.Fatalf("failed to create config: %v", err)
+		}
+		db, err := infrastructure.NewDBPostgres(ctx, cfg)
+		if err != nil {
+			fmt.Printf("failed to create db: %v", err)
+			os.Exit(1)
+		}
+		c := clocker.RealClocker{}
+		blogRepo := repository.NewBlogRepository(&c)
+		blogs, err := export_blogs.NewUsecase(db, blogRepo).Run(ctx)
+		if err != nil {
+			fmt.Printf("failed to export blogs: %v", err)
+			os.Exit(1)
+		}
+		if err := os.MkdirAll(exportDir, 0o755); err != nil {
+			fmt.Printf("failed to create directory: %v", err)
+			os.Exit(1)
+		}
+		for _, blog := range blogs {
+			b, err := blogfile.Marshal(blog)
+			if err != nil {
+				fmt.Printf("failed to marshal blog %d: %v", blog.Id, err)
+				os.Exit(1)
+			}
+			path := filepath.Join(exportDir, blogfile.FileName(blog))
+			if err := os.WriteFile(path, b, 0o644); err != nil {
+				fmt.Printf("failed to write file: %v", err)
+				os.Exit(1)
+			}
+		}
+		fmt.Printf("exported blogs: %d blogs to %s\n", len(blogs), exportDir)
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportDir, "dir", "blogs", "directory to write markdown files")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/shoet/blog/internal/blogfile"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/infrastructure/services/blog_service"
+	"github.com/shoet/blog/internal/infrastructure/services/related_blogs_cache_service"
+	"github.com/shoet/blog/internal/infrastructure/services/sitemap_cache_service"
+	"github.com/shoet/blog/internal/usecase/create_blog"
+	"github.com/shoet/blog/internal/usecase/import_blogs"
+	"github.com/shoet/blog/internal/usecase/put_blog"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importDir      string
+	importAuthorId int64
+	importDryRun   bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import blogs from markdown files with front matter",
+	Long: "Import blogs from markdown files written by export.\n" +
+		"Blogs are updated when the id or slug matches, otherwise created by the author of --author-id.",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		blogs, err := readBlogFiles(importDir)
+		if err != nil {
+			fmt.Printf("failed to read blog files: %v", err)
+			os.Exit(1)
+		}
+		cfg, err := config.NewConfig()
+		if err != nil {
+			log.Fatalf("failed to create config: %v", err)
+		}
+		db, err := infrastructure.NewDBPostgres(ctx, cfg)
+		if err != nil {
+			fmt.Printf("failed to create db: %v", err)
+			os.Exit(1)
+		}
+		usecase, err := newImportBlogsUsecase(ctx, cfg, db)
+		if err != nil {
+			fmt.Printf("failed to create usecase: %v", err)
+			os.Exit(1)
+		}
+		results, err := usecase.Run(ctx, blogs, models.UserId(importAuthorId), importDryRun)
+		counts := map[import_blogs.Action]int{}
+		for _, result := range results {
+			counts[result.Action]++
+			if result.Action != import_blogs.ActionUnchanged {
+				fmt.Printf("%s: %s\n", result.Action, result.Blog.Title)
+			}
+		}
+		if err != nil {
+			fmt.Printf("failed to import blogs: %v", err)
+			os.Exit(1)
+		}
+		prefix := ""
+		if importDryRun {
+			prefix = "(dry run) "
+		}
+		fmt.Printf("%simported blogs: %d created, %d updated, %d unchanged\n", prefix,
+			counts[import_blogs.ActionCreate], counts[import_blogs.ActionUpdate], counts[import_blogs.ActionUnchanged])
+	},
+}
+
+// newImportBlogsUsecase は、ブログの作成・更新時にキャッシュを破棄するimport_blogsのユースケースを生成する
+func newImportBlogsUsecase(
+	ctx context.Context, cfg *config.Config, db infrastructure.DB,
+) (*import_blogs.Usecase, error) {
+	kvs, err := infrastructure.NewRedisKVS(
+		ctx,
+		cfg.KVSHost,
+		cfg.KVSPort,
+		cfg.KVSUser,
+		cfg.KVSPass,
+		cfg.JWTExpiresInSec,
+		cfg.KVSTlsEnabled,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create redis kvs: %w", err)
+	}
+	c := clocker.RealClocker{}
+	blogRepo := repository.NewBlogRepository(&c)
+	blogRevisionRepo := repository.NewBlogRevisionRepository(&c)
+	sitemapCache := sitemap_cache_service.NewSitemapCacheService(
+		kvs, time.Duration(cfg.SitemapCacheExpiresInSec)*time.Second)
+	relatedBlogsCache := related_blogs_cache_service.NewRelatedBlogsCacheService(
+		kvs, time.Duration(cfg.RelatedCacheExpiresInSec)*time.Second)
+
+	return import_blogs.NewUsecase(
+		db,
+		blogRepo,
+		create_blog.NewUsecase(db, blogRepo, blogRevisionRepo, blog_service.NewBlogService(), sitemapCache),
+		put_blog.NewUsecase(db, blogRepo, blogRevisionRepo, sitemapCache, relatedBlogsCache),
+	), nil
+}
+
+// readBlogFiles は、ディレクトリ内のMarkdownファイルをファイル名順に読み込む
+func readBlogFiles(dir string) ([]*models.Blog, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+blogfile.Ext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to glob: %w", err)
+	}
+	sort.Strings(paths)
+	blogs := make([]*models.Blog, 0, len(paths))
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		blog, err := blogfile.Unmarshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+		}
+		blogs = append(blogs, blog)
+	}
+	return blogs, nil
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importDir, "dir", "blogs", "directory to read markdown files")
+	importCmd.Flags().Int64Var(&importAuthorId, "author-id", 0, "author of blogs to create")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "show changes without importing")
+	rootCmd.AddCommand(importCmd)
+}
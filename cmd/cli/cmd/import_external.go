@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/external"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/adapter"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/usecase/import_blogs"
+	"github.com/shoet/blog/internal/usecase/import_external_blogs"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importExternalFormat    string
+	importExternalPath      string
+	importExternalImagesDir string
+	importExternalAuthorId  int64
+	importExternalAuthors   map[string]int64
+	importExternalDryRun    bool
+)
+
+var importExternalCmd = &cobra.Command{
+	Use:   "import-external",
+	Short: "Import blogs from WordPress (WXR), Hugo or Zenn",
+	Long: "Import blogs from a WordPress export file (--format=wxr --path=export.xml),\n" +
+		"a Hugo site (--format=hugo --path=site) or a Zenn repository (--format=zenn --path=repo).\n" +
+		"Authors are mapped by --author name=id, others are created by the author of --author-id.\n" +
+		"Images in the local directory next to the export are uploaded and their URLs are rewritten:\n" +
+		"  wxr:  <dir of export>/uploads (paths under wp-content/uploads)\n" +
+		"  hugo: <site>/static, and the page bundle directory for relative paths\n" +
+		"  zenn: <repo> (paths such as /images/...)\n" +
+		"Anything skipped is reported at the end.",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		source, err := external.Read(
+			external.Format(importExternalFormat), importExternalPath, importExternalImagesDir, time.Now())
+		if err != nil {
+			fmt.Printf("failed to read %s: %v", importExternalFormat, err)
+			os.Exit(1)
+		}
+		cfg, err := config.NewConfig()
+		if err != nil {
+			log.Fatalf("failed to create config: %v", err)
+		}
+		db, err := infrastructure.NewDBPostgres(ctx, cfg)
+		if err != nil {
+			fmt.Printf("failed to create db: %v", err)
+			os.Exit(1)
+		}
+		importBlogs, err := newImportBlogsUsecase(ctx, cfg, db)
+		if err != nil {
+			fmt.Printf("failed to create usecase: %v", err)
+			os.Exit(1)
+		}
+		s3Adapter, err := adapter.NewS3Adapter(cfg)
+		if err != nil {
+			fmt.Printf("failed to create s3 adapter: %v", err)
+			os.Exit(1)
+		}
+
+		authors := make(map[string]models.UserId, len(importExternalAuthors))
+		for name, id := range importExternalAuthors {
+			authors[name] = models.UserId(id)
+		}
+		usecase := import_external_blogs.NewUsecase(
+			cfg, repository.NewFileRepository(cfg, s3Adapter), importBlogs)
+		report, err := usecase.Run(ctx, &import_external_blogs.Input{
+			Source:          source,
+			Authors:         authors,
+			DefaultAuthorId: models.UserId(importExternalAuthorId),
+			DryRun:          importExternalDryRun,
+		})
+		counts := map[import_blogs.Action]int{}
+		for _, result := range report.Results {
+			counts[result.Action]++
+			if result.Action != import_blogs.ActionUnchanged {
+				fmt.Printf("%s: %s\n", result.Action, result.Blog.Title)
+			}
+		}
+		if len(report.Skips) > 0 {
+			fmt.Printf("skipped:\n")
+			for _, skip := range report.Skips {
+				fmt.Printf("  %s: %s\n", skip.Source, skip.Reason)
+			}
+		}
+		if err != nil {
+			fmt.Printf("failed to import blogs: %v", err)
+			os.Exit(1)
+		}
+		prefix := ""
+		if importExternalDryRun {
+			prefix = "(dry run) "
+		}
+		fmt.Printf("%simported blogs: %d created, %d updated, %d unchanged, %d skipped, %d images uploaded\n", prefix,
+			counts[import_blogs.ActionCreate], counts[import_blogs.ActionUpdate], counts[import_blogs.ActionUnchanged],
+			len(report.Skips), report.UploadedImages)
+	},
+}
+
+func init() {
+	importExternalCmd.Flags().StringVar(&importExternalFormat, "format", "", "format of source: wxr, hugo or zenn")
+	importExternalCmd.Flags().StringVar(&importExternalPath, "path", "", "export file (wxr) or root directory (hugo, zenn)")
+	importExternalCmd.Flags().StringVar(&importExternalImagesDir, "images-dir", "", "directory to find images (default depends on format)")
+	importExternalCmd.Flags().Int64Var(&importExternalAuthorId, "author-id", 0, "author of blogs whose author is not mapped")
+	importExternalCmd.Flags().StringToInt64Var(&importExternalAuthors, "author", nil, "map author name of source to user id (name=id)")
+	importExternalCmd.Flags().BoolVar(&importExternalDryRun, "dry-run", false, "show changes without importing or uploading")
+	_ = importExternalCmd.MarkFlagRequired("format")
+	_ = importExternalCmd.MarkFlagRequired("path")
+	rootCmd.AddCommand(importExternalCmd)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/usecase/rebuild_blog_renderings"
+	"github.com/spf13/cobra"
+)
+
+var rebuildBlogRenderingsCmd = &cobra.Command{
+	Use:   "rebuild-blog-renderings",
+	Short: "Re-render markdown content of all blogs",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		cfg, err := config.NewConfig()
+		if err != nil {
+			log.Fatalf("failed to create config: %v", err)
+		}
+		db, err := infrastructure.NewDBPostgres(ctx, cfg)
+		if err != nil {
+			fmt.Printf("failed to create db: %v", err)
+			os.Exit(1)
+		}
+		c := clocker.RealClocker{}
+		blogRepo := repository.NewBlogRepository(&c)
+		count, err := rebuild_blog_renderings.NewUsecase(db, blogRepo).Run(ctx)
+		if err != nil {
+			fmt.Printf("failed to rebuild blog renderings: %v", err)
+			os.Exit(1)
+		}
+		fmt.Printf("rebuilt blog renderings: %d blogs\n", count)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rebuildBlogRenderingsCmd)
+}
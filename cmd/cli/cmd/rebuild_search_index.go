@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/usecase/rebuild_search_index"
+	"github.com/spf13/cobra"
+)
+
+var rebuildSearchIndexCmd = &cobra.Command{
+	Use:   "rebuild-search-index",
+	Short: "Rebuild full-text search index of blogs",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		cfg, err := config.NewConfig()
+		if err != nil {
+			return fmt.Errorf("failed to create config: %w", err)
+		}
+		db, err := infrastructure.NewDBPostgres(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create db: %w", err)
+		}
+		c := clocker.RealClocker{}
+		blogRepo := repository.NewBlogRepository(&c)
+		count, err := rebuild_search_index.NewUsecase(db, blogRepo).Run(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild search index: %w", err)
+		}
+		fmt.Printf("rebuilt search index: %d blogs\n", count)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rebuildSearchIndexCmd)
+}
@@ -0,0 +1,99 @@
+package blogfile
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Ext は、ブログを書き出すファイルの拡張子
+const Ext = ".md"
+
+const delimiter = "---\n"
+
+var ErrInvalidFormat = fmt.Errorf("invalid blog file format")
+
+// frontMatter は、ファイル先頭のYAMLで表すブログの本文以外の項目
+type frontMatter struct {
+	Id                     models.BlogId `yaml:"id"`
+	Slug                   string        `yaml:"slug"`
+	Title                  string        `yaml:"title"`
+	Description            string        `yaml:"description"`
+	Tags                   []string      `yaml:"tags"`
+	IsPublic               bool          `yaml:"is_public"`
+	Created                uint          `yaml:"created"`
+	Modified               uint          `yaml:"modified"`
+	ThumbnailImageFileName string        `yaml:"thumbnail,omitempty"`
+}
+
+// FileName は、ブログを書き出すファイル名を返す
+// スラッグはブログ間で重複しないため、ファイル名に使用する
+func FileName(blog *models.Blog) string {
+	return blog.Slug + Ext
+}
+
+// Marshal は、ブログをYAMLのフロントマターと本文のMarkdownからなるファイルの内容に変換する
+func Marshal(blog *models.Blog) ([]byte, error) {
+	tags := blog.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	meta, err := yaml.Marshal(&frontMatter{
+		Id:                     blog.Id,
+		Slug:                   blog.Slug,
+		Title:                  blog.Title,
+		Description:            blog.Description,
+		Tags:                   tags,
+		IsPublic:               blog.IsPublic,
+		Created:                blog.Created,
+		Modified:               blog.Modified,
+		ThumbnailImageFileName: blog.ThumbnailImageFileName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal front matter: %w", err)
+	}
+	var buf bytes.Buffer
+	buf.WriteString(delimiter)
+	buf.Write(meta)
+	buf.WriteString(delimiter)
+	buf.WriteString("\n")
+	buf.WriteString(blog.Content)
+	return buf.Bytes(), nil
+}
+
+// Unmarshal は、Marshalで変換したファイルの内容をブログに変換する
+// フロントマターがない場合や解釈できない場合はErrInvalidFormatを返す
+func Unmarshal(b []byte) (*models.Blog, error) {
+	if !bytes.HasPrefix(b, []byte(delimiter)) {
+		return nil, fmt.Errorf("%w: front matter is not found", ErrInvalidFormat)
+	}
+	rest := b[len(delimiter):]
+	end := bytes.Index(rest, []byte("\n"+delimiter))
+	if end < 0 {
+		return nil, fmt.Errorf("%w: front matter is not closed", ErrInvalidFormat)
+	}
+	var meta frontMatter
+	if err := yaml.Unmarshal(rest[:end+1], &meta); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	// フロントマターと本文の間の空行は本文に含めない
+	content := bytes.TrimPrefix(rest[end+1+len(delimiter):], []byte("\n"))
+	tags := meta.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return &models.Blog{
+		Id:                     meta.Id,
+		Slug:                   meta.Slug,
+		Title:                  meta.Title,
+		Description:            meta.Description,
+		Content:                string(content),
+		Tags:                   tags,
+		IsPublic:               meta.IsPublic,
+		Created:                meta.Created,
+		Modified:               meta.Modified,
+		ThumbnailImageFileName: meta.ThumbnailImageFileName,
+	}, nil
+}
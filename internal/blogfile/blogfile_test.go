@@ -0,0 +1,110 @@
+package blogfile_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/blogfile"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+func Test_MarshalUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		blog *models.Blog
+	}{
+		{
+			name: "すべての項目",
+			blog: &models.Blog{
+				Id:                     1,
+				Slug:                   "hello-world",
+				Title:                  "タイトル: コロンを含む",
+				Description:            "概要",
+				Content:                "# 見出し\n\n---\n\n本文\n",
+				Tags:                   []string{"go", "aws"},
+				IsPublic:               true,
+				Created:                1704034800,
+				Modified:               1706713200,
+				ThumbnailImageFileName: "image.png",
+			},
+		},
+		{
+			name: "タグと本文がない",
+			blog: &models.Blog{
+				Id:    2,
+				Slug:  "2",
+				Title: "title",
+				Tags:  []string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := blogfile.Marshal(tt.blog)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+			got, err := blogfile.Unmarshal(b)
+			if err != nil {
+				t.Fatalf("failed to unmarshal: %v\n%s", err, b)
+			}
+			if diff := cmp.Diff(got, tt.blog); diff != "" {
+				t.Errorf("differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Marshal(t *testing.T) {
+	b, err := blogfile.Marshal(&models.Blog{
+		Id: 1, Slug: "hello", Title: "hello", Tags: []string{"go"}, Content: "本文\n",
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	want := strings.Join([]string{
+		"---",
+		"id: 1",
+		"slug: hello",
+		"title: hello",
+		`description: ""`,
+		"tags:",
+		"    - go",
+		"is_public: false",
+		"created: 0",
+		"modified: 0",
+		"---",
+		"",
+		"本文",
+		"",
+	}, "\n")
+	if diff := cmp.Diff(string(b), want); diff != "" {
+		t.Errorf("differs: (-got +want)\n%s", diff)
+	}
+}
+
+func Test_Unmarshal_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "フロントマターがない", input: "# 本文"},
+		{name: "フロントマターが閉じていない", input: "---\nid: 1\n本文"},
+		{name: "YAMLとして解釈できない", input: "---\nid: [1\n---\n本文"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := blogfile.Unmarshal([]byte(tt.input)); !errors.Is(err, blogfile.ErrInvalidFormat) {
+				t.Errorf("got: %v, want: %v", err, blogfile.ErrInvalidFormat)
+			}
+		})
+	}
+}
+
+func Test_FileName(t *testing.T) {
+	if got := blogfile.FileName(&models.Blog{Id: 1, Slug: "hello-world"}); got != "hello-world.md" {
+		t.Errorf("got: %s, want: hello-world.md", got)
+	}
+}
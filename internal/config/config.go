@@ -33,8 +33,26 @@ type Config struct {
 	JWTExpiresInSec             int    `env:"JWT_EXPIRES_IN_SEC" envDefault:"86400"`
 	CORSWhiteList               string `env:"CORS_WHITE_LIST"`
 	SiteDomain                  string `env:"SITE_DOMAIN"`
+	SiteTimezone                string `env:"BLOG_SITE_TIMEZONE" envDefault:"Asia/Tokyo"`
 	CdnDomain                   string `env:"CDN_DOMAIN"`
 	GitHubPersonalAccessToken   string `env:"GITHUB_PERSONAL_ACCESS_TOKEN"`
+	ScheduledPublishIntervalSec int    `env:"BLOG_SCHEDULED_PUBLISH_INTERVAL_SEC" envDefault:"60"`
+	PreviewTokenSecret          string `env:"BLOG_PREVIEW_TOKEN_SECRET"`
+	PreviewTokenExpiresInSec    int    `env:"BLOG_PREVIEW_TOKEN_EXPIRES_IN_SEC" envDefault:"604800"`
+	BlogCursorSecret            string `env:"BLOG_CURSOR_SECRET"`
+	FeedTitle                   string `env:"BLOG_FEED_TITLE" envDefault:"blog"`
+	FeedDescription             string `env:"BLOG_FEED_DESCRIPTION"`
+	SitemapCacheExpiresInSec    int    `env:"BLOG_SITEMAP_CACHE_EXPIRES_IN_SEC" envDefault:"3600"`
+	RelatedCacheExpiresInSec    int    `env:"BLOG_RELATED_CACHE_EXPIRES_IN_SEC" envDefault:"3600"`
+	ViewFlushIntervalSec        int    `env:"BLOG_VIEW_FLUSH_INTERVAL_SEC" envDefault:"300"`
+	ReactionEmojis              string `env:"BLOG_REACTION_EMOJIS" envDefault:"👍,❤️,🎉,😂,🤔"`
+	TrashRetentionDays          int    `env:"BLOG_TRASH_RETENTION_DAYS" envDefault:"30"`
+	TrashPurgeIntervalSec       int    `env:"BLOG_TRASH_PURGE_INTERVAL_SEC" envDefault:"3600"`
+	CacheControlBlogList        string `env:"BLOG_CACHE_CONTROL_BLOG_LIST" envDefault:"public, max-age=60"`
+	CacheControlBlogDetail      string `env:"BLOG_CACHE_CONTROL_BLOG_DETAIL" envDefault:"public, max-age=60"`
+	CacheControlTags            string `env:"BLOG_CACHE_CONTROL_TAGS" envDefault:"public, max-age=300"`
+	CacheControlComments        string `env:"BLOG_CACHE_CONTROL_COMMENTS" envDefault:"public, no-cache"`
+	CacheControlPrivacyPolicy   string `env:"BLOG_CACHE_CONTROL_PRIVACY_POLICY" envDefault:"public, max-age=3600"`
 }
 
 func NewConfig() (*Config, error) {
@@ -1,5 +1,14 @@
 package config
 
 const (
-	KVS_HANDLENAME_SALT = "handlename.salt.%d" // 末尾はBlogID
+	KVS_HANDLENAME_SALT        = "handlename.salt.%d" // 末尾はBlogID
+	KVS_SCHEDULED_PUBLISH_LOCK = "scheduled_publish.lock"
+	KVS_PREVIEW_TOKENS         = "preview_token_set.%d" // 末尾はBlogID、トークンIDをフィールドとするハッシュ
+	KVS_SITEMAP                = "sitemap"
+	KVS_RELATED_BLOGS          = "related_blogs.%d" // 末尾はBlogID
+	KVS_VIEW_SALT              = "view.salt.%s"     // 末尾は日付
+	KVS_VIEW_VISITOR           = "view.visitor.%s"  // 末尾は訪問者とBlogIDのハッシュ
+	KVS_VIEW_COUNTS            = "view.counts"
+	KVS_VIEW_FLUSH_LOCK        = "view.flush.lock"
+	KVS_TRASH_PURGE_LOCK       = "trash.purge.lock"
 )
@@ -0,0 +1,202 @@
+package external
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/slug"
+)
+
+// Format は、取り込み元のサービスの形式
+type Format string
+
+const (
+	FormatWXR  Format = "wxr"
+	FormatHugo Format = "hugo"
+	FormatZenn Format = "zenn"
+)
+
+var ErrUnknownFormat = fmt.Errorf("unknown format")
+
+// Post は、取り込み元から読み込んだ1件の記事
+type Post struct {
+	// Source は、レポートに表示する取り込み元での記事の位置
+	Source string
+	// Author は、取り込み元での著者名(指定されていない場合は空)
+	Author string
+	// Blog は、記事を変換したブログ(著者は未設定)
+	Blog *models.Blog
+	// Images は、本文中の画像のURLと、対応するローカルの画像ファイルのパス
+	Images map[string]string
+}
+
+// Skip は、取り込まなかった記事や本文の一部と、その理由
+type Skip struct {
+	Source string
+	Reason string
+}
+
+// Result は、取り込み元から読み込んだ記事と、読み込まなかったものの一覧
+type Result struct {
+	Posts []*Post
+	Skips []*Skip
+}
+
+func (r *Result) skip(source string, format string, args ...any) {
+	r.Skips = append(r.Skips, &Skip{Source: source, Reason: fmt.Sprintf(format, args...)})
+}
+
+// Read は、形式に応じて取り込み元の記事を読み込む
+// pathはWXRの場合はエクスポートしたXMLファイル、Hugoの場合はサイトのルート、Zennの場合はリポジトリのルート
+// imagesDirは本文中の画像を探すディレクトリで、空の場合は形式ごとの既定のディレクトリを使う
+// nowより後に公開される記事は予約公開とする
+func Read(format Format, path string, imagesDir string, now time.Time) (*Result, error) {
+	switch format {
+	case FormatWXR:
+		if imagesDir == "" {
+			imagesDir = filepath.Join(filepath.Dir(path), "uploads")
+		}
+		return ReadWXR(path, imagesDir, now)
+	case FormatHugo:
+		if imagesDir == "" {
+			imagesDir = filepath.Join(path, "static")
+		}
+		return ReadHugo(path, imagesDir, now)
+	case FormatZenn:
+		if imagesDir == "" {
+			imagesDir = path
+		}
+		return ReadZenn(path, imagesDir, now)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// setPublishState は、公開状態と公開日時からブログの公開・予約公開を設定する
+func setPublishState(blog *models.Blog, public bool, publishAt time.Time, now time.Time) {
+	if !public {
+		return
+	}
+	if !publishAt.IsZero() && publishAt.After(now) {
+		t := uint(publishAt.Unix())
+		blog.PublishAt = &t
+		return
+	}
+	blog.IsPublic = true
+}
+
+// unixTime は、日時をブログの作成日時に変換する
+// 日時がない場合は、取り込み時の日時とするため0を返す
+func unixTime(t time.Time) uint {
+	if t.IsZero() || t.Unix() < 0 {
+		return 0
+	}
+	return uint(t.Unix())
+}
+
+// normalizeSlug は、取り込み元のスラッグをこのブログで使用できるスラッグに変換する
+// パーセントエンコードされたスラッグは復号し、変換できない場合はタイトルから生成する
+func normalizeSlug(s string, title string) string {
+	if decoded, err := url.PathUnescape(s); err == nil {
+		s = decoded
+	}
+	if normalized := slug.FromTitle(s); normalized != "" {
+		return normalized
+	}
+	return slug.FromTitle(title)
+}
+
+// mergeTags は、カテゴリとタグを重複を除いて1つのタグの一覧にする
+func mergeTags(lists ...[]string) []string {
+	tags := []string{}
+	seen := map[string]bool{}
+	for _, list := range lists {
+		for _, tag := range list {
+			tag = strings.TrimSpace(tag)
+			if tag == "" || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+var (
+	markdownImagePattern = regexp.MustCompile(`!\[([^\]]*)\]\(\s*<?([^)\s>]+)>?((?:\s+"[^"]*")?)[^)]*\)`)
+	htmlImagePattern     = regexp.MustCompile(`(<img\b[^>]*\bsrc=["'])([^"']+)(["'])`)
+)
+
+// RewriteImages は、本文中の画像のURLをreplaceで置き換える
+// replaceがfalseを返した画像は変更しない
+// Zennの画像の幅の指定(=250x)など、Markdownで解釈できない指定は取り除く
+func RewriteImages(content string, replace func(src string) (string, bool)) string {
+	content = markdownImagePattern.ReplaceAllStringFunc(content, func(s string) string {
+		m := markdownImagePattern.FindStringSubmatch(s)
+		src := m[2]
+		if replaced, ok := replace(src); ok {
+			src = replaced
+		}
+		return fmt.Sprintf("![%s](%s%s)", m[1], src, m[3])
+	})
+	return htmlImagePattern.ReplaceAllStringFunc(content, func(s string) string {
+		m := htmlImagePattern.FindStringSubmatch(s)
+		if replaced, ok := replace(m[2]); ok {
+			return m[1] + replaced + m[3]
+		}
+		return s
+	})
+}
+
+// collectImages は、本文中の画像のうちローカルのファイルに対応するものを集める
+// resolveはURLに対応するローカルのファイルの候補を返し、ローカルの画像でない場合はfalseを返す
+// 候補のファイルが存在しない画像はスキップとして記録する
+func collectImages(
+	result *Result, source string, content string, resolve func(src string) ([]string, bool),
+) map[string]string {
+	images := map[string]string{}
+	RewriteImages(content, func(src string) (string, bool) {
+		if _, ok := images[src]; ok {
+			return "", false
+		}
+		candidates, ok := resolve(src)
+		if !ok {
+			return "", false
+		}
+		for _, path := range candidates {
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				images[src] = path
+				return "", false
+			}
+		}
+		result.skip(source, "image %s is not found in local directory", src)
+		return "", false
+	})
+	return images
+}
+
+// localPath は、URLのパスをディレクトリ配下のファイルのパスに変換する
+// ディレクトリの外を指す場合はfalseを返す
+func localPath(dir string, urlPath string) (string, bool) {
+	if decoded, err := url.PathUnescape(urlPath); err == nil {
+		urlPath = decoded
+	}
+	rel := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(urlPath, "/")))
+	if rel == "." || !filepath.IsLocal(rel) {
+		return "", false
+	}
+	return filepath.Join(dir, rel), true
+}
+
+// isRemoteURL は、スキームやホストを含むURLかを判定する
+func isRemoteURL(src string) bool {
+	u, err := url.Parse(src)
+	return err != nil || u.Scheme != "" || u.Host != ""
+}
@@ -0,0 +1,405 @@
+package external_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/shoet/blog/internal/external"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+var now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// writeFiles は、ディレクトリにファイルを作成する
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+}
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func skipReasons(skips []*external.Skip) []string {
+	reasons := make([]string, 0, len(skips))
+	for _, s := range skips {
+		reasons = append(reasons, s.Source+": "+s.Reason)
+	}
+	return reasons
+}
+
+const wxr = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<item>
+		<title>Go言語入門</title>
+		<dc:creator><![CDATA[alice]]></dc:creator>
+		<content:encoded><![CDATA[<!-- wp:paragraph -->
+<p>Goは<strong>シンプル</strong>な<a href="https://go.dev">言語</a>です。</p>
+<!-- /wp:paragraph -->
+<h2>画像</h2>
+<p><img src="https://example.com/wp-content/uploads/2023/01/gopher-300x200.png" alt="gopher"></p>
+<p><img src="https://example.com/wp-content/uploads/2023/01/missing.png" alt=""></p>
+<ul><li>one</li><li>two</li></ul>
+<pre class="wp-block-code"><code class="language-go">fmt.Println("*")</code></pre>
+<iframe src="https://www.youtube.com/embed/xxx"></iframe>]]></content:encoded>
+		<excerpt:encoded><![CDATA[Goの入門]]></excerpt:encoded>
+		<wp:post_id>10</wp:post_id>
+		<wp:post_date>2023-01-02 12:00:00</wp:post_date>
+		<wp:post_date_gmt>2023-01-02 03:00:00</wp:post_date_gmt>
+		<wp:post_name>go-intro</wp:post_name>
+		<wp:status>publish</wp:status>
+		<wp:post_type>post</wp:post_type>
+		<category domain="category" nicename="programming"><![CDATA[プログラミング]]></category>
+		<category domain="category" nicename="uncategorized"><![CDATA[Uncategorized]]></category>
+		<category domain="post_tag" nicename="go"><![CDATA[Go]]></category>
+		<category domain="post_tag" nicename="programming"><![CDATA[プログラミング]]></category>
+	</item>
+	<item>
+		<title>予約投稿</title>
+		<dc:creator><![CDATA[bob]]></dc:creator>
+		<content:encoded><![CDATA[本文]]></content:encoded>
+		<excerpt:encoded><![CDATA[]]></excerpt:encoded>
+		<wp:post_id>11</wp:post_id>
+		<wp:post_date_gmt>2024-02-01 00:00:00</wp:post_date_gmt>
+		<wp:post_name>%e4%ba%88%e7%b4%84</wp:post_name>
+		<wp:status>future</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+	<item>
+		<title>下書き</title>
+		<dc:creator><![CDATA[alice]]></dc:creator>
+		<content:encoded><![CDATA[]]></content:encoded>
+		<wp:post_id>12</wp:post_id>
+		<wp:post_date>2023-03-01 09:00:00</wp:post_date>
+		<wp:post_date_gmt>0000-00-00 00:00:00</wp:post_date_gmt>
+		<wp:post_name></wp:post_name>
+		<wp:status>draft</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+	<item>
+		<title>削除済み</title>
+		<wp:post_id>13</wp:post_id>
+		<wp:status>trash</wp:status>
+		<wp:post_type>post</wp:post_type>
+	</item>
+	<item>
+		<title>About</title>
+		<wp:post_id>14</wp:post_id>
+		<wp:status>publish</wp:status>
+		<wp:post_type>page</wp:post_type>
+	</item>
+	<item>
+		<title>gopher</title>
+		<wp:post_id>15</wp:post_id>
+		<wp:status>inherit</wp:status>
+		<wp:post_type>attachment</wp:post_type>
+	</item>
+</channel>
+</rss>
+`
+
+func Test_ReadWXR(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"export.xml":                     wxr,
+		"uploads/2023/01/gopher.png":     "png",
+		"uploads/2023/01/unrelated.jpeg": "jpeg",
+	})
+
+	result, err := external.Read(external.FormatWXR, filepath.Join(dir, "export.xml"), "", now)
+	if err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+
+	want := []*external.Post{
+		{
+			Source: `export.xml (post_id=10) "Go言語入門"`,
+			Author: "alice",
+			Blog: &models.Blog{
+				Title:       "Go言語入門",
+				Description: "Goの入門",
+				Content: strings.Join([]string{
+					"Goは**シンプル**な[言語](https://go.dev)です。",
+					"## 画像",
+					"![gopher](https://example.com/wp-content/uploads/2023/01/gopher-300x200.png)",
+					"![](https://example.com/wp-content/uploads/2023/01/missing.png)",
+					"- one\n- two",
+					"```go\nfmt.Println(\"*\")\n```",
+				}, "\n\n"),
+				Tags:     []string{"プログラミング", "Go"},
+				Created:  uint(time.Date(2023, 1, 2, 3, 0, 0, 0, time.UTC).Unix()),
+				IsPublic: true,
+				Slug:     "go-intro",
+			},
+			Images: map[string]string{
+				"https://example.com/wp-content/uploads/2023/01/gopher-300x200.png": filepath.Join(dir, "uploads", "2023", "01", "gopher.png"),
+			},
+		},
+		{
+			Source: `export.xml (post_id=11) "予約投稿"`,
+			Author: "bob",
+			Blog: &models.Blog{
+				Title:     "予約投稿",
+				Content:   "本文",
+				Tags:      []string{},
+				Created:   uint(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC).Unix()),
+				PublishAt: uintPtr(uint(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC).Unix())),
+				Slug:      "post-11",
+			},
+			Images: map[string]string{},
+		},
+		{
+			Source: `export.xml (post_id=12) "下書き"`,
+			Author: "alice",
+			Blog: &models.Blog{
+				Title:   "下書き",
+				Tags:    []string{},
+				Created: uint(time.Date(2023, 3, 1, 9, 0, 0, 0, time.UTC).Unix()),
+				Slug:    "post-12",
+			},
+			Images: map[string]string{},
+		},
+	}
+	if diff := cmp.Diff(result.Posts, want); diff != "" {
+		t.Errorf("differs: (-got +want)\n%s", diff)
+	}
+
+	wantSkips := []string{
+		`export.xml (post_id=10) "Go言語入門": <iframe> is removed from content`,
+		`export.xml (post_id=10) "Go言語入門": image https://example.com/wp-content/uploads/2023/01/missing.png is not found in local directory`,
+		`export.xml (post_id=13) "削除済み": status trash is not supported`,
+		`export.xml (post_id=14) "About": post type page is not supported`,
+	}
+	if diff := cmp.Diff(skipReasons(result.Skips), wantSkips); diff != "" {
+		t.Errorf("differs: (-got +want)\n%s", diff)
+	}
+}
+
+func Test_ReadHugo(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"content/_index.md": "---\ntitle: Home\n---\n",
+		"content/posts/toml-post.md": `+++
+title = "TOMLの記事"
+date = 2023-05-01T10:00:00+09:00
+draft = false
+tags = ["go", "hugo"]
+categories = [
+  "tech", # カテゴリ
+]
+author = "alice"
+description = 'TOMLで書いた記事'
+
+[params]
+toc = true
++++
+
+![logo](/img/logo.png "ロゴ")
+
+{{< tweet user="x" id="1" >}}
+`,
+		"content/posts/bundle/index.md": `---
+title: バンドル
+date: 2023-06-01
+draft: true
+slug: My_Bundle
+tags: [go]
+authors: [bob]
+summary: 要約
+---
+{{< figure src="photo.jpg" caption="写真" >}}
+`,
+		"content/posts/bundle/photo.jpg": "jpg",
+		"content/posts/future.md":        "---\ntitle: 未来\ndate: 2024-03-01\n---\n本文\n",
+		"content/posts/broken.md":        "---\ntitle: [\n---\n",
+		"static/img/logo.png":            "png",
+	})
+
+	result, err := external.Read(external.FormatHugo, dir, "", now)
+	if err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+
+	march := uint(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC).Unix())
+	want := []*external.Post{
+		{
+			Source: filepath.Join("content", "posts", "bundle", "index.md"),
+			Author: "bob",
+			Blog: &models.Blog{
+				Title:       "バンドル",
+				Description: "要約",
+				Content:     "![](photo.jpg)\n\n写真\n",
+				Tags:        []string{"go"},
+				Created:     uint(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC).Unix()),
+				Slug:        "my-bundle",
+			},
+			Images: map[string]string{
+				"photo.jpg": filepath.Join(dir, "content", "posts", "bundle", "photo.jpg"),
+			},
+		},
+		{
+			Source: filepath.Join("content", "posts", "future.md"),
+			Blog: &models.Blog{
+				Title:     "未来",
+				Content:   "本文\n",
+				Tags:      []string{},
+				Created:   march,
+				PublishAt: &march,
+				Slug:      "future",
+			},
+			Images: map[string]string{},
+		},
+		{
+			Source: filepath.Join("content", "posts", "toml-post.md"),
+			Author: "alice",
+			Blog: &models.Blog{
+				Title:       "TOMLの記事",
+				Description: "TOMLで書いた記事",
+				Content:     "![logo](/img/logo.png \"ロゴ\")\n\n{{< tweet user=\"x\" id=\"1\" >}}\n",
+				Tags:        []string{"tech", "go", "hugo"},
+				Created:     uint(time.Date(2023, 5, 1, 1, 0, 0, 0, time.UTC).Unix()),
+				IsPublic:    true,
+				Slug:        "toml-post",
+			},
+			Images: map[string]string{
+				"/img/logo.png": filepath.Join(dir, "static", "img", "logo.png"),
+			},
+		},
+	}
+	if diff := cmp.Diff(result.Posts, want); diff != "" {
+		t.Errorf("differs: (-got +want)\n%s", diff)
+	}
+
+	gotSkips := skipReasons(result.Skips)
+	wantSkips := []string{
+		filepath.Join("content", "_index.md") + ": list page is not supported",
+		filepath.Join("content", "posts", "broken.md") + ": failed to parse front matter",
+		filepath.Join("content", "posts", "toml-post.md") + ": shortcode {{< tweet >}} is not converted",
+	}
+	if len(gotSkips) != len(wantSkips) {
+		t.Fatalf("unexpected skips: %v", gotSkips)
+	}
+	for i := range wantSkips {
+		if !strings.HasPrefix(gotSkips[i], wantSkips[i]) {
+			t.Errorf("got: %s, want prefix: %s", gotSkips[i], wantSkips[i])
+		}
+	}
+}
+
+func Test_ReadZenn(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"articles/go_generics_intro.md": `---
+title: "ジェネリクス入門"
+emoji: "🐹"
+type: "tech"
+topics: ["go", "generics"]
+published: true
+published_at: 2023-07-01 09:00
+---
+
+![](/images/go-generics/diagram.png =400x)
+
+:::message
+注意
+:::
+`,
+		"articles/draft-article.md":         "---\ntitle: \"下書き\"\ntopics: []\npublished: false\n---\n本文\n",
+		"images/go-generics/diagram.png":    "png",
+		"books/sample-book/config.yaml":     "title: book\n",
+		"books/sample-book/chapter-1.md":    "---\ntitle: chapter\n---\n",
+		"articles/images-not-article.txt":   "text",
+		"images/go-generics/unused-img.png": "png",
+	})
+
+	result, err := external.Read(external.FormatZenn, dir, "", now)
+	if err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+
+	want := []*external.Post{
+		{
+			Source: filepath.Join("articles", "draft-article.md"),
+			Blog: &models.Blog{
+				Title:   "下書き",
+				Content: "本文\n",
+				Tags:    []string{},
+				Slug:    "draft-article",
+			},
+			Images: map[string]string{},
+		},
+		{
+			Source: filepath.Join("articles", "go_generics_intro.md"),
+			Blog: &models.Blog{
+				Title:    "ジェネリクス入門",
+				Content:  "![](/images/go-generics/diagram.png =400x)\n\n:::message\n注意\n:::\n",
+				Tags:     []string{"go", "generics"},
+				Created:  uint(time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC).Unix()),
+				IsPublic: true,
+				Slug:     "go-generics-intro",
+			},
+			Images: map[string]string{
+				"/images/go-generics/diagram.png": filepath.Join(dir, "images", "go-generics", "diagram.png"),
+			},
+		},
+	}
+	if diff := cmp.Diff(result.Posts, want); diff != "" {
+		t.Errorf("differs: (-got +want)\n%s", diff)
+	}
+	wantSkips := []string{
+		filepath.Join("articles", "go_generics_intro.md") + ": block :::message is not converted",
+	}
+	if diff := cmp.Diff(skipReasons(result.Skips), wantSkips); diff != "" {
+		t.Errorf("differs: (-got +want)\n%s", diff)
+	}
+}
+
+func Test_Read_UnknownFormat(t *testing.T) {
+	if _, err := external.Read(external.Format("qiita"), t.TempDir(), "", now); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func Test_RewriteImages(t *testing.T) {
+	content := strings.Join([]string{
+		`![a](/images/a.png =250x)`,
+		`![b](b.png "タイトル")`,
+		`![c](https://example.com/c.png)`,
+		`<img src="/images/a.png" alt="a">`,
+	}, "\n")
+	replaced := map[string]string{
+		"/images/a.png": "https://cdn.example.com/content/a.png",
+		"b.png":         "https://cdn.example.com/content/b.png",
+	}
+	got := external.RewriteImages(content, func(src string) (string, bool) {
+		url, ok := replaced[src]
+		return url, ok
+	})
+	want := strings.Join([]string{
+		`![a](https://cdn.example.com/content/a.png)`,
+		`![b](https://cdn.example.com/content/b.png "タイトル")`,
+		`![c](https://example.com/c.png)`,
+		`<img src="https://cdn.example.com/content/a.png" alt="a">`,
+	}, "\n")
+	if diff := cmp.Diff(got, want, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("differs: (-got +want)\n%s", diff)
+	}
+}
@@ -0,0 +1,256 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatter は、Markdownのファイル先頭のメタデータ
+type frontMatter map[string]any
+
+// splitFrontMatter は、Markdownのファイルをフロントマターと本文に分ける
+// YAML(---)とTOML(+++)のフロントマターに対応し、フロントマターがない場合は空のフロントマターを返す
+func splitFrontMatter(b []byte) (frontMatter, string, error) {
+	b = bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n"))
+	for _, delimiter := range []string{"---", "+++"} {
+		if !bytes.HasPrefix(b, []byte(delimiter+"\n")) {
+			continue
+		}
+		rest := b[len(delimiter)+1:]
+		end := bytes.Index(rest, []byte("\n"+delimiter+"\n"))
+		if end < 0 {
+			if !bytes.HasSuffix(rest, []byte("\n"+delimiter)) {
+				return nil, "", fmt.Errorf("front matter is not closed")
+			}
+			end = len(rest) - len(delimiter) - 1
+		}
+		meta := frontMatter{}
+		var err error
+		if delimiter == "---" {
+			err = yaml.Unmarshal(rest[:end+1], &meta)
+		} else {
+			meta, err = parseTOML(string(rest[:end+1]))
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse front matter: %w", err)
+		}
+		content := ""
+		if end+1+len(delimiter)+1 <= len(rest) {
+			content = string(rest[end+1+len(delimiter)+1:])
+		}
+		return meta, strings.TrimLeft(content, "\n"), nil
+	}
+	return frontMatter{}, string(b), nil
+}
+
+// string は、最初に値が設定されているキーの文字列を返す
+func (m frontMatter) string(keys ...string) string {
+	for _, key := range keys {
+		switch v := m[key].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case []any:
+			// authorsなどの一覧は先頭を使う
+			if len(v) > 0 {
+				if s, ok := v[0].(string); ok && s != "" {
+					return s
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// bool は、キーの真偽値を返す
+// 設定されていない場合はdefaultValueを返す
+func (m frontMatter) bool(key string, defaultValue bool) bool {
+	switch v := m[key].(type) {
+	case bool:
+		return v
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+// strings は、キーの文字列の一覧を返す
+// 文字列が1つだけ設定されている場合は、その文字列のみの一覧とする
+func (m frontMatter) strings(key string) []string {
+	switch v := m[key].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// time は、最初に値が設定されているキーの日時を返す
+// タイムゾーンが指定されていない日時はlocの日時とする
+func (m frontMatter) time(loc *time.Location, keys ...string) (time.Time, error) {
+	for _, key := range keys {
+		switch v := m[key].(type) {
+		case time.Time:
+			return v, nil
+		case string:
+			if v == "" {
+				continue
+			}
+			return parseDate(v, loc)
+		}
+	}
+	return time.Time{}, nil
+}
+
+// parseDate は、フロントマターで使われる形式の日時を解釈する
+func parseDate(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %s", s)
+}
+
+// parseTOML は、TOMLのフロントマターを解釈する
+// フロントマターで使われる文字列・真偽値・数値・日時・それらの配列のみに対応し
+// テーブル内のキーは"テーブル名.キー"として扱う
+func parseTOML(s string) (frontMatter, error) {
+	meta := frontMatter{}
+	table := ""
+	lines := strings.Split(s, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && !strings.Contains(line, "=") {
+			table = strings.Trim(line, "[] ") + "."
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: key and value are not found", i+1)
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		value = stripTOMLComment(value)
+		// 複数行の配列は閉じるまで連結する
+		for strings.HasPrefix(value, "[") && !strings.HasSuffix(value, "]") && i+1 < len(lines) {
+			i++
+			value += " " + stripTOMLComment(lines[i])
+		}
+		v, err := parseTOMLValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		meta[table+key] = v
+	}
+	return meta, nil
+}
+
+// stripTOMLComment は、値の後ろのコメントを取り除く
+func stripTOMLComment(value string) string {
+	inString := rune(0)
+	for i, r := range value {
+		switch {
+		case inString != 0:
+			if r == inString && (i == 0 || value[i-1] != '\\') {
+				inString = 0
+			}
+		case r == '"' || r == '\'':
+			inString = r
+		case r == '#':
+			return strings.TrimSpace(value[:i])
+		}
+	}
+	return strings.TrimSpace(value)
+}
+
+func parseTOMLValue(value string) (any, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		return strconv.Unquote(value)
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return nil, fmt.Errorf("string is not closed: %s", value)
+		}
+		return value[1 : len(value)-1], nil
+	case strings.HasPrefix(value, "["):
+		if !strings.HasSuffix(value, "]") {
+			return nil, fmt.Errorf("array is not closed: %s", value)
+		}
+		values := []any{}
+		for _, e := range splitTOMLArray(value[1 : len(value)-1]) {
+			v, err := parseTOMLValue(e)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	case value == "true" || value == "false":
+		return value == "true", nil
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f, nil
+	}
+	// 日時は文字列として扱い、使用する際に解釈する
+	if _, err := parseDate(value, time.UTC); err == nil {
+		return value, nil
+	}
+	return nil, fmt.Errorf("unsupported value: %s", value)
+}
+
+// splitTOMLArray は、配列の要素を文字列の外のカンマで区切る
+func splitTOMLArray(s string) []string {
+	var elements []string
+	inString := rune(0)
+	start := 0
+	for i, r := range s {
+		switch {
+		case inString != 0:
+			if r == inString && (i == 0 || s[i-1] != '\\') {
+				inString = 0
+			}
+		case r == '"' || r == '\'':
+			inString = r
+		case r == ',':
+			elements = append(elements, s[start:i])
+			start = i + 1
+		}
+	}
+	elements = append(elements, s[start:])
+	values := make([]string, 0, len(elements))
+	for _, e := range elements {
+		if e = strings.TrimSpace(e); e != "" {
+			values = append(values, e)
+		}
+	}
+	return values
+}
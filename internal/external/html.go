@@ -0,0 +1,278 @@
+package external
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+	spacesPattern     = regexp.MustCompile(`[ \t\r\f]+`)
+	markdownEscaper   = strings.NewReplacer(
+		`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`,
+	)
+	codeLanguagePattern = regexp.MustCompile(`(?:^|\s)(?:language|lang)-(\S+)`)
+	paragraphPattern    = regexp.MustCompile(`\n[ \t]*\n\s*`)
+	lineBreakPattern    = regexp.MustCompile(` ?\n ?`)
+)
+
+// htmlToMarkdown は、HTMLの本文をMarkdownに変換する
+// 本文のMarkdownは生のHTMLを出力しないため、変換できない埋め込み(iframeなど)は取り除き
+// 取り除いた要素名を返す
+func htmlToMarkdown(s string) (string, []string, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{
+		Type: html.ElementNode, Data: "body", DataAtom: atom.Body,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse html: %w", err)
+	}
+	c := &htmlConverter{}
+	var sb strings.Builder
+	for _, n := range nodes {
+		sb.WriteString(c.convert(n))
+	}
+	return cleanMarkdown(sb.String()), c.removed, nil
+}
+
+// cleanMarkdown は、連続する空行と前後の空白を取り除く
+func cleanMarkdown(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		// 行末の2つの空白は改行として残す
+		if strings.HasSuffix(line, "  ") && strings.TrimSpace(line) != "" {
+			lines[i] = strings.TrimRight(line, " ") + "  "
+			continue
+		}
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimSpace(blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+type htmlConverter struct {
+	removed []string
+}
+
+func (c *htmlConverter) children(n *html.Node) string {
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		sb.WriteString(c.convert(child))
+	}
+	return sb.String()
+}
+
+func (c *htmlConverter) convert(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return convertText(n.Data)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+	switch n.DataAtom {
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Figure, atom.Figcaption:
+		return block(c.children(n))
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		return block(strings.Repeat("#", level) + " " + oneLine(c.children(n)))
+	case atom.Br:
+		return "  \n"
+	case atom.Hr:
+		return block("---")
+	case atom.Strong, atom.B:
+		return wrapInline(c.children(n), "**")
+	case atom.Em, atom.I:
+		return wrapInline(c.children(n), "*")
+	case atom.Del, atom.S:
+		return wrapInline(c.children(n), "~~")
+	case atom.Code:
+		return "`" + textContent(n) + "`"
+	case atom.Pre:
+		return block(codeBlock(n))
+	case atom.A:
+		text := c.children(n)
+		href := attr(n, "href")
+		if href == "" {
+			return text
+		}
+		return fmt.Sprintf("[%s](%s)", oneLine(text), href)
+	case atom.Img:
+		src := attr(n, "src")
+		if src == "" {
+			return ""
+		}
+		return fmt.Sprintf("![%s](%s)", markdownEscaper.Replace(attr(n, "alt")), src)
+	case atom.Ul, atom.Ol:
+		return block(c.list(n))
+	case atom.Blockquote:
+		return block(prefixLines(strings.TrimSpace(cleanMarkdown(c.children(n))), "> ", ">"))
+	case atom.Table:
+		return block(c.table(n))
+	case atom.Script, atom.Style, atom.Iframe, atom.Object, atom.Embed,
+		atom.Video, atom.Audio, atom.Form, atom.Noscript:
+		c.removed = append(c.removed, n.Data)
+		return ""
+	default:
+		return c.children(n)
+	}
+}
+
+// convertText は、テキストの空白を整え、Markdownの記号をエスケープする
+// 空行は段落の区切りとして残す
+func convertText(s string) string {
+	paragraphs := paragraphPattern.Split(s, -1)
+	for i, p := range paragraphs {
+		p = spacesPattern.ReplaceAllString(p, " ")
+		p = lineBreakPattern.ReplaceAllString(p, "\n")
+		paragraphs[i] = markdownEscaper.Replace(p)
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// list は、リストの項目を変換する
+// 項目内の2行目以降は、入れ子のリストとして解釈されるようにマーカーの幅だけ字下げする
+func (c *htmlConverter) list(n *html.Node) string {
+	var items []string
+	index := 1
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || child.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = fmt.Sprintf("%d. ", index)
+		}
+		index++
+		content := strings.TrimSpace(cleanMarkdown(c.children(child)))
+		indent := strings.Repeat(" ", len(marker))
+		items = append(items, marker+prefixLines(content, indent, "")[len(indent):])
+	}
+	return strings.Join(items, "\n")
+}
+
+// table は、表をGFMの表に変換する
+// 先頭の行を見出しとする
+func (c *htmlConverter) table(n *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if child.DataAtom != atom.Tr {
+				walk(child)
+				continue
+			}
+			var cells []string
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+					text := oneLine(c.children(cell))
+					cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+				}
+			}
+			rows = append(rows, cells)
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// codeBlock は、整形済みテキストをフェンスで囲んだコードブロックに変換する
+// 言語はpreかcodeのクラス名(language-go)から取得する
+func codeBlock(n *html.Node) string {
+	language := ""
+	for _, e := range []*html.Node{n, n.FirstChild} {
+		if e == nil || e.Type != html.ElementNode {
+			continue
+		}
+		if m := codeLanguagePattern.FindStringSubmatch(attr(e, "class")); m != nil {
+			language = m[1]
+			break
+		}
+	}
+	code := strings.TrimSuffix(strings.TrimPrefix(textContent(n), "\n"), "\n")
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + language + "\n" + code + "\n" + fence
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.DataAtom == atom.Br {
+			sb.WriteString("\n")
+			continue
+		}
+		sb.WriteString(textContent(child))
+	}
+	return sb.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func block(s string) string {
+	return "\n\n" + s + "\n\n"
+}
+
+// wrapInline は、前後の空白を除いたテキストを記号で囲む
+// 空白を含めて囲むと強調として解釈されないため、空白は記号の外に出す
+func wrapInline(s string, mark string) string {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return s
+	}
+	start := s[:strings.Index(s, trimmed)]
+	end := s[len(start)+len(trimmed):]
+	return start + mark + trimmed + mark + end
+}
+
+func oneLine(s string) string {
+	return strings.TrimSpace(strings.Join(strings.Fields(s), " "))
+}
+
+// prefixLines は、各行の先頭に文字列を付与する
+// 空行にはemptyを付与する
+func prefixLines(s string, prefix string, empty string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = empty
+			continue
+		}
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,159 @@
+package external
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+var (
+	// hugoFigurePattern は、画像のショートコード
+	hugoFigurePattern = regexp.MustCompile(`\{\{[<%]\s*figure\s+([^}]*?)\s*[>%]\}\}`)
+	hugoAttrPattern   = regexp.MustCompile(`(\w+)=(?:"([^"]*)"|(\S+))`)
+	// hugoShortcodePattern は、Markdownに変換できないショートコード
+	hugoShortcodePattern = regexp.MustCompile(`\{\{[<%]\s*([\w-]+)`)
+)
+
+// ReadHugo は、Hugoのサイトのcontentディレクトリ以下の記事を読み込む
+// タグとカテゴリはどちらもタグとし、下書きは非公開とする
+// 本文中の相対パスの画像はページバンドルのディレクトリから、絶対パスの画像はimagesDirから探す
+// 一覧ページ(_index.md)は読み込まない
+func ReadHugo(root string, imagesDir string, now time.Time) (*Result, error) {
+	contentDir := filepath.Join(root, "content")
+	var paths []string
+	err := filepath.WalkDir(contentDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(p, ".md") {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk content directory: %w", err)
+	}
+	sort.Strings(paths)
+
+	result := &Result{}
+	for _, p := range paths {
+		source, err := filepath.Rel(root, p)
+		if err != nil {
+			source = p
+		}
+		if filepath.Base(p) == "_index.md" {
+			result.skip(source, "list page is not supported")
+			continue
+		}
+		post, err := readHugoFile(result, source, p, imagesDir, now)
+		if err != nil {
+			result.skip(source, "%v", err)
+			continue
+		}
+		result.Posts = append(result.Posts, post)
+	}
+	return result, nil
+}
+
+func readHugoFile(result *Result, source string, p string, imagesDir string, now time.Time) (*Post, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	meta, content, err := splitFrontMatter(b)
+	if err != nil {
+		return nil, err
+	}
+	title := meta.string("title")
+	if title == "" {
+		return nil, fmt.Errorf("title is empty")
+	}
+	date, err := meta.time(time.UTC, "date")
+	if err != nil {
+		return nil, err
+	}
+	publishDate, err := meta.time(time.UTC, "publishDate", "publishdate", "date")
+	if err != nil {
+		return nil, err
+	}
+
+	content = convertHugoFigures(content)
+	for _, m := range hugoShortcodePattern.FindAllStringSubmatch(content, -1) {
+		result.skip(source, "shortcode {{< %s >}} is not converted", m[1])
+	}
+
+	// ページバンドル(index.md)はディレクトリ名をスラッグとする
+	name := strings.TrimSuffix(filepath.Base(p), ".md")
+	if name == "index" {
+		name = filepath.Base(filepath.Dir(p))
+	}
+	blog := &models.Blog{
+		Title:       title,
+		Description: meta.string("description", "summary"),
+		Content:     content,
+		Tags:        mergeTags(meta.strings("categories"), meta.strings("tags")),
+		Created:     unixTime(date),
+		Slug:        normalizeSlug(firstNonEmpty(meta.string("slug"), name), title),
+	}
+	setPublishState(blog, !meta.bool("draft", false), publishDate, now)
+
+	bundleDir := filepath.Dir(p)
+	return &Post{
+		Source: source,
+		Author: meta.string("author", "authors"),
+		Blog:   blog,
+		Images: collectImages(result, source, content, func(src string) ([]string, bool) {
+			if isRemoteURL(src) {
+				return nil, false
+			}
+			src, _, _ = strings.Cut(src, "?")
+			var local string
+			var ok bool
+			if path.IsAbs(src) {
+				local, ok = localPath(imagesDir, src)
+			} else {
+				local, ok = localPath(bundleDir, src)
+			}
+			if !ok {
+				return nil, false
+			}
+			return []string{local}, true
+		}),
+	}, nil
+}
+
+// convertHugoFigures は、画像のショートコード(figure)をMarkdownの画像に変換する
+// キャプションは画像の次の段落とする
+func convertHugoFigures(content string) string {
+	return hugoFigurePattern.ReplaceAllStringFunc(content, func(s string) string {
+		attrs := map[string]string{}
+		for _, m := range hugoAttrPattern.FindAllStringSubmatch(hugoFigurePattern.FindStringSubmatch(s)[1], -1) {
+			attrs[m[1]] = m[2] + m[3]
+		}
+		if attrs["src"] == "" {
+			return s
+		}
+		image := fmt.Sprintf("![%s](%s)", firstNonEmpty(attrs["alt"], attrs["title"]), attrs["src"])
+		if caption := firstNonEmpty(attrs["caption"], attrs["title"]); caption != "" {
+			image += "\n\n" + caption
+		}
+		return image
+	})
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
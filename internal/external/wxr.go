@@ -0,0 +1,206 @@
+package external
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+const wxrContentNamespace = "http://purl.org/rss/1.0/modules/content/"
+
+// wxrUploadsPath は、WordPressのメディアのURLのうちアップロードしたディレクトリ以下を示す部分
+const wxrUploadsPath = "/wp-content/uploads/"
+
+type wxrDocument struct {
+	Items []*wxrItem `xml:"channel>item"`
+}
+
+type wxrItem struct {
+	Title       string         `xml:"title"`
+	Link        string         `xml:"link"`
+	Creator     string         `xml:"creator"`
+	Encoded     []wxrEncoded   `xml:"encoded"`
+	PostId      int64          `xml:"post_id"`
+	PostDate    string         `xml:"post_date"`
+	PostDateGMT string         `xml:"post_date_gmt"`
+	PostName    string         `xml:"post_name"`
+	Status      string         `xml:"status"`
+	PostType    string         `xml:"post_type"`
+	Categories  []*wxrCategory `xml:"category"`
+}
+
+// wxrEncoded は、本文(content:encoded)と抜粋(excerpt:encoded)
+// 要素名が同じため、名前空間で区別する
+type wxrEncoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type wxrCategory struct {
+	Domain string `xml:"domain,attr"`
+	Name   string `xml:",chardata"`
+}
+
+var (
+	// wxrCaptionPattern は、画像のキャプションのショートコード
+	wxrCaptionPattern = regexp.MustCompile(`\[/?caption[^\]]*\]`)
+	// wxrShortcodePattern は、Markdownに変換できないメディアのショートコード
+	wxrShortcodePattern = regexp.MustCompile(`\[(gallery|embed|audio|video|playlist)\b[^\]]*\]`)
+	// wxrResizedPattern は、WordPressが生成した縮小画像のファイル名の接尾辞(-300x200)
+	wxrResizedPattern = regexp.MustCompile(`-\d+x\d+(\.[^.]+)$`)
+)
+
+// ReadWXR は、WordPressのエクスポートファイル(WXR)から投稿を読み込む
+// 本文のHTMLはMarkdownに変換し、カテゴリとタグはどちらもタグとする
+// 公開済みの投稿は公開、予約投稿は予約公開、下書き・レビュー待ち・非公開の投稿は非公開とし
+// ゴミ箱の投稿と固定ページなどの投稿以外は読み込まない
+// 本文中のアップロードした画像は、URLのwp-content/uploads以下のパスでimagesDirから探す
+func ReadWXR(path string, imagesDir string, now time.Time) (*Result, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	var doc wxrDocument
+	if err := xml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse wxr: %w", err)
+	}
+	result := &Result{}
+	for _, item := range doc.Items {
+		source := fmt.Sprintf("%s (post_id=%d)", filepath.Base(path), item.PostId)
+		if item.Title != "" {
+			source = fmt.Sprintf("%s %q", source, item.Title)
+		}
+		// 添付ファイルは本文中の画像として取り込むため、記事としては扱わない
+		if item.PostType == "attachment" {
+			continue
+		}
+		if item.PostType != "post" {
+			result.skip(source, "post type %s is not supported", item.PostType)
+			continue
+		}
+		post, err := readWXRItem(result, source, item, imagesDir, now)
+		if err != nil {
+			result.skip(source, "%v", err)
+			continue
+		}
+		if post != nil {
+			result.Posts = append(result.Posts, post)
+		}
+	}
+	return result, nil
+}
+
+func readWXRItem(result *Result, source string, item *wxrItem, imagesDir string, now time.Time) (*Post, error) {
+	var public bool
+	switch item.Status {
+	case "publish", "future":
+		public = true
+	case "draft", "pending", "private":
+		public = false
+	default:
+		return nil, fmt.Errorf("status %s is not supported", item.Status)
+	}
+	if item.Title == "" {
+		return nil, fmt.Errorf("title is empty")
+	}
+
+	var content, excerpt string
+	for _, e := range item.Encoded {
+		switch {
+		case e.XMLName.Space == wxrContentNamespace:
+			content = e.Value
+		case strings.Contains(e.XMLName.Space, "/excerpt/"):
+			excerpt = e.Value
+		}
+	}
+	content = wxrCaptionPattern.ReplaceAllString(content, "")
+	for _, m := range wxrShortcodePattern.FindAllStringSubmatch(content, -1) {
+		result.skip(source, "shortcode [%s] is not converted", m[1])
+	}
+	markdown, removed, err := htmlToMarkdown(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, element := range removed {
+		result.skip(source, "<%s> is removed from content", element)
+	}
+
+	var categories, tags []string
+	for _, c := range item.Categories {
+		switch c.Domain {
+		case "category":
+			// 未分類は既定のカテゴリのため、タグにしない
+			if c.Name != "Uncategorized" && c.Name != "未分類" {
+				categories = append(categories, c.Name)
+			}
+		case "post_tag":
+			tags = append(tags, c.Name)
+		}
+	}
+
+	date, err := wxrDate(item)
+	if err != nil {
+		return nil, err
+	}
+	blog := &models.Blog{
+		Title:       item.Title,
+		Description: strings.TrimSpace(excerpt),
+		Content:     markdown,
+		Tags:        mergeTags(categories, tags),
+		Created:     unixTime(date),
+		Slug:        normalizeSlug(item.PostName, item.Title),
+	}
+	if blog.Slug == "" {
+		blog.Slug = fmt.Sprintf("post-%d", item.PostId)
+	}
+	setPublishState(blog, public, date, now)
+	return &Post{
+		Source: source,
+		Author: item.Creator,
+		Blog:   blog,
+		Images: collectImages(result, source, markdown, func(src string) ([]string, bool) {
+			return wxrImagePaths(imagesDir, src)
+		}),
+	}, nil
+}
+
+// wxrDate は、投稿の日時を返す
+// 下書きなどでGMTの日時が設定されていない場合は、サイトのタイムゾーンの日時をUTCとして扱う
+func wxrDate(item *wxrItem) (time.Time, error) {
+	for _, s := range []string{item.PostDateGMT, item.PostDate} {
+		if s == "" || strings.HasPrefix(s, "0000-00-00") {
+			continue
+		}
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", s, time.UTC)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid post date: %s", s)
+		}
+		return t, nil
+	}
+	return time.Time{}, nil
+}
+
+// wxrImagePaths は、アップロードした画像のURLに対応するローカルのファイルの候補を返す
+// 縮小画像のファイルがない場合は、元の画像を使う
+func wxrImagePaths(imagesDir string, src string) ([]string, bool) {
+	_, rel, ok := strings.Cut(src, wxrUploadsPath)
+	if !ok {
+		return nil, false
+	}
+	rel, _, _ = strings.Cut(rel, "?")
+	path, ok := localPath(imagesDir, rel)
+	if !ok {
+		return nil, false
+	}
+	candidates := []string{path}
+	if original := wxrResizedPattern.ReplaceAllString(path, "$1"); original != path {
+		candidates = append(candidates, original)
+	}
+	return candidates, true
+}
@@ -0,0 +1,94 @@
+package external
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// zennLocation は、Zennの公開日時(published_at)のタイムゾーン
+var zennLocation = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+var (
+	// zennEmbedPattern は、Markdownに変換できない埋め込み(@[tweet](URL)など)
+	zennEmbedPattern = regexp.MustCompile(`(?m)^@\[(\w+)\]`)
+	// zennBlockPattern は、Markdownに変換できないメッセージやアコーディオン(:::message)
+	zennBlockPattern = regexp.MustCompile(`(?m)^:::+\s*(\w+)`)
+)
+
+// ReadZenn は、Zennと連携したリポジトリのarticlesディレクトリ以下の記事を読み込む
+// トピックはタグとし、公開していない記事は非公開とする
+// スラッグはファイル名とし、本文中の画像(/images/...)はimagesDirから探す
+func ReadZenn(root string, imagesDir string, now time.Time) (*Result, error) {
+	paths, err := filepath.Glob(filepath.Join(root, "articles", "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to glob: %w", err)
+	}
+	sort.Strings(paths)
+
+	result := &Result{}
+	for _, p := range paths {
+		source := filepath.Join("articles", filepath.Base(p))
+		post, err := readZennFile(result, source, p, imagesDir, now)
+		if err != nil {
+			result.skip(source, "%v", err)
+			continue
+		}
+		result.Posts = append(result.Posts, post)
+	}
+	return result, nil
+}
+
+func readZennFile(result *Result, source string, p string, imagesDir string, now time.Time) (*Post, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	meta, content, err := splitFrontMatter(b)
+	if err != nil {
+		return nil, err
+	}
+	title := meta.string("title")
+	if title == "" {
+		return nil, fmt.Errorf("title is empty")
+	}
+	publishedAt, err := meta.time(zennLocation, "published_at")
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range zennEmbedPattern.FindAllStringSubmatch(content, -1) {
+		result.skip(source, "embed @[%s] is not converted", m[1])
+	}
+	for _, m := range zennBlockPattern.FindAllStringSubmatch(content, -1) {
+		result.skip(source, "block :::%s is not converted", m[1])
+	}
+
+	blog := &models.Blog{
+		Title:   title,
+		Content: content,
+		Tags:    mergeTags(meta.strings("topics")),
+		Created: unixTime(publishedAt),
+		Slug:    normalizeSlug(strings.TrimSuffix(filepath.Base(p), ".md"), title),
+	}
+	setPublishState(blog, meta.bool("published", false), publishedAt, now)
+	return &Post{
+		Source: source,
+		Blog:   blog,
+		Images: collectImages(result, source, content, func(src string) ([]string, bool) {
+			if isRemoteURL(src) || !strings.HasPrefix(src, "/") {
+				return nil, false
+			}
+			local, ok := localPath(imagesDir, src)
+			if !ok {
+				return nil, false
+			}
+			return []string{local}, true
+		}),
+	}, nil
+}
@@ -0,0 +1,80 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	Id      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href     string `xml:"href,attr"`
+	Rel      string `xml:"rel,attr,omitempty"`
+	Type     string `xml:"type,attr,omitempty"`
+	Hreflang string `xml:"hreflang,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	Id         string         `xml:"id"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Summary    string         `xml:"summary"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// Atom は、フィードをAtom 1.0形式で出力する
+func (f *Feed) Atom() ([]byte, error) {
+	af := atomFeed{
+		Title:   f.Title,
+		Id:      f.FeedURL,
+		Updated: f.LastModified().UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.Link, Rel: "alternate", Type: "text/html"},
+			{Href: f.FeedURL, Rel: "self", Type: "application/atom+xml"},
+		},
+		Entries: make([]atomEntry, 0, len(f.Items)),
+	}
+	for _, item := range f.Items {
+		entry := atomEntry{
+			Title:     item.Title,
+			Id:        item.Id,
+			Links:     []atomLink{{Href: item.Link, Rel: "alternate", Type: "text/html"}},
+			Published: item.Published.UTC().Format(time.RFC3339),
+			Updated:   item.Updated.UTC().Format(time.RFC3339),
+			Summary:   item.Summary,
+		}
+		for _, alternate := range item.Alternates {
+			entry.Links = append(entry.Links, atomLink{
+				Href: alternate.Link, Rel: "alternate", Type: "text/html", Hreflang: alternate.Locale,
+			})
+		}
+		if item.ImageURL != "" {
+			entry.Links = append(entry.Links, atomLink{
+				Href: item.ImageURL, Rel: "enclosure", Type: imageType(item.ImageURL),
+			})
+		}
+		for _, tag := range item.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		af.Entries = append(af.Entries, entry)
+	}
+	b, err := xml.MarshalIndent(&af, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal atom: %w", err)
+	}
+	return append([]byte(xml.Header), b...), nil
+}
@@ -0,0 +1,69 @@
+package feed
+
+import (
+	"mime"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	ContentTypeRSS  = "application/rss+xml; charset=utf-8"
+	ContentTypeAtom = "application/atom+xml; charset=utf-8"
+	ContentTypeJSON = "application/feed+json; charset=utf-8"
+)
+
+// Feed は、配信フォーマットに依存しないフィードの内容
+type Feed struct {
+	Title       string
+	Description string
+	// Link は、フィードの配信元となるサイトのURL
+	Link string
+	// FeedURL は、フィード自身のURL
+	FeedURL string
+	Updated time.Time
+	Items   []*Item
+}
+
+// Item は、フィードに含まれる1件の記事
+type Item struct {
+	// Id は、記事を識別する変わらない値で、リンクが変わっても同じ記事と判定できるようにする
+	Id        string
+	Title     string
+	Link      string
+	Summary   string
+	ImageURL  string
+	Tags      []string
+	Published time.Time
+	Updated   time.Time
+	// Alternates は、翻訳がある記事の言語ごとのリンク
+	Alternates []Alternate
+}
+
+// Alternate は、記事の言語ごとのリンク
+type Alternate struct {
+	// Locale は、BCP 47の言語タグ
+	Locale string
+	Link   string
+}
+
+// LastModified は、フィードに含まれる記事の最終更新日時を返す
+// 記事がない場合はフィードの更新日時を返す
+func (f *Feed) LastModified() time.Time {
+	last := f.Updated
+	for _, item := range f.Items {
+		if item.Updated.After(last) {
+			last = item.Updated
+		}
+	}
+	return last
+}
+
+// imageType は、画像URLの拡張子からMIMEタイプを推定する
+func imageType(url string) string {
+	ext := strings.ToLower(path.Ext(url))
+	if t := mime.TypeByExtension(ext); t != "" {
+		return t
+	}
+	return "image/jpeg"
+}
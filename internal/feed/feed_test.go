@@ -0,0 +1,141 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestFeed() *Feed {
+	published := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &Feed{
+		Title:       "blog",
+		Description: "description",
+		Link:        "https://example.com",
+		FeedURL:     "https://api.example.com/feed.rss",
+		Updated:     published,
+		Items: []*Item{
+			{
+				Id:        "https://example.com/blogs/2",
+				Title:     "second <post>",
+				Link:      "https://example.com/blogs/second-post",
+				Summary:   "summary & more",
+				ImageURL:  "https://cdn.example.com/thumbnail/image.png",
+				Tags:      []string{"go", "test"},
+				Published: published.Add(24 * time.Hour),
+				Updated:   published.Add(48 * time.Hour),
+				Alternates: []Alternate{
+					{Locale: "ja", Link: "https://example.com/blogs/second-post?lang=ja"},
+					{Locale: "en", Link: "https://example.com/blogs/second-post?lang=en"},
+				},
+			},
+			{
+				Id:        "https://example.com/blogs/1",
+				Title:     "first",
+				Link:      "https://example.com/blogs/1",
+				Published: published,
+				Updated:   published,
+			},
+		},
+	}
+}
+
+func Test_Feed_LastModified(t *testing.T) {
+	f := newTestFeed()
+	want := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	if got := f.LastModified(); !got.Equal(want) {
+		t.Errorf("LastModified() = %v, want %v", got, want)
+	}
+}
+
+func Test_Feed_RSS(t *testing.T) {
+	b, err := newTestFeed().RSS()
+	if err != nil {
+		t.Fatalf("failed to RSS: %v", err)
+	}
+	var got rss
+	if err := xml.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to unmarshal rss: %v", err)
+	}
+	if len(got.Channel.Items) != 2 {
+		t.Fatalf("want 2 items, got %d", len(got.Channel.Items))
+	}
+	item := got.Channel.Items[0]
+	if item.Title != "second <post>" || item.Description != "summary & more" {
+		t.Errorf("unexpected item: %+v", item)
+	}
+	if item.Guid.Value != "https://example.com/blogs/2" || item.Guid.IsPermaLink {
+		t.Errorf("want guid by id that is not permalink, got %+v", item.Guid)
+	}
+	if guid := got.Channel.Items[1].Guid; guid.Value != "https://example.com/blogs/1" || !guid.IsPermaLink {
+		t.Errorf("want permalink guid, got %+v", guid)
+	}
+	if item.PubDate != "Tue, 02 Jan 2024 00:00:00 +0000" {
+		t.Errorf("unexpected pubDate: %s", item.PubDate)
+	}
+	if item.Enclosure == nil || item.Enclosure.Type != "image/png" {
+		t.Errorf("unexpected enclosure: %+v", item.Enclosure)
+	}
+	if got.Channel.Items[1].Enclosure != nil {
+		t.Errorf("want no enclosure, got %+v", got.Channel.Items[1].Enclosure)
+	}
+	if !strings.Contains(string(b), `<atom:link href="https://example.com/blogs/second-post?lang=en" rel="alternate" type="text/html" hreflang="en"></atom:link>`) {
+		t.Errorf("missing hreflang alternate: %s", b)
+	}
+}
+
+func Test_Feed_Atom(t *testing.T) {
+	b, err := newTestFeed().Atom()
+	if err != nil {
+		t.Fatalf("failed to Atom: %v", err)
+	}
+	if !strings.Contains(string(b), `xmlns="http://www.w3.org/2005/Atom"`) {
+		t.Errorf("missing atom namespace: %s", b)
+	}
+	var got atomFeed
+	if err := xml.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to unmarshal atom: %v", err)
+	}
+	if got.Updated != "2024-01-03T00:00:00Z" {
+		t.Errorf("unexpected updated: %s", got.Updated)
+	}
+	if len(got.Entries) != 2 || len(got.Entries[0].Categories) != 2 {
+		t.Fatalf("unexpected entries: %+v", got.Entries)
+	}
+	if got.Entries[0].Id != "https://example.com/blogs/2" {
+		t.Errorf("want entry id by id, got %s", got.Entries[0].Id)
+	}
+	var hreflangs []string
+	for _, link := range got.Entries[0].Links {
+		if link.Rel == "alternate" && link.Hreflang != "" {
+			hreflangs = append(hreflangs, link.Hreflang)
+		}
+	}
+	if strings.Join(hreflangs, ",") != "ja,en" {
+		t.Errorf("unexpected hreflang alternates: %+v", got.Entries[0].Links)
+	}
+	for _, link := range got.Entries[1].Links {
+		if link.Hreflang != "" {
+			t.Errorf("want no hreflang alternate, got %+v", link)
+		}
+	}
+}
+
+func Test_Feed_JSON(t *testing.T) {
+	b, err := newTestFeed().JSON()
+	if err != nil {
+		t.Fatalf("failed to JSON: %v", err)
+	}
+	var got jsonFeed
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to unmarshal json feed: %v", err)
+	}
+	if got.Version != jsonFeedVersion || got.FeedURL != "https://api.example.com/feed.rss" {
+		t.Errorf("unexpected feed: %+v", got)
+	}
+	if len(got.Items) != 2 || got.Items[0].Image != "https://cdn.example.com/thumbnail/image.png" {
+		t.Errorf("unexpected items: %+v", got.Items)
+	}
+}
@@ -0,0 +1,60 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	HomePageURL string     `json:"home_page_url"`
+	FeedURL     string     `json:"feed_url"`
+	Description string     `json:"description,omitempty"`
+	Items       []jsonItem `json:"items"`
+}
+
+type jsonItem struct {
+	Id            string   `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	Summary       string   `json:"summary,omitempty"`
+	ContentText   string   `json:"content_text"`
+	Image         string   `json:"image,omitempty"`
+	DatePublished string   `json:"date_published"`
+	DateModified  string   `json:"date_modified"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// JSON は、フィードをJSON Feed 1.1形式で出力する
+func (f *Feed) JSON() ([]byte, error) {
+	jf := jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.FeedURL,
+		Description: f.Description,
+		Items:       make([]jsonItem, 0, len(f.Items)),
+	}
+	for _, item := range f.Items {
+		jf.Items = append(jf.Items, jsonItem{
+			Id:            item.Id,
+			URL:           item.Link,
+			Title:         item.Title,
+			Summary:       item.Summary,
+			ContentText:   item.Summary,
+			Image:         item.ImageURL,
+			DatePublished: item.Published.UTC().Format(time.RFC3339),
+			DateModified:  item.Updated.UTC().Format(time.RFC3339),
+			Tags:          item.Tags,
+		})
+	}
+	b, err := json.Marshal(&jf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json feed: %w", err)
+	}
+	return b, nil
+}
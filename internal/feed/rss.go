@@ -0,0 +1,99 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      rssLink   `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssLink struct {
+	Href     string `xml:"href,attr"`
+	Rel      string `xml:"rel,attr"`
+	Type     string `xml:"type,attr"`
+	Hreflang string `xml:"hreflang,attr,omitempty"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	Guid        rssGuid       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+	// AtomLinks は、RSSで表現できない言語ごとのリンクをAtomの要素で表す
+	AtomLinks []rssLink `xml:"atom:link"`
+}
+
+type rssGuid struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// RSS は、フィードをRSS 2.0形式で出力する
+func (f *Feed) RSS() ([]byte, error) {
+	channel := rssChannel{
+		Title:       f.Title,
+		Link:        f.Link,
+		Description: f.Description,
+		AtomLink: rssLink{
+			Href: f.FeedURL,
+			Rel:  "self",
+			Type: "application/rss+xml",
+		},
+		Items: make([]rssItem, 0, len(f.Items)),
+	}
+	if last := f.LastModified(); !last.IsZero() {
+		channel.LastBuildDate = last.UTC().Format(time.RFC1123Z)
+	}
+	for _, item := range f.Items {
+		ri := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Summary,
+			Guid:        rssGuid{IsPermaLink: item.Id == item.Link, Value: item.Id},
+			PubDate:     item.Published.UTC().Format(time.RFC1123Z),
+			Categories:  item.Tags,
+		}
+		for _, alternate := range item.Alternates {
+			ri.AtomLinks = append(ri.AtomLinks, rssLink{
+				Href: alternate.Link, Rel: "alternate", Type: "text/html", Hreflang: alternate.Locale,
+			})
+		}
+		if item.ImageURL != "" {
+			ri.Enclosure = &rssEnclosure{URL: item.ImageURL, Length: 0, Type: imageType(item.ImageURL)}
+		}
+		channel.Items = append(channel.Items, ri)
+	}
+	b, err := xml.MarshalIndent(&rss{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: channel,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rss: %w", err)
+	}
+	return append([]byte(xml.Header), b...), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"path/filepath"
 	"time"
 
@@ -51,6 +52,22 @@ func (s *S3Adapter) ExistObject(ctx context.Context, bucketName string, key stri
 	return true, nil
 }
 
+// PutObject は、署名付きURLを経由せずにオブジェクトを直接アップロードする
+func (s *S3Adapter) PutObject(
+	ctx context.Context, bucketName string, key string, body io.Reader, contentType string,
+) error {
+	input := &s3.PutObjectInput{
+		Bucket:      &bucketName,
+		Key:         &key,
+		Body:        body,
+		ContentType: aws.String(contentType),
+	}
+	if _, err := s.s3Client.PutObject(ctx, input); err != nil {
+		return fmt.Errorf("failed to PutObject: %w", err)
+	}
+	return nil
+}
+
 // deprecated
 func (s *S3Adapter) GeneratePreSignedURL(destinationPath string, fileName string) (presignedUrl, objectUrl string, err error) {
 	bucketName := s.config.AWSS3Bucket
@@ -19,6 +19,29 @@ type Blog struct {
 	Tags                   []string `json:"tags,omitempty" db:"tags"`
 	Created                uint     `json:"created" db:"created"`
 	Modified               uint     `json:"modified" db:"modified"`
+	PublishAt              *uint    `json:"publishAt,omitempty" db:"publish_at"` // 予約公開日時
+	Slug                   string   `json:"slug" db:"slug"`
+	DefaultLocale          string   `json:"defaultLocale" db:"default_locale"`   // タイトル・概要・本文の言語
+	DeletedAt              *uint    `json:"deletedAt,omitempty" db:"deleted_at"` // ゴミ箱に移動した日時
+	Version                int64    `json:"version" db:"version"`                // 更新のたびに増えるバージョン
+
+	// Snippetはキーワード検索時に一致箇所を<mark>で囲んだ本文の抜粋
+	Snippet string `json:"snippet,omitempty" db:"-"`
+	// Renderedは詳細取得時に指定された場合のみ設定される、本文のレンダリング結果
+	Rendered *BlogRendering `json:"rendered,omitempty" db:"-"`
+	// Seriesは詳細取得時に設定される、所属するシリーズと前後のブログ
+	Series *BlogSeriesNavigation `json:"series,omitempty" db:"-"`
+	// Reactionsは一覧・詳細取得時に設定される、絵文字ごとのリアクション数
+	Reactions []*ReactionCount `json:"reactions,omitempty" db:"-"`
+	// Authorは一覧・詳細取得時に指定された場合のみ設定される、著者のプロフィール
+	Author *UserProfile `json:"author,omitempty" db:"-"`
+	// Localeは詳細取得時、言語で絞り込んだ一覧取得時に設定される、表示しているタイトル・概要・本文の言語
+	Locale string `json:"locale,omitempty" db:"-"`
+	// Localesは詳細取得時に設定される、閲覧できる言語の一覧(既定の言語が先頭)
+	Locales []string `json:"locales,omitempty" db:"-"`
+	// SortValueは一覧取得時に設定される、並び順の基準の値の文字列表現
+	// カーソルの生成に使用する
+	SortValue string `json:"-" db:"sort_value"`
 }
 
 func (blog *Blog) HavingTag(tag string) bool {
@@ -65,3 +88,22 @@ func (blogs Blogs) ToSlice() []*Blog {
 	}
 	return result
 }
+
+// AuthorIdsは、ブログの著者のIDを重複なく返す
+func (blogs Blogs) AuthorIds() []UserId {
+	ids := make([]UserId, 0, len(blogs))
+	for _, blog := range blogs {
+		if !slices.Contains(ids, blog.AuthorId) {
+			ids = append(ids, blog.AuthorId)
+		}
+	}
+	return ids
+}
+
+// SetAuthorsは、著者のIDごとのプロフィールをブログに設定する
+// プロフィールが存在しない著者のブログには設定しない
+func (blogs Blogs) SetAuthors(profiles map[UserId]*UserProfile) {
+	for _, blog := range blogs {
+		blog.Author = profiles[blog.AuthorId]
+	}
+}
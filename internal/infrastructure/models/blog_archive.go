@@ -0,0 +1,35 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// BlogArchive は、年月ごとの公開済みのブログの件数
+type BlogArchive struct {
+	Year  int   `json:"year" db:"year"`
+	Month int   `json:"month" db:"month"`
+	Count int64 `json:"count" db:"count"`
+}
+
+var ErrInvalidArchiveMonth = fmt.Errorf("invalid archive month")
+
+// ArchivePeriod は、アーカイブの年月に含まれるブログの作成日時の範囲
+// Fromは期間に含み、Toは期間に含まない
+type ArchivePeriod struct {
+	From uint
+	To   uint
+}
+
+// NewArchivePeriod は、サイトのタイムゾーンでの年月の初日から翌月の初日までの期間を生成する
+func NewArchivePeriod(year int, month int, loc *time.Location) (*ArchivePeriod, error) {
+	if month < 1 || month > 12 {
+		return nil, fmt.Errorf("%w: month must be 1-12", ErrInvalidArchiveMonth)
+	}
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+	if from.Unix() < 0 {
+		return nil, fmt.Errorf("%w: year is out of range", ErrInvalidArchiveMonth)
+	}
+	to := from.AddDate(0, 1, 0)
+	return &ArchivePeriod{From: uint(from.Unix()), To: uint(to.Unix())}, nil
+}
@@ -0,0 +1,69 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+func Test_NewArchivePeriod(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	tests := []struct {
+		name    string
+		year    int
+		month   int
+		loc     *time.Location
+		want    *models.ArchivePeriod
+		wantErr bool
+	}{
+		{
+			name:  "サイトのタイムゾーンの月初から翌月の月初まで",
+			year:  2024,
+			month: 3,
+			loc:   jst,
+			// 2024-02-29T15:00:00Z から 2024-03-31T15:00:00Z
+			want: &models.ArchivePeriod{From: 1709218800, To: 1711897200},
+		},
+		{
+			name:  "12月は翌年の1月まで",
+			year:  2023,
+			month: 12,
+			loc:   time.UTC,
+			want:  &models.ArchivePeriod{From: 1701388800, To: 1704067200},
+		},
+		{
+			name:    "月が範囲外",
+			year:    2024,
+			month:   13,
+			loc:     jst,
+			wantErr: true,
+		},
+		{
+			name:    "1970年より前",
+			year:    1969,
+			month:   1,
+			loc:     time.UTC,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := models.NewArchivePeriod(tt.year, tt.month, tt.loc)
+			if tt.wantErr {
+				if !errors.Is(err, models.ErrInvalidArchiveMonth) {
+					t.Fatalf("got: %v, want: %v", err, models.ErrInvalidArchiveMonth)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
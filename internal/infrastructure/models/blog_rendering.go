@@ -0,0 +1,74 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/shoet/blog/internal/markdown"
+)
+
+// BlogRendering は、本文のMarkdownをレンダリングした結果
+type BlogRendering struct {
+	BlogId             BlogId  `json:"-" db:"blog_id"`
+	HTML               string  `json:"html" db:"html"`
+	Toc                BlogToc `json:"toc" db:"toc"`
+	CharacterCount     int     `json:"characterCount" db:"character_count"`
+	WordCount          int     `json:"wordCount" db:"word_count"`
+	ReadingTimeMinutes int     `json:"readingTimeMinutes" db:"reading_time_minutes"`
+}
+
+// NewBlogRendering は、本文をレンダリングしてBlogRenderingを生成する
+func NewBlogRendering(blogId BlogId, content string) (*BlogRendering, error) {
+	result, err := markdown.Render(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render content: %w", err)
+	}
+	toc := BlogToc(result.Headings)
+	if toc == nil {
+		toc = BlogToc{}
+	}
+	return &BlogRendering{
+		BlogId:             blogId,
+		HTML:               result.HTML,
+		Toc:                toc,
+		CharacterCount:     result.Stats.Characters,
+		WordCount:          result.Stats.Words,
+		ReadingTimeMinutes: result.Stats.ReadingTimeMinutes,
+	}, nil
+}
+
+// BlogToc は、見出しの階層構造をJSONとしてDBに保存する
+type BlogToc []*markdown.Heading
+
+func (t BlogToc) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]*markdown.Heading(t))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal toc: %w", err)
+	}
+	return string(b), nil
+}
+
+func (t *BlogToc) Scan(src any) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*t = BlogToc{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for BlogToc: %T", src)
+	}
+	var toc []*markdown.Heading
+	if err := json.Unmarshal(b, &toc); err != nil {
+		return fmt.Errorf("failed to unmarshal toc: %w", err)
+	}
+	*t = toc
+	return nil
+}
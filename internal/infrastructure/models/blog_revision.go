@@ -0,0 +1,72 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type BlogRevisionId int64
+
+// BlogRevision はブログ更新時点のスナップショット
+type BlogRevision struct {
+	Id                     BlogRevisionId `json:"id" db:"id"`
+	BlogId                 BlogId         `json:"blogId" db:"blog_id"`
+	Revision               int64          `json:"revision" db:"revision"`
+	AuthorId               UserId         `json:"authorId" db:"author_id"`
+	Title                  string         `json:"title" db:"title"`
+	Content                string         `json:"content,omitempty" db:"content"`
+	Description            string         `json:"description" db:"description"`
+	ThumbnailImageFileName string         `json:"thumbnailImageFileName" db:"thumbnail_image_file_name"`
+	IsPublic               bool           `json:"isPublic" db:"is_public"`
+	Tags                   RevisionTags   `json:"tags" db:"tags"`
+	Created                uint           `json:"created" db:"created"`
+}
+
+// ApplyTo は、現在のブログの内容をリビジョンの内容で置き換えたブログを生成する
+// 置き換えるのはタイトル・本文・概要・サムネイル・タグのみとし、
+// 公開状態や予約公開の日時などは現在のブログのものを引き継ぐ
+func (r *BlogRevision) ApplyTo(current *Blog) *Blog {
+	blog := *current
+	blog.Title = r.Title
+	blog.Content = r.Content
+	blog.Description = r.Description
+	blog.ThumbnailImageFileName = r.ThumbnailImageFileName
+	blog.Tags = append([]string{}, r.Tags...)
+	return &blog
+}
+
+// RevisionTags は、リビジョン時点のタグ名をJSON配列としてDBに保存する
+type RevisionTags []string
+
+func (t RevisionTags) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(t))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tags: %w", err)
+	}
+	return string(b), nil
+}
+
+func (t *RevisionTags) Scan(src any) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*t = RevisionTags{}
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for RevisionTags: %T", src)
+	}
+	var tags []string
+	if err := json.Unmarshal(b, &tags); err != nil {
+		return fmt.Errorf("failed to unmarshal tags: %w", err)
+	}
+	*t = tags
+	return nil
+}
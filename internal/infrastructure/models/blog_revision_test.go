@@ -0,0 +1,71 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+func Test_BlogRevision_ApplyTo(t *testing.T) {
+	publishAt := uint(1767193200)
+	tests := []struct {
+		name     string
+		current  *models.Blog
+		revision *models.BlogRevision
+		want     *models.Blog
+	}{
+		{
+			name: "予約公開中のブログは予約公開の日時を引き継ぐ",
+			current: &models.Blog{
+				Id: 1, AuthorId: 1, Title: "current", Content: "current",
+				IsPublic: false, PublishAt: &publishAt, Tags: []string{"go"},
+			},
+			revision: &models.BlogRevision{
+				BlogId: 1, Revision: 1, AuthorId: 1, Title: "old", Content: "old", Description: "old",
+				ThumbnailImageFileName: "old.png", IsPublic: true, Tags: models.RevisionTags{"old"},
+			},
+			want: &models.Blog{
+				Id: 1, AuthorId: 1, Title: "old", Content: "old", Description: "old",
+				ThumbnailImageFileName: "old.png", IsPublic: false, PublishAt: &publishAt, Tags: []string{"old"},
+			},
+		},
+		{
+			name: "公開中のブログは非公開に戻さない",
+			current: &models.Blog{
+				Id: 1, AuthorId: 1, Title: "current", IsPublic: true, Tags: []string{},
+			},
+			revision: &models.BlogRevision{
+				BlogId: 1, Revision: 1, AuthorId: 1, Title: "old", IsPublic: false, Tags: models.RevisionTags{},
+			},
+			want: &models.Blog{
+				Id: 1, AuthorId: 1, Title: "old", IsPublic: true, Tags: []string{},
+			},
+		},
+		{
+			name: "非公開のブログは公開しない",
+			current: &models.Blog{
+				Id: 1, AuthorId: 1, Title: "current", Content: "current", IsPublic: false, Tags: []string{"go"},
+			},
+			revision: &models.BlogRevision{
+				BlogId: 1, Revision: 1, AuthorId: 1, Title: "old", Content: "old", Description: "old",
+				ThumbnailImageFileName: "old.png", IsPublic: true, Tags: models.RevisionTags{"old"},
+			},
+			want: &models.Blog{
+				Id: 1, AuthorId: 1, Title: "old", Content: "old", Description: "old",
+				ThumbnailImageFileName: "old.png", IsPublic: false, Tags: []string{"old"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.revision.ApplyTo(tt.current)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("differs: (-got +want)\n%s", diff)
+			}
+			if tt.current.Title == got.Title {
+				t.Errorf("current blog must not be changed")
+			}
+		})
+	}
+}
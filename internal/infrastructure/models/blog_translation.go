@@ -0,0 +1,23 @@
+package models
+
+// DefaultBlogLocale は、既定の言語を指定せずに作成したブログの言語
+const DefaultBlogLocale = "ja"
+
+// BlogTranslation は、ブログのタイトル・概要・本文の言語ごとの翻訳
+type BlogTranslation struct {
+	BlogId      BlogId `json:"blogId" db:"blog_id"`
+	Locale      string `json:"locale" db:"locale"`
+	Title       string `json:"title" db:"title"`
+	Description string `json:"description" db:"description"`
+	Content     string `json:"content" db:"content"`
+	Created     uint   `json:"created" db:"created"`
+	Modified    uint   `json:"modified" db:"modified"`
+}
+
+// ApplyTranslation は、ブログのタイトル・概要・本文を翻訳で置き換え、表示する言語を設定する
+func (blog *Blog) ApplyTranslation(translation *BlogTranslation) {
+	blog.Title = translation.Title
+	blog.Description = translation.Description
+	blog.Content = translation.Content
+	blog.Locale = translation.Locale
+}
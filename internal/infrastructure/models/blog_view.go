@@ -0,0 +1,131 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// BlogViewDaily は、ブログの日別の閲覧数
+// Dateは YYYY-MM-DD 形式
+type BlogViewDaily struct {
+	BlogId BlogId `json:"blogId" db:"blog_id"`
+	Date   string `json:"date" db:"view_date"`
+	Views  int64  `json:"views" db:"views"`
+}
+
+// ViewCount は、日別の閲覧数
+type ViewCount struct {
+	Date  string `json:"date" db:"view_date"`
+	Views int64  `json:"views" db:"views"`
+}
+
+// BlogViewCount は、期間内のブログごとの閲覧数
+type BlogViewCount struct {
+	BlogId BlogId `json:"blogId" db:"blog_id"`
+	Title  string `json:"title" db:"title"`
+	Views  int64  `json:"views" db:"views"`
+}
+
+// BlogViews は、ブログの期間内の閲覧数の推移
+type BlogViews struct {
+	BlogId BlogId       `json:"blogId"`
+	From   string       `json:"from"`
+	To     string       `json:"to"`
+	Total  int64        `json:"total"`
+	Daily  []*ViewCount `json:"daily"`
+}
+
+// SiteViews は、サイト全体の期間内の閲覧数の推移と、閲覧数の多いブログ
+type SiteViews struct {
+	From  string           `json:"from"`
+	To    string           `json:"to"`
+	Total int64            `json:"total"`
+	Daily []*ViewCount     `json:"daily"`
+	Blogs []*BlogViewCount `json:"blogs"`
+}
+
+const (
+	// DefaultViewPeriodDays は、期間が未指定の場合に集計する日数
+	DefaultViewPeriodDays = 30
+	// MaxViewPeriodDays は、一度に集計できる日数の上限
+	MaxViewPeriodDays = 366
+)
+
+// viewDateLayout は、閲覧数を集計する日付の形式
+const viewDateLayout = "2006-01-02"
+
+// viewLocation は、閲覧数を日別に集計する際のタイムゾーン
+var viewLocation = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+var ErrInvalidViewPeriod = fmt.Errorf("invalid view period")
+
+// ViewDate は、閲覧数を集計する日付を返す
+func ViewDate(t time.Time) string {
+	return t.In(viewLocation).Format(viewDateLayout)
+}
+
+// ViewPeriod は、閲覧数を集計する期間
+// From, Toは YYYY-MM-DD 形式で、どちらも期間に含む
+type ViewPeriod struct {
+	From string
+	To   string
+}
+
+// NewViewPeriod は、集計する期間を検証して生成する
+// 未指定の場合、Toはnowの日付、FromはToを含む直近DefaultViewPeriodDays日とする
+func NewViewPeriod(from string, to string, now time.Time) (*ViewPeriod, error) {
+	toDate, err := parseViewDate(to, now)
+	if err != nil {
+		return nil, err
+	}
+	fromDate, err := parseViewDate(from, toDate.AddDate(0, 0, -(DefaultViewPeriodDays-1)))
+	if err != nil {
+		return nil, err
+	}
+	if fromDate.After(toDate) {
+		return nil, fmt.Errorf("%w: from is after to", ErrInvalidViewPeriod)
+	}
+	if fromDate.AddDate(0, 0, MaxViewPeriodDays).Before(toDate.AddDate(0, 0, 1)) {
+		return nil, fmt.Errorf("%w: period exceeds %d days", ErrInvalidViewPeriod, MaxViewPeriodDays)
+	}
+	return &ViewPeriod{
+		From: fromDate.Format(viewDateLayout),
+		To:   toDate.Format(viewDateLayout),
+	}, nil
+}
+
+func parseViewDate(value string, defaultTime time.Time) (time.Time, error) {
+	if value == "" {
+		value = ViewDate(defaultTime)
+	}
+	t, err := time.ParseInLocation(viewDateLayout, value, viewLocation)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", ErrInvalidViewPeriod, err)
+	}
+	return t, nil
+}
+
+// Fill は、日別の閲覧数に閲覧のなかった日を0件として補完する
+func (p *ViewPeriod) Fill(counts []*ViewCount) []*ViewCount {
+	views := make(map[string]int64, len(counts))
+	for _, c := range counts {
+		views[c.Date] = c.Views
+	}
+	from, _ := time.ParseInLocation(viewDateLayout, p.From, viewLocation)
+	to, _ := time.ParseInLocation(viewDateLayout, p.To, viewLocation)
+	result := []*ViewCount{}
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		date := d.Format(viewDateLayout)
+		result = append(result, &ViewCount{Date: date, Views: views[date]})
+	}
+	return result
+}
+
+// TotalViews は、日別の閲覧数の合計を返す
+func TotalViews(counts []*ViewCount) int64 {
+	var total int64
+	for _, c := range counts {
+		total += c.Views
+	}
+	return total
+}
@@ -0,0 +1,85 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+func Test_NewViewPeriod(t *testing.T) {
+	// JSTでは2024-01-01
+	now := time.Date(2023, 12, 31, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		want    *models.ViewPeriod
+		wantErr bool
+	}{
+		{
+			name: "未指定の場合は直近30日",
+			want: &models.ViewPeriod{From: "2023-12-03", To: "2024-01-01"},
+		},
+		{
+			name: "toのみ指定",
+			to:   "2023-06-30",
+			want: &models.ViewPeriod{From: "2023-06-01", To: "2023-06-30"},
+		},
+		{
+			name: "366日まで指定できる",
+			from: "2023-01-01",
+			to:   "2024-01-01",
+			want: &models.ViewPeriod{From: "2023-01-01", To: "2024-01-01"},
+		},
+		{
+			name:    "366日を超える",
+			from:    "2022-12-31",
+			to:      "2024-01-01",
+			wantErr: true,
+		},
+		{
+			name:    "fromがtoより後",
+			from:    "2024-01-02",
+			to:      "2024-01-01",
+			wantErr: true,
+		},
+		{
+			name:    "日付の形式が不正",
+			from:    "2024/01/01",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := models.NewViewPeriod(tt.from, tt.to, now)
+			if tt.wantErr {
+				if !errors.Is(err, models.ErrInvalidViewPeriod) {
+					t.Fatalf("want ErrInvalidViewPeriod, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to create view period: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_ViewPeriod_Fill(t *testing.T) {
+	period := &models.ViewPeriod{From: "2024-02-28", To: "2024-03-01"}
+	got := period.Fill([]*models.ViewCount{{Date: "2024-02-29", Views: 3}})
+	want := []*models.ViewCount{
+		{Date: "2024-02-28", Views: 0},
+		{Date: "2024-02-29", Views: 3},
+		{Date: "2024-03-01", Views: 0},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
@@ -18,4 +18,6 @@ type Comment struct {
 
 	Nickname           *string `json:"nickname,omitempty"`
 	AvatarImageFileURL *string `json:"avatarImageFileUrl,omitempty"`
+
+	Reactions []*ReactionCount `json:"reactions,omitempty" db:"-"`
 }
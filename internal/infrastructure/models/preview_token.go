@@ -0,0 +1,12 @@
+package models
+
+type PreviewTokenId string
+
+// PreviewToken は、非公開ブログのプレビュー用トークン
+type PreviewToken struct {
+	Id        PreviewTokenId `json:"id"`
+	BlogId    BlogId         `json:"blogId"`
+	Token     string         `json:"token,omitempty"` // 署名済みトークンは発行時のみ返す
+	ExpiresAt uint           `json:"expiresAt"`
+	Created   uint           `json:"created"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "strings"
+
+// ReactionTarget は、リアクションの対象の種類
+type ReactionTarget string
+
+const (
+	ReactionTargetBlog    ReactionTarget = "blog"
+	ReactionTargetComment ReactionTarget = "comment"
+)
+
+// Reactor は、リアクションした読者
+// ログインユーザーはUserId、匿名の読者はClientIdで識別する
+type Reactor struct {
+	UserId   *UserId
+	ClientId *string
+}
+
+// ReactionCount は、絵文字ごとのリアクション数
+type ReactionCount struct {
+	Emoji string `json:"emoji" db:"emoji"`
+	Count int64  `json:"count" db:"count"`
+}
+
+// ParseReactionEmojis は、カンマ区切りの絵文字の一覧を分割する
+// 空の要素と重複は除外する
+func ParseReactionEmojis(value string) []string {
+	emojis := []string{}
+	seen := map[string]struct{}{}
+	for _, e := range strings.Split(value, ",") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+		emojis = append(emojis, e)
+	}
+	return emojis
+}
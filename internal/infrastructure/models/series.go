@@ -0,0 +1,75 @@
+package models
+
+type SeriesId int64
+
+// Series は、複数のブログを順序付きでまとめた連載
+type Series struct {
+	Id          SeriesId       `json:"id" db:"id"`
+	AuthorId    UserId         `json:"authorId" db:"author_id"`
+	Title       string         `json:"title" db:"title"`
+	Description string         `json:"description" db:"description"`
+	Created     uint           `json:"created" db:"created"`
+	Modified    uint           `json:"modified" db:"modified"`
+	Entries     []*SeriesEntry `json:"entries" db:"-"`
+}
+
+// SeriesEntry は、シリーズに含まれるブログ
+type SeriesEntry struct {
+	BlogId   BlogId `json:"blogId" db:"blog_id"`
+	Position int    `json:"position" db:"position"`
+	Title    string `json:"title" db:"title"`
+	Slug     string `json:"slug" db:"slug"`
+	IsPublic bool   `json:"isPublic" db:"is_public"`
+	Created  uint   `json:"created" db:"created"`
+}
+
+// PublicEntries は、公開済みのブログのみを返す
+func (s *Series) PublicEntries() []*SeriesEntry {
+	entries := make([]*SeriesEntry, 0, len(s.Entries))
+	for _, e := range s.Entries {
+		if e.IsPublic {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// BlogSeriesNavigation は、ブログが所属するシリーズと前後のブログ
+type BlogSeriesNavigation struct {
+	Id       SeriesId     `json:"id"`
+	Title    string       `json:"title"`
+	Position int          `json:"position"`
+	Total    int          `json:"total"`
+	Prev     *SeriesEntry `json:"prev,omitempty"`
+	Next     *SeriesEntry `json:"next,omitempty"`
+}
+
+// Navigation は、シリーズ内でのブログの位置と前後のブログを返す
+// 前後のブログは公開済みのもののみを対象とし、ブログがシリーズに含まれない場合はnilを返す
+func (s *Series) Navigation(blogId BlogId) *BlogSeriesNavigation {
+	var entries []*SeriesEntry
+	for _, e := range s.Entries {
+		if e.IsPublic || e.BlogId == blogId {
+			entries = append(entries, e)
+		}
+	}
+	for i, e := range entries {
+		if e.BlogId != blogId {
+			continue
+		}
+		nav := &BlogSeriesNavigation{
+			Id:       s.Id,
+			Title:    s.Title,
+			Position: i + 1,
+			Total:    len(entries),
+		}
+		if i > 0 {
+			nav.Prev = entries[i-1]
+		}
+		if i < len(entries)-1 {
+			nav.Next = entries[i+1]
+		}
+		return nav
+	}
+	return nil
+}
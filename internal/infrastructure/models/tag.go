@@ -5,8 +5,12 @@ import "slices"
 type TagId int64
 
 type Tag struct {
-	Id   TagId  `json:"id" db:"id"`
-	Name string `json:"name" db:"name"`
+	Id          TagId   `json:"id" db:"id"`
+	Name        string  `json:"name" db:"name"`
+	Description string  `json:"description" db:"description"`
+	Color       *string `json:"color" db:"color"`
+	// Count は、タグが付けられた公開済みのブログの件数
+	Count int64 `json:"count" db:"count"`
 }
 
 type Tags []*Tag
@@ -42,3 +46,9 @@ func (arr BlogsTagsArray) TagNames() []string {
 func (arr BlogsTagsArray) Contains(tag string) bool {
 	return slices.Contains(arr.TagNames(), tag)
 }
+
+// TagLastModified は、タグと、タグに紐づく公開済みブログの最終更新日時
+type TagLastModified struct {
+	Name     string `json:"name" db:"name"`
+	Modified uint   `json:"modified" db:"modified"`
+}
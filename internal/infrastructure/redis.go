@@ -67,3 +67,136 @@ func (r *RedisKVS) Load(ctx context.Context, key string) (*string, error) {
 	val := ret.Val()
 	return &val, nil
 }
+
+// TryLock は、keyが存在しない場合のみtokenを保存してロックを取得する
+// ロックはttl経過後に自動で解放される
+func (r *RedisKVS) TryLock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
+	ok, err := r.SetIfNotExists(ctx, key, token, ttl)
+	if err != nil {
+		return false, fmt.Errorf("failed to lock key: %w", err)
+	}
+	return ok, nil
+}
+
+// unlockScript は、保存されている値がtokenと一致する場合のみキーを削除する
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Unlock は、TryLockで取得したロックを解放する
+// 他のプロセスが取得したロックは解放しない
+func (r *RedisKVS) Unlock(ctx context.Context, key string, token string) error {
+	if err := unlockScript.Run(ctx, r.cli, []string{key}, token).Err(); err != nil {
+		return fmt.Errorf("failed to unlock key: %w", err)
+	}
+	return nil
+}
+
+// SaveWithExpiration は、有効期限を指定して値を保存する
+func (r *RedisKVS) SaveWithExpiration(ctx context.Context, key string, value string, expiration time.Duration) error {
+	ret := r.cli.Set(ctx, key, value, expiration)
+	if ret.Err() != nil {
+		return fmt.Errorf("failed to set key: %w", ret.Err())
+	}
+	return nil
+}
+
+func (r *RedisKVS) Delete(ctx context.Context, key string) error {
+	ret := r.cli.Del(ctx, key)
+	if ret.Err() != nil {
+		return fmt.Errorf("failed to delete key: %w", ret.Err())
+	}
+	return nil
+}
+
+// SetIfNotExists は、keyが存在しない場合のみ有効期限を指定して値を保存する
+// 保存した場合はtrueを返す
+func (r *RedisKVS) SetIfNotExists(ctx context.Context, key string, value string, expiration time.Duration) (bool, error) {
+	ret := r.cli.SetNX(ctx, key, value, expiration)
+	if ret.Err() != nil {
+		return false, fmt.Errorf("failed to setnx key: %w", ret.Err())
+	}
+	return ret.Val(), nil
+}
+
+// IncrementHash は、ハッシュのfieldの値をnだけ加算する
+func (r *RedisKVS) IncrementHash(ctx context.Context, key string, field string, n int64) error {
+	if err := r.cli.HIncrBy(ctx, key, field, n).Err(); err != nil {
+		return fmt.Errorf("failed to hincrby key: %w", err)
+	}
+	return nil
+}
+
+// saveHashFieldScript は、ハッシュのfieldに値を保存し、キーの有効期限を延長する
+// 有効期限は、すでに設定されている有効期限より遅い場合のみ更新する
+var saveHashFieldScript = redis.NewScript(`
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < tonumber(ARGV[3]) then
+	redis.call("PEXPIRE", KEYS[1], ARGV[3])
+end
+return 1
+`)
+
+// SaveHashField は、ハッシュのfieldに値を保存する
+// キーの有効期限は、保存済みのfieldとあわせて最も遅いexpirationまで延長する
+func (r *RedisKVS) SaveHashField(
+	ctx context.Context, key string, field string, value string, expiration time.Duration,
+) error {
+	if err := saveHashFieldScript.Run(ctx, r.cli, []string{key}, field, value, expiration.Milliseconds()).Err(); err != nil {
+		return fmt.Errorf("failed to save hash field: %w", err)
+	}
+	return nil
+}
+
+// LoadHash は、ハッシュの全fieldを取得する
+// キーが存在しない場合は空のmapを返す
+func (r *RedisKVS) LoadHash(ctx context.Context, key string) (map[string]string, error) {
+	ret := r.cli.HGetAll(ctx, key)
+	if ret.Err() != nil {
+		return nil, fmt.Errorf("failed to hgetall key: %w", ret.Err())
+	}
+	return ret.Val(), nil
+}
+
+// LoadHashField は、ハッシュのfieldの値を取得する
+// fieldが存在しない場合はnilを返す
+func (r *RedisKVS) LoadHashField(ctx context.Context, key string, field string) (*string, error) {
+	ret := r.cli.HGet(ctx, key, field)
+	if ret.Err() != nil {
+		if errors.Is(ret.Err(), redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to hget key: %w", ret.Err())
+	}
+	val := ret.Val()
+	return &val, nil
+}
+
+// DeleteHashField は、ハッシュのfieldを削除する
+// 削除した場合はtrueを返す
+func (r *RedisKVS) DeleteHashField(ctx context.Context, key string, field string) (bool, error) {
+	ret := r.cli.HDel(ctx, key, field)
+	if ret.Err() != nil {
+		return false, fmt.Errorf("failed to hdel key: %w", ret.Err())
+	}
+	return ret.Val() > 0, nil
+}
+
+// PopHash は、ハッシュの全fieldを取得し、キーを削除する
+// 取得と削除はトランザクションで行うため、その間の加算が失われることはない
+func (r *RedisKVS) PopHash(ctx context.Context, key string) (map[string]string, error) {
+	var getAll *redis.MapStringStringCmd
+	_, err := r.cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		getAll = pipe.HGetAll(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pop hash: %w", err)
+	}
+	return getAll.Val(), nil
+}
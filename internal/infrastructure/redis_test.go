@@ -3,6 +3,7 @@ package infrastructure_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/shoet/blog/internal/infrastructure"
 )
@@ -49,3 +50,142 @@ func Test_Load(t *testing.T) {
 		t.Errorf("want %s, got %s", want, *ret)
 	}
 }
+
+func Test_TryLock(t *testing.T) {
+	ctx := context.Background()
+	kvs, err := infrastructure.NewRedisKVS(ctx, "127.0.0.1", 6379, "default", "redispw", 10, false)
+	if err != nil {
+		t.Fatalf("failed to create redis kvs: %v", err)
+	}
+
+	key := "test.lock"
+	t.Cleanup(func() {
+		_ = kvs.Unlock(ctx, key, "token1")
+	})
+
+	ok, err := kvs.TryLock(ctx, key, "token1", 10*time.Second)
+	if err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+	if !ok {
+		t.Fatalf("want lock acquired")
+	}
+
+	ok, err = kvs.TryLock(ctx, key, "token2", 10*time.Second)
+	if err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+	if ok {
+		t.Errorf("want lock not acquired while locked")
+	}
+
+	// 他のtokenでは解放できない
+	if err := kvs.Unlock(ctx, key, "token2"); err != nil {
+		t.Fatalf("failed to unlock: %v", err)
+	}
+	ok, err = kvs.TryLock(ctx, key, "token2", 10*time.Second)
+	if err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+	if ok {
+		t.Errorf("want lock not released by other token")
+	}
+
+	if err := kvs.Unlock(ctx, key, "token1"); err != nil {
+		t.Fatalf("failed to unlock: %v", err)
+	}
+	ok, err = kvs.TryLock(ctx, key, "token2", 10*time.Second)
+	if err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+	if !ok {
+		t.Errorf("want lock acquired after unlock")
+	}
+	_ = kvs.Unlock(ctx, key, "token2")
+}
+
+func Test_SaveWithExpiration(t *testing.T) {
+	ctx := context.Background()
+	kvs, err := infrastructure.NewRedisKVS(ctx, "127.0.0.1", 6379, "default", "redispw", 10, false)
+	if err != nil {
+		t.Fatalf("failed to create redis kvs: %v", err)
+	}
+
+	if err := kvs.SaveWithExpiration(ctx, "test.expiration", "test", time.Minute); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	ret, err := kvs.Load(ctx, "test.expiration")
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if ret == nil || *ret != "test" {
+		t.Errorf("want test, got %v", ret)
+	}
+
+	if err := kvs.Delete(ctx, "test.expiration"); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+	ret, err = kvs.Load(ctx, "test.expiration")
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if ret != nil {
+		t.Errorf("want nil after delete, got %v", *ret)
+	}
+}
+
+func Test_SetIfNotExists(t *testing.T) {
+	ctx := context.Background()
+	kvs, err := infrastructure.NewRedisKVS(ctx, "127.0.0.1", 6379, "default", "redispw", 10, false)
+	if err != nil {
+		t.Fatalf("failed to create redis kvs: %v", err)
+	}
+	key := "test.set_if_not_exists"
+	_ = kvs.Delete(ctx, key)
+
+	ok, err := kvs.SetIfNotExists(ctx, key, "1", time.Minute)
+	if err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	if !ok {
+		t.Errorf("want saved")
+	}
+	ok, err = kvs.SetIfNotExists(ctx, key, "2", time.Minute)
+	if err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	if ok {
+		t.Errorf("want not saved when key exists")
+	}
+	_ = kvs.Delete(ctx, key)
+}
+
+func Test_PopHash(t *testing.T) {
+	ctx := context.Background()
+	kvs, err := infrastructure.NewRedisKVS(ctx, "127.0.0.1", 6379, "default", "redispw", 10, false)
+	if err != nil {
+		t.Fatalf("failed to create redis kvs: %v", err)
+	}
+	key := "test.pop_hash"
+	_ = kvs.Delete(ctx, key)
+
+	for _, field := range []string{"a", "a", "b"} {
+		if err := kvs.IncrementHash(ctx, key, field, 1); err != nil {
+			t.Fatalf("failed to increment: %v", err)
+		}
+	}
+	got, err := kvs.PopHash(ctx, key)
+	if err != nil {
+		t.Fatalf("failed to pop: %v", err)
+	}
+	if got["a"] != "2" || got["b"] != "1" {
+		t.Errorf("unexpected hash: %v", got)
+	}
+	got, err = kvs.PopHash(ctx, key)
+	if err != nil {
+		t.Fatalf("failed to pop: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("want empty after pop, got %v", got)
+	}
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// ListArchives は、公開済みのブログの件数を作成日時の年月ごとに集計する
+// 年月はtimezone(IANAのタイムゾーン名)で判定し、新しい年月から順に返す
+func (r *BlogRepository) ListArchives(
+	ctx context.Context, tx infrastructure.TX, timezone string,
+) ([]*models.BlogArchive, error) {
+	createdAt := goqu.L("to_timestamp(blogs.created) AT TIME ZONE ?", timezone)
+	sql, params, err := goqu.
+		Select(
+			goqu.L("EXTRACT(YEAR FROM ?)::int", createdAt).As("year"),
+			goqu.L("EXTRACT(MONTH FROM ?)::int", createdAt).As("month"),
+			goqu.COUNT("*").As("count"),
+		).
+		From("blogs").
+		Where(goqu.Ex{"blogs.is_public": true, "blogs.deleted_at": nil}).
+		GroupBy(goqu.I("year"), goqu.I("month")).
+		Order(goqu.I("year").Desc(), goqu.I("month").Desc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	archives := []*models.BlogArchive{}
+	if err := tx.SelectContext(ctx, &archives, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blogs: %w", err)
+	}
+	return archives, nil
+}
@@ -0,0 +1,91 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_BlogRepository_ListArchives(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	testdata := []struct {
+		created  uint
+		isPublic bool
+	}{
+		// 2024-02-29T15:00:00Z はJSTでは2024-03-01
+		{1709218800, true},
+		// 2024-03-15T00:00:00Z
+		{1710460800, true},
+		// 2024-03-20T00:00:00Z 非公開
+		{1710892800, false},
+		// 2023-12-31T14:59:59Z はJSTでは2023-12-31
+		{1704034799, true},
+	}
+	for _, d := range testdata {
+		id, err := sut.Add(ctx, tx, &models.Blog{
+			AuthorId:    1,
+			Title:       "title",
+			Content:     "content",
+			Description: "description",
+			IsPublic:    d.isPublic,
+		})
+		if err != nil {
+			t.Fatalf("failed to add blog: %v", err)
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE blogs SET created = $1 WHERE id = $2", d.created, id); err != nil {
+			t.Fatalf("failed to update created: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		timezone string
+		want     []*models.BlogArchive
+	}{
+		{
+			name:     "サイトのタイムゾーンで年月を判定する",
+			timezone: "Asia/Tokyo",
+			want: []*models.BlogArchive{
+				{Year: 2024, Month: 3, Count: 2},
+				{Year: 2023, Month: 12, Count: 1},
+			},
+		},
+		{
+			name:     "UTCの場合",
+			timezone: "UTC",
+			want: []*models.BlogArchive{
+				{Year: 2024, Month: 3, Count: 1},
+				{Year: 2024, Month: 2, Count: 1},
+				{Year: 2023, Month: 12, Count: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sut.ListArchives(ctx, tx, tt.timezone)
+			if err != nil {
+				t.Fatalf("failed to list archives: %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
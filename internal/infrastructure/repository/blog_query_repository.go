@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/options"
+	"github.com/shoet/blog/internal/search"
+)
+
+// blogListColumns は一覧として取得するカラム
+// 一覧のレスポンスには本文を含めない
+var blogListColumns = []interface{}{
+	"blogs.id", "blogs.author_id", "blogs.title", "blogs.description",
+	"blogs.thumbnail_image_file_name", "blogs.is_public", "blogs.created", "blogs.modified", "blogs.slug",
+	"blogs.default_locale",
+}
+
+// Query は、絞り込み・並び順・ページングの条件に一致するブログの一覧を取得する
+// キーワードが指定された場合は全文検索し、スニペットを付与する
+// 言語が指定された場合は、その言語の翻訳があるブログのタイトル・概要を翻訳で置き換える
+// 並び順やキーワード検索は既定の言語のタイトル・概要・本文を基準とする
+func (r *BlogRepository) Query(
+	ctx context.Context, tx infrastructure.TX, option *options.ListBlogOptions,
+) (models.Blogs, error) {
+	query, ok := blogSearchQuery(option.Filter)
+	if !ok {
+		return models.Blogs{}, nil
+	}
+	builder := applyBlogFilter(blogDataset(query), option.Filter)
+	sortKey := blogSortExpression(option.Sort.Key, query)
+	// カーソルの生成のため、並び順の基準の値もあわせて取得する
+	sortValue := goqu.L("(?)::text", sortKey).As("sort_value")
+	if query != "" {
+		builder = builder.Select(append(searchColumns(query), sortValue)...)
+	} else {
+		builder = builder.Select(append(slices.Clone(blogListColumns), sortValue)...)
+	}
+
+	desc := !option.Sort.Asc
+	prev := option.Page == 0 && option.Cursor != nil && option.Cursor.Direction == options.PageDirectionPrev
+	if option.Page > 0 {
+		// オフセット方式
+		builder = builder.
+			Order(blogOrder(sortKey, desc), blogOrder(goqu.I("blogs.id"), desc)).
+			Offset(uint((option.Page - 1) * option.Limit))
+	} else {
+		// カーソル方式
+		// 前のページは逆順で取得し、取得後に並び順を戻す
+		if prev {
+			desc = !desc
+		}
+		if option.Cursor != nil {
+			builder = builder.Where(blogCursorExpression(option.Sort.Key, query, option.Cursor, desc))
+		}
+		builder = builder.Order(blogOrder(sortKey, desc), blogOrder(goqu.I("blogs.id"), desc))
+	}
+	builder = builder.Limit(uint(option.Limit))
+
+	sql, params, err := builder.ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var rows []*blogSearchRow
+	if err := tx.SelectContext(ctx, &rows, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blogs: %w", err)
+	}
+	var blogs models.Blogs
+	if query != "" {
+		blogs = toSearchResult(rows, *option.Filter.KeyWord)
+	} else {
+		blogs = make(models.Blogs, 0, len(rows))
+		for _, row := range rows {
+			blog := row.Blog
+			blogs = append(blogs, &blog)
+		}
+	}
+	if prev {
+		slices.Reverse(blogs)
+	}
+
+	// タグはブログごとに取得せず、まとめて取得する
+	ids := make([]models.BlogId, 0, len(blogs))
+	for _, blog := range blogs {
+		ids = append(ids, blog.Id)
+	}
+	tags, err := r.ListBlogTags(ctx, tx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blog tags: %w", err)
+	}
+	for _, blog := range blogs {
+		blog.Tags = tags[blog.Id]
+		if blog.Tags == nil {
+			blog.Tags = []string{}
+		}
+	}
+
+	if option.Filter.Locale != nil {
+		if err := r.applyTranslations(ctx, tx, blogs, ids, *option.Filter.Locale); err != nil {
+			return nil, err
+		}
+	}
+	return blogs, nil
+}
+
+// applyTranslations は、一覧のブログのタイトル・概要を指定した言語の翻訳で置き換える
+// 既定の言語が指定した言語のブログは置き換えない
+func (r *BlogRepository) applyTranslations(
+	ctx context.Context, tx infrastructure.TX, blogs models.Blogs, ids []models.BlogId, locale string,
+) error {
+	translations, err := r.ListTranslations(ctx, tx, ids, locale)
+	if err != nil {
+		return fmt.Errorf("failed to list translations: %w", err)
+	}
+	for _, blog := range blogs {
+		if t, ok := translations[blog.Id]; ok {
+			blog.ApplyTranslation(t)
+			continue
+		}
+		blog.Locale = blog.DefaultLocale
+	}
+	return nil
+}
+
+// Count は、絞り込み条件に一致するブログの件数を取得する
+func (r *BlogRepository) Count(
+	ctx context.Context, tx infrastructure.TX, filter options.BlogFilter,
+) (int64, error) {
+	query, ok := blogSearchQuery(filter)
+	if !ok {
+		return 0, nil
+	}
+	sql, params, err := applyBlogFilter(blogDataset(query), filter).
+		Select(goqu.COUNT("*")).
+		ToSQL()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var count int64
+	if err := tx.QueryRowxContext(ctx, sql, params...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count blogs: %w", err)
+	}
+	return count, nil
+}
+
+// blogSearchQuery は、キーワードからtsqueryのリテラルを生成する
+// キーワードが指定されていない場合は空文字を返し、
+// 検索できる語を含まないキーワードの場合は一致するブログがないためfalseを返す
+func blogSearchQuery(filter options.BlogFilter) (string, bool) {
+	if filter.KeyWord == nil {
+		return "", true
+	}
+	query := search.BuildQuery(*filter.KeyWord)
+	return query, query != ""
+}
+
+// blogDataset は、一覧を取得するクエリのベースを返す
+// キーワードが指定された場合は検索インデックスと結合する
+func blogDataset(query string) *goqu.SelectDataset {
+	if query != "" {
+		return searchDataset(query)
+	}
+	return goqu.From("blogs")
+}
+
+// applyBlogFilter は、一覧の絞り込み条件をWHERE句として追加する
+// キーワードはblogDatasetで扱い、ゴミ箱にあるブログは常に除く
+func applyBlogFilter(builder *goqu.SelectDataset, filter options.BlogFilter) *goqu.SelectDataset {
+	builder = builder.Where(goqu.Ex{"blogs.deleted_at": nil})
+	if filter.IsPublic {
+		builder = builder.Where(goqu.I("blogs.is_public").IsTrue())
+	}
+	if len(filter.Tags) > 0 {
+		blogIds := goqu.
+			From("blogs_tags").
+			Join(
+				goqu.T("tags"),
+				goqu.On(goqu.Ex{"blogs_tags.tag_id": goqu.I("tags.id")}),
+			).
+			Where(goqu.Ex{"tags.name": filter.Tags}).
+			Select(goqu.I("blogs_tags.blog_id"))
+		if filter.TagMatch == options.TagMatchAll {
+			// 指定されたタグをすべて持つブログのみとする
+			blogIds = blogIds.
+				GroupBy(goqu.I("blogs_tags.blog_id")).
+				Having(goqu.COUNT(goqu.DISTINCT("tags.id")).Eq(len(filter.Tags)))
+		}
+		builder = builder.Where(goqu.I("blogs.id").In(blogIds))
+	}
+	if filter.CreatedFrom != nil {
+		builder = builder.Where(goqu.I("blogs.created").Gte(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		builder = builder.Where(goqu.I("blogs.created").Lt(*filter.CreatedTo))
+	}
+	if filter.AuthorId != nil {
+		builder = builder.Where(goqu.I("blogs.author_id").Eq(*filter.AuthorId))
+	}
+	if filter.Locale != nil {
+		// 既定の言語が一致するか、その言語の翻訳があるブログとする
+		translated := goqu.
+			From("blog_translations").
+			Select("blog_id").
+			Where(goqu.Ex{"locale": *filter.Locale})
+		builder = builder.Where(goqu.Or(
+			goqu.I("blogs.default_locale").Eq(*filter.Locale),
+			goqu.I("blogs.id").In(translated),
+		))
+	}
+	return builder
+}
+
+// blogSortExpression は、並び順の基準となる値の式を返す
+func blogSortExpression(key options.BlogSortKey, query string) exp.Expression {
+	switch key {
+	case options.BlogSortCreated:
+		return goqu.I("blogs.created")
+	case options.BlogSortModified:
+		return goqu.I("blogs.modified")
+	case options.BlogSortTitle:
+		return goqu.I("blogs.title")
+	case options.BlogSortPopularity:
+		return goqu.L(
+			"COALESCE((SELECT SUM(blog_view_daily.views) FROM blog_view_daily WHERE blog_view_daily.blog_id = blogs.id), 0)",
+		)
+	case options.BlogSortRelevance:
+		if query != "" {
+			return goqu.L("ts_rank(blog_search_index.document, ?::tsquery)", query)
+		}
+	}
+	return goqu.I("blogs.id")
+}
+
+// blogCursorExpression は、カーソルの位置より後に並ぶブログに絞り込む条件を返す
+// 並び順の基準の値とIDの組で比較するため、基準の値が同じブログがあっても欠落しない
+func blogCursorExpression(
+	key options.BlogSortKey, query string, cursor *options.BlogCursor, desc bool,
+) exp.Expression {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	if key == options.BlogSortId || key == "" {
+		return goqu.L(fmt.Sprintf("? %s ?", op), goqu.I("blogs.id"), cursor.Id)
+	}
+	return goqu.L(
+		fmt.Sprintf("(?, ?) %s (?::%s, ?)", op, blogSortValueType(key)),
+		blogSortExpression(key, query), goqu.I("blogs.id"), cursor.Value, cursor.Id,
+	)
+}
+
+// blogSortValueType は、カーソルに文字列で保持した並び順の基準の値を比較する際の型を返す
+func blogSortValueType(key options.BlogSortKey) string {
+	switch key {
+	case options.BlogSortTitle:
+		return "text"
+	case options.BlogSortPopularity:
+		return "numeric"
+	case options.BlogSortRelevance:
+		return "real"
+	}
+	return "bigint"
+}
+
+func blogOrder(e exp.Expression, desc bool) exp.OrderedExpression {
+	orderable, ok := e.(exp.Orderable)
+	if !ok {
+		orderable = goqu.L("?", e)
+	}
+	if desc {
+		return orderable.Desc()
+	}
+	return orderable.Asc()
+}
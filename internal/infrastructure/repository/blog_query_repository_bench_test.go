@@ -0,0 +1,180 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/options"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+// countingTX は、発行したクエリの回数を数えるTX
+type countingTX struct {
+	infrastructure.TX
+	count int
+}
+
+func (c *countingTX) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	c.count++
+	return c.TX.QueryxContext(ctx, query, args...)
+}
+
+func (c *countingTX) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	c.count++
+	return c.TX.QueryRowxContext(ctx, query, args...)
+}
+
+func (c *countingTX) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	c.count++
+	return c.TX.SelectContext(ctx, dest, query, args...)
+}
+
+// prepareBlogsWithTags は、タグを付けた公開済みのブログをcount件登録する
+func prepareBlogsWithTags(
+	tb testing.TB, ctx context.Context, tx infrastructure.TX, sut *repository.BlogRepository, count int,
+) {
+	tb.Helper()
+	tagIds := make([]models.TagId, 0, 3)
+	for i := 0; i < 3; i++ {
+		tagId, err := sut.AddTag(ctx, tx, fmt.Sprintf("tag%d", i))
+		if err != nil {
+			tb.Fatalf("failed to add tag: %v", err)
+		}
+		tagIds = append(tagIds, tagId)
+	}
+	for i := 0; i < count; i++ {
+		id, err := sut.Add(ctx, tx, &models.Blog{
+			AuthorId:    1,
+			Title:       fmt.Sprintf("title%d", i),
+			Content:     "content",
+			Description: "description",
+			IsPublic:    true,
+		})
+		if err != nil {
+			tb.Fatalf("failed to add blog: %v", err)
+		}
+		for _, tagId := range tagIds[:i%len(tagIds)+1] {
+			if _, err := sut.AddBlogTag(ctx, tx, id, tagId); err != nil {
+				tb.Fatalf("failed to add blogs_tags: %v", err)
+			}
+		}
+	}
+}
+
+func Test_BlogRepository_Query_TagsQueryCount(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	prepareBlogsWithTags(t, ctx, tx, sut, 20)
+
+	for _, limit := range []int64{1, 10, 20} {
+		t.Run(fmt.Sprintf("limit=%d", limit), func(t *testing.T) {
+			counter := &countingTX{TX: tx}
+			option, err := options.NewListBlogOptions(&options.BlogFilter{}, &options.BlogSort{}, nil, &limit)
+			if err != nil {
+				t.Fatalf("failed to create options: %v", err)
+			}
+			blogs, err := sut.Query(ctx, counter, option)
+			if err != nil {
+				t.Fatalf("failed to query: %v", err)
+			}
+			if len(blogs) != int(limit) {
+				t.Fatalf("unexpected blogs count: want %d, got %d", limit, len(blogs))
+			}
+			for _, b := range blogs {
+				if len(b.Tags) == 0 {
+					t.Errorf("tags not loaded: blog %d", b.Id)
+				}
+			}
+			// 一覧の取得とタグの取得の2回のみで、件数に依存しない
+			if counter.count != 2 {
+				t.Errorf("unexpected query count: want 2, got %d", counter.count)
+			}
+		})
+	}
+}
+
+// Benchmark_BlogRepository_Query_Tags は、ブログ一覧のタグの取得について
+// ブログごとに取得する場合(per_row)とまとめて取得する場合(batched)を比較する
+// queryは一覧の取得を含めたQuery全体を計測する
+//
+//	go test ./internal/infrastructure/repository -run '^$' -bench Query_Tags
+func Benchmark_BlogRepository_Query_Tags(b *testing.B) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(b, ctx)
+	if err != nil {
+		b.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(b, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	prepareBlogsWithTags(b, ctx, tx, sut, 50)
+
+	for _, limit := range []int64{10, 50} {
+		option, err := options.NewListBlogOptions(&options.BlogFilter{}, &options.BlogSort{}, nil, &limit)
+		if err != nil {
+			b.Fatalf("failed to create options: %v", err)
+		}
+		blogs, err := sut.Query(ctx, tx, option)
+		if err != nil {
+			b.Fatalf("failed to query: %v", err)
+		}
+		ids := make([]models.BlogId, 0, len(blogs))
+		for _, blog := range blogs {
+			ids = append(ids, blog.Id)
+		}
+
+		b.Run(fmt.Sprintf("tags/per_row/limit=%d", limit), func(b *testing.B) {
+			counter := &countingTX{TX: tx}
+			for i := 0; i < b.N; i++ {
+				for _, id := range ids {
+					if _, err := sut.WithBlogTags(ctx, counter, id); err != nil {
+						b.Fatalf("failed to select blogs_tags: %v", err)
+					}
+				}
+			}
+			b.ReportMetric(float64(counter.count)/float64(b.N), "queries/op")
+		})
+
+		b.Run(fmt.Sprintf("tags/batched/limit=%d", limit), func(b *testing.B) {
+			counter := &countingTX{TX: tx}
+			for i := 0; i < b.N; i++ {
+				if _, err := sut.ListBlogTags(ctx, counter, ids); err != nil {
+					b.Fatalf("failed to list blog tags: %v", err)
+				}
+			}
+			b.ReportMetric(float64(counter.count)/float64(b.N), "queries/op")
+		})
+
+		b.Run(fmt.Sprintf("query/limit=%d", limit), func(b *testing.B) {
+			counter := &countingTX{TX: tx}
+			for i := 0; i < b.N; i++ {
+				if _, err := sut.Query(ctx, counter, option); err != nil {
+					b.Fatalf("failed to query: %v", err)
+				}
+			}
+			b.ReportMetric(float64(counter.count)/float64(b.N), "queries/op")
+		})
+	}
+}
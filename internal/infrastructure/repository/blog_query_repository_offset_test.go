@@ -15,7 +15,7 @@ import (
 	"github.com/shoet/blog/internal/testutil"
 )
 
-func Test_BlogRepositoryOffset_List(t *testing.T) {
+func Test_BlogRepository_QueryOffset(t *testing.T) {
 	ctx := context.Background()
 	clocker := &clocker.FiexedClocker{}
 	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
@@ -24,7 +24,7 @@ func Test_BlogRepositoryOffset_List(t *testing.T) {
 	}
 	testutil.RepositoryTestPrepare(t, ctx, db)
 
-	sut := repository.NewBlogRepositoryOffset(clocker)
+	sut := repository.NewBlogRepository(clocker)
 
 	testdata := []*models.Blog{
 		{Id: 1, AuthorId: 1, Title: "title1", Content: "content1", Description: "description1", ThumbnailImageFileName: "thumbnail1", IsPublic: false},
@@ -60,9 +60,9 @@ func Test_BlogRepositoryOffset_List(t *testing.T) {
 			name: "単純なLimit",
 			args: args{
 				option: &options.ListBlogOptions{
-					IsPublic: true,
-					Limit:    5,
-					Page:     1,
+					Filter: options.BlogFilter{IsPublic: true},
+					Limit:  5,
+					Page:   1,
 				},
 			},
 			wants: wants{
@@ -80,9 +80,9 @@ func Test_BlogRepositoryOffset_List(t *testing.T) {
 			name: "ページの指定",
 			args: args{
 				option: &options.ListBlogOptions{
-					IsPublic: true,
-					Limit:    5,
-					Page:     2,
+					Filter: options.BlogFilter{IsPublic: true},
+					Limit:  5,
+					Page:   2,
 				},
 			},
 			wants: wants{
@@ -100,9 +100,9 @@ func Test_BlogRepositoryOffset_List(t *testing.T) {
 			name: "末尾",
 			args: args{
 				option: &options.ListBlogOptions{
-					IsPublic: true,
-					Limit:    6,
-					Page:     3,
+					Filter: options.BlogFilter{IsPublic: true},
+					Limit:  6,
+					Page:   3,
 				},
 			},
 			wants: wants{
@@ -117,9 +117,9 @@ func Test_BlogRepositoryOffset_List(t *testing.T) {
 			name: "範囲外の指定",
 			args: args{
 				option: &options.ListBlogOptions{
-					IsPublic: true,
-					Limit:    5,
-					Page:     4,
+					Filter: options.BlogFilter{IsPublic: true},
+					Limit:  5,
+					Page:   4,
 				},
 			},
 			wants: wants{
@@ -177,13 +177,13 @@ func Test_BlogRepositoryOffset_List(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			got, err := sut.List(ctx, db, tt.args.option)
+			got, err := sut.Query(ctx, db, tt.args.option)
 			if diff := cmp.Diff(tt.wants.err, err); diff != "" {
 				t.Errorf("unexpected error: %v", diff)
 			}
 
 			options := cmp.Options{
-				cmpopts.IgnoreFields(models.Blog{}, "Created", "Modified", "Tags", "Content"),
+				cmpopts.IgnoreFields(models.Blog{}, "Created", "Modified", "Version", "Slug", "DefaultLocale", "Tags", "Content", "SortValue"),
 			}
 
 			if diff := cmp.Diff(tt.wants.blogs, got, options); diff != "" {
@@ -207,7 +207,7 @@ func Test_BlogRepositoryOffset_List(t *testing.T) {
 	}
 }
 
-func Test_BlogRepositoryOffset_ListByTag(t *testing.T) {
+func Test_BlogRepository_QueryOffset_Tag(t *testing.T) {
 	ctx := context.Background()
 	clocker := &clocker.FiexedClocker{}
 	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
@@ -216,7 +216,7 @@ func Test_BlogRepositoryOffset_ListByTag(t *testing.T) {
 	}
 	testutil.RepositoryTestPrepare(t, ctx, db)
 
-	sut := repository.NewBlogRepositoryOffset(clocker)
+	sut := repository.NewBlogRepository(clocker)
 
 	testdataTags := []*models.Tag{
 		{Id: 1, Name: "tag1"},
@@ -272,9 +272,9 @@ func Test_BlogRepositoryOffset_ListByTag(t *testing.T) {
 			name: "単純なLimit",
 			args: args{
 				option: &options.ListBlogOptions{
-					IsPublic: true,
-					Limit:    3,
-					Page:     1,
+					Filter: options.BlogFilter{IsPublic: true},
+					Limit:  3,
+					Page:   1,
 				},
 				tag: "tag1",
 			},
@@ -291,9 +291,9 @@ func Test_BlogRepositoryOffset_ListByTag(t *testing.T) {
 			name: "ページの指定",
 			args: args{
 				option: &options.ListBlogOptions{
-					IsPublic: true,
-					Limit:    3,
-					Page:     2,
+					Filter: options.BlogFilter{IsPublic: true},
+					Limit:  3,
+					Page:   2,
 				},
 				tag: "tag1",
 			},
@@ -309,9 +309,9 @@ func Test_BlogRepositoryOffset_ListByTag(t *testing.T) {
 			name: "is_public=true",
 			args: args{
 				option: &options.ListBlogOptions{
-					IsPublic: true,
-					Limit:    5,
-					Page:     1,
+					Filter: options.BlogFilter{IsPublic: true},
+					Limit:  5,
+					Page:   1,
 				},
 				tag: "tag3",
 			},
@@ -330,9 +330,9 @@ func Test_BlogRepositoryOffset_ListByTag(t *testing.T) {
 			name: "is_public=false",
 			args: args{
 				option: &options.ListBlogOptions{
-					IsPublic: false,
-					Limit:    5,
-					Page:     1,
+					Filter: options.BlogFilter{IsPublic: false},
+					Limit:  5,
+					Page:   1,
 				},
 				tag: "tag3",
 			},
@@ -426,13 +426,15 @@ func Test_BlogRepositoryOffset_ListByTag(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			got, err := sut.ListByTag(ctx, db, tt.args.tag, tt.args.option)
+			option := *tt.args.option
+			option.Filter.Tags = []string{tt.args.tag}
+			got, err := sut.Query(ctx, db, &option)
 			if diff := cmp.Diff(tt.wants.err, err); diff != "" {
 				t.Errorf("unexpected error: %v", diff)
 			}
 
 			options := cmp.Options{
-				cmpopts.IgnoreFields(models.Blog{}, "Created", "Modified", "Content"),
+				cmpopts.IgnoreFields(models.Blog{}, "Created", "Modified", "Version", "Slug", "DefaultLocale", "Tags", "Content", "SortValue"),
 			}
 
 			if diff := cmp.Diff(tt.wants.blogs, got, options); diff != "" {
@@ -483,7 +485,7 @@ func Test_BlogRepositoryOffset_ListByTag(t *testing.T) {
 
 }
 
-func Test_BlogRepositoryOffset_ListByKeyword(t *testing.T) {
+func Test_BlogRepository_QueryOffset_Keyword(t *testing.T) {
 	ctx := context.Background()
 	clocker := &clocker.FiexedClocker{}
 	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
@@ -492,7 +494,7 @@ func Test_BlogRepositoryOffset_ListByKeyword(t *testing.T) {
 	}
 	testutil.RepositoryTestPrepare(t, ctx, db)
 
-	sut := repository.NewBlogRepositoryOffset(clocker)
+	sut := repository.NewBlogRepository(clocker)
 
 	testdata := []*models.Blog{
 		{Id: 1, AuthorId: 1, Title: "title1XXX", Content: "content1AAA", Description: "description1AAA", ThumbnailImageFileName: "thumbnail1", IsPublic: false},
@@ -530,9 +532,9 @@ func Test_BlogRepositoryOffset_ListByKeyword(t *testing.T) {
 			args: args{
 				keyword: "AAA",
 				option: &options.ListBlogOptions{
-					IsPublic: true,
-					Limit:    2,
-					Page:     2,
+					Filter: options.BlogFilter{IsPublic: true},
+					Limit:  2,
+					Page:   2,
 				},
 			},
 			wants: wants{
@@ -548,9 +550,9 @@ func Test_BlogRepositoryOffset_ListByKeyword(t *testing.T) {
 			args: args{
 				keyword: "ZZZ",
 				option: &options.ListBlogOptions{
-					IsPublic: true,
-					Limit:    3,
-					Page:     2,
+					Filter: options.BlogFilter{IsPublic: true},
+					Limit:  3,
+					Page:   2,
 				},
 			},
 			wants: wants{
@@ -588,17 +590,26 @@ func Test_BlogRepositoryOffset_ListByKeyword(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to insert test data: %v", err)
 	}
+	for _, d := range testdata {
+		if err := sut.UpsertSearchIndex(ctx, db, d); err != nil {
+			t.Fatalf("failed to upsert search index: %v", err)
+		}
+	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			got, err := sut.ListByKeyword(ctx, db, tt.args.keyword, tt.args.option)
+			// キーワード検索は関連度の高い順とする
+			option := *tt.args.option
+			option.Filter.KeyWord = &tt.args.keyword
+			option.Sort = options.BlogSort{Key: options.BlogSortRelevance}
+			got, err := sut.Query(ctx, db, &option)
 			if diff := cmp.Diff(tt.wants.err, err); diff != "" {
 				t.Errorf("unexpected error: %v", diff)
 			}
 
 			options := cmp.Options{
-				cmpopts.IgnoreFields(models.Blog{}, "Created", "Modified", "Tags", "Content"),
+				cmpopts.IgnoreFields(models.Blog{}, "Created", "Modified", "Version", "Slug", "DefaultLocale", "Tags", "Content", "Snippet", "SortValue"),
 			}
 
 			if diff := cmp.Diff(tt.wants.blogs, got, options); diff != "" {
@@ -0,0 +1,311 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/options"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_BlogRepository_Query_Filter(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	testdata := []struct {
+		authorId models.UserId
+		created  uint
+		tags     []string
+	}{
+		{1, 1000, []string{"go", "aws"}},
+		{1, 2000, []string{"go"}},
+		{2, 3000, []string{"aws"}},
+		{2, 4000, []string{}},
+	}
+	tagIds := map[string]models.TagId{}
+	var blogIds []models.BlogId
+	for _, d := range testdata {
+		id, err := sut.Add(ctx, tx, &models.Blog{
+			AuthorId:    d.authorId,
+			Title:       "title",
+			Content:     "content",
+			Description: "description",
+			IsPublic:    true,
+		})
+		if err != nil {
+			t.Fatalf("failed to add blog: %v", err)
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE blogs SET created = $1 WHERE id = $2", d.created, id); err != nil {
+			t.Fatalf("failed to update created: %v", err)
+		}
+		for _, tag := range d.tags {
+			tagId, ok := tagIds[tag]
+			if !ok {
+				tagId, err = sut.AddTag(ctx, tx, tag)
+				if err != nil {
+					t.Fatalf("failed to add tag: %v", err)
+				}
+				tagIds[tag] = tagId
+			}
+			if _, err := sut.AddBlogTag(ctx, tx, id, tagId); err != nil {
+				t.Fatalf("failed to add blogs_tags: %v", err)
+			}
+		}
+		blogIds = append(blogIds, id)
+	}
+
+	authorId := models.UserId(2)
+	createdFrom := uint(2000)
+	createdTo := uint(4000)
+	tests := []struct {
+		name   string
+		filter options.BlogFilter
+		want   []models.BlogId
+	}{
+		{
+			name:   "すべてのタグを持つ",
+			filter: options.BlogFilter{Tags: []string{"go", "aws"}, TagMatch: options.TagMatchAll},
+			want:   []models.BlogId{blogIds[0]},
+		},
+		{
+			name:   "いずれかのタグを持つ",
+			filter: options.BlogFilter{Tags: []string{"go", "aws"}, TagMatch: options.TagMatchAny},
+			want:   []models.BlogId{blogIds[2], blogIds[1], blogIds[0]},
+		},
+		{
+			name:   "著者",
+			filter: options.BlogFilter{AuthorId: &authorId},
+			want:   []models.BlogId{blogIds[3], blogIds[2]},
+		},
+		{
+			name:   "作成日時の範囲",
+			filter: options.BlogFilter{CreatedFrom: &createdFrom, CreatedTo: &createdTo},
+			want:   []models.BlogId{blogIds[2], blogIds[1]},
+		},
+		{
+			name: "タグと著者と作成日時の組み合わせ",
+			filter: options.BlogFilter{
+				Tags: []string{"aws"}, TagMatch: options.TagMatchAny,
+				AuthorId: &authorId, CreatedFrom: &createdFrom,
+			},
+			want: []models.BlogId{blogIds[2]},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			filter.IsPublic = true
+			option := &options.ListBlogOptions{
+				Filter: filter,
+				Limit:  10,
+				Page:   1,
+			}
+			blogs, err := sut.Query(ctx, tx, option)
+			if err != nil {
+				t.Fatalf("failed to list blogs: %v", err)
+			}
+			if len(blogs) != len(tt.want) {
+				t.Fatalf("want %d blogs, got %d", len(tt.want), len(blogs))
+			}
+			for i, id := range tt.want {
+				if blogs[i].Id != id {
+					t.Errorf("want blog id %d, got %d", id, blogs[i].Id)
+				}
+			}
+			count, err := sut.Count(ctx, tx, option.Filter)
+			if err != nil {
+				t.Fatalf("failed to count blogs: %v", err)
+			}
+			if count != int64(len(tt.want)) {
+				t.Errorf("want count %d, got %d", len(tt.want), count)
+			}
+		})
+	}
+}
+
+func Test_BlogRepository_Query_Sort(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	testdata := []struct {
+		id       models.BlogId
+		title    string
+		created  uint
+		modified uint
+		views    int64
+	}{
+		{1, "c", 100, 400, 10},
+		{2, "a", 300, 100, 30},
+		{3, "b", 200, 300, 30},
+		{4, "d", 400, 200, 0},
+	}
+	for _, d := range testdata {
+		sql, params, err := goqu.
+			Insert("blogs").
+			Rows(goqu.Record{
+				"id":          d.id,
+				"author_id":   1,
+				"title":       d.title,
+				"content":     "content",
+				"description": "description",
+				"is_public":   true,
+				"created":     d.created,
+				"modified":    d.modified,
+			}).
+			ToSQL()
+		if err != nil {
+			t.Fatalf("failed to build sql: %v", err)
+		}
+		if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+			t.Fatalf("failed to insert blog: %v", err)
+		}
+		if d.views > 0 {
+			views := []*models.BlogViewDaily{{BlogId: d.id, Date: "2024-01-01", Views: d.views}}
+			if err := sut.AddViews(ctx, tx, views); err != nil {
+				t.Fatalf("failed to add views: %v", err)
+			}
+		}
+	}
+
+	cursor := func(sort options.BlogSort, value string, id models.BlogId, direction options.PageDirection) *options.BlogCursor {
+		return &options.BlogCursor{Sort: sort, Value: value, Id: id, Direction: direction}
+	}
+	popularity := options.BlogSort{Key: options.BlogSortPopularity}
+	titleAsc := options.BlogSort{Key: options.BlogSortTitle, Asc: true}
+	modified := options.BlogSort{Key: options.BlogSortModified}
+	tests := []struct {
+		name   string
+		option options.ListBlogOptions
+		want   []models.BlogId
+	}{
+		{
+			name:   "作成日時の昇順",
+			option: options.ListBlogOptions{Sort: options.BlogSort{Key: options.BlogSortCreated, Asc: true}, Limit: 10},
+			want:   []models.BlogId{1, 3, 2, 4},
+		},
+		{
+			name:   "更新日時の降順",
+			option: options.ListBlogOptions{Sort: options.BlogSort{Key: options.BlogSortModified}, Limit: 10},
+			want:   []models.BlogId{1, 3, 4, 2},
+		},
+		{
+			name:   "タイトルの昇順",
+			option: options.ListBlogOptions{Sort: options.BlogSort{Key: options.BlogSortTitle, Asc: true}, Limit: 10},
+			want:   []models.BlogId{2, 3, 1, 4},
+		},
+		{
+			name:   "閲覧数の降順で、同数の場合はIDの降順",
+			option: options.ListBlogOptions{Sort: options.BlogSort{Key: options.BlogSortPopularity}, Limit: 10},
+			want:   []models.BlogId{3, 2, 1, 4},
+		},
+		{
+			name: "閲覧数の降順でカーソルの次のページ",
+			option: options.ListBlogOptions{
+				Sort: popularity, Limit: 2,
+				Cursor: cursor(popularity, "30", 3, options.PageDirectionNext),
+			},
+			want: []models.BlogId{2, 1},
+		},
+		{
+			name: "閲覧数の降順でカーソルの前のページ",
+			option: options.ListBlogOptions{
+				Sort: popularity, Limit: 2,
+				Cursor: cursor(popularity, "10", 1, options.PageDirectionPrev),
+			},
+			want: []models.BlogId{3, 2},
+		},
+		{
+			name: "タイトルの昇順でカーソルの次のページ",
+			option: options.ListBlogOptions{
+				Sort: titleAsc, Limit: 2,
+				Cursor: cursor(titleAsc, "b", 3, options.PageDirectionNext),
+			},
+			want: []models.BlogId{1, 4},
+		},
+		{
+			name: "タイトルの昇順でカーソルの前のページ",
+			option: options.ListBlogOptions{
+				Sort: titleAsc, Limit: 2,
+				Cursor: cursor(titleAsc, "d", 4, options.PageDirectionPrev),
+			},
+			want: []models.BlogId{3, 1},
+		},
+		{
+			name: "更新日時の降順でカーソルの次のページ",
+			option: options.ListBlogOptions{
+				Sort: modified, Limit: 2,
+				Cursor: cursor(modified, "300", 3, options.PageDirectionNext),
+			},
+			want: []models.BlogId{4, 2},
+		},
+		{
+			name: "閲覧数の降順でオフセット",
+			option: options.ListBlogOptions{
+				Sort: options.BlogSort{Key: options.BlogSortPopularity}, Limit: 2, Page: 2,
+			},
+			want: []models.BlogId{1, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blogs, err := sut.Query(ctx, tx, &tt.option)
+			if err != nil {
+				t.Fatalf("failed to query blogs: %v", err)
+			}
+			got := make([]models.BlogId, 0, len(blogs))
+			for _, b := range blogs {
+				got = append(got, b.Id)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("want %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+	t.Run("取得したブログの並び順の基準の値からカーソルを生成できる", func(t *testing.T) {
+		option := &options.ListBlogOptions{Sort: popularity, Limit: 2}
+		blogs, err := sut.Query(ctx, tx, option)
+		if err != nil {
+			t.Fatalf("failed to query blogs: %v", err)
+		}
+		if len(blogs) != 2 || blogs[1].SortValue != "30" {
+			t.Fatalf("unexpected sort value: %+v", blogs)
+		}
+		option.Cursor = options.NewBlogCursor(popularity, blogs[1], options.PageDirectionNext)
+		blogs, err = sut.Query(ctx, tx, option)
+		if err != nil {
+			t.Fatalf("failed to query blogs: %v", err)
+		}
+		if len(blogs) != 2 || blogs[0].Id != 1 || blogs[1].Id != 4 {
+			t.Fatalf("unexpected next page: %+v", blogs)
+		}
+	})
+}
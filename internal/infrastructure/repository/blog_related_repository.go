@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// ListRelatedCandidates は、関連ブログの候補となる公開済みのブログをタグとともに全件取得する
+// 本文は取得しない
+func (r *BlogRepository) ListRelatedCandidates(
+	ctx context.Context, tx infrastructure.TX,
+) ([]*models.Blog, error) {
+	sql, params, err := goqu.
+		Select(
+			"id", "author_id", "title", "description",
+			"thumbnail_image_file_name", "is_public", "created", "modified", "slug",
+		).
+		From("blogs").
+		Where(goqu.Ex{"is_public": true, "deleted_at": nil}).
+		Order(goqu.I("id").Desc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	blogs := []*models.Blog{}
+	if err := tx.SelectContext(ctx, &blogs, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blogs: %w", err)
+	}
+
+	// タグはブログごとに取得せず、公開済みのブログの分をまとめて取得する
+	sql, params, err = goqu.
+		Select("blogs_tags.blog_id", goqu.I("tags.name").As("tag")).
+		From("blogs_tags").
+		Join(
+			goqu.T("tags"),
+			goqu.On(goqu.Ex{"blogs_tags.tag_id": goqu.I("tags.id")}),
+		).
+		Join(
+			goqu.T("blogs"),
+			goqu.On(goqu.Ex{"blogs.id": goqu.I("blogs_tags.blog_id")}),
+		).
+		Where(goqu.Ex{"blogs.is_public": true, "blogs.deleted_at": nil}).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var blogTags []*BlogTag
+	if err := tx.SelectContext(ctx, &blogTags, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blogs_tags: %w", err)
+	}
+	tags := map[models.BlogId][]string{}
+	for _, bt := range blogTags {
+		tags[bt.BlogId] = append(tags[bt.BlogId], bt.Tag)
+	}
+	for _, blog := range blogs {
+		blog.Tags = tags[blog.Id]
+		sort.Strings(blog.Tags)
+	}
+	return blogs, nil
+}
+
+// ListBlogIdsByTags は、指定したタグのいずれかを持つブログのIDを取得する
+func (r *BlogRepository) ListBlogIdsByTags(
+	ctx context.Context, tx infrastructure.TX, tags []string,
+) ([]models.BlogId, error) {
+	if len(tags) == 0 {
+		return []models.BlogId{}, nil
+	}
+	sql, params, err := goqu.
+		From("blogs_tags").
+		SelectDistinct("blogs_tags.blog_id").
+		Join(
+			goqu.T("tags"),
+			goqu.On(goqu.Ex{"blogs_tags.tag_id": goqu.I("tags.id")}),
+		).
+		Where(goqu.Ex{"tags.name": tags}).
+		Order(goqu.I("blogs_tags.blog_id").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	ids := []models.BlogId{}
+	if err := tx.SelectContext(ctx, &ids, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blogs_tags: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,91 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_BlogRepository_Related(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM blogs"); err != nil {
+		t.Fatalf("failed to delete blogs: %v", err)
+	}
+
+	tagIds := map[string]models.TagId{}
+	for _, tag := range []string{"related_go", "related_api"} {
+		id, err := sut.AddTag(ctx, tx, tag)
+		if err != nil {
+			t.Fatalf("failed to add tag: %v", err)
+		}
+		tagIds[tag] = id
+	}
+	blogs := []struct {
+		isPublic bool
+		tags     []string
+	}{
+		{isPublic: true, tags: []string{"related_go", "related_api"}},
+		{isPublic: false, tags: []string{"related_go"}},
+		{isPublic: true, tags: nil},
+	}
+	var blogIds []models.BlogId
+	for _, b := range blogs {
+		id, err := sut.Add(ctx, tx, &models.Blog{
+			AuthorId:    1,
+			Title:       "title",
+			Content:     "content",
+			Description: "description",
+			IsPublic:    b.isPublic,
+		})
+		if err != nil {
+			t.Fatalf("failed to add blog: %v", err)
+		}
+		for _, tag := range b.tags {
+			if _, err := sut.AddBlogTag(ctx, tx, id, tagIds[tag]); err != nil {
+				t.Fatalf("failed to add blog tag: %v", err)
+			}
+		}
+		blogIds = append(blogIds, id)
+	}
+
+	candidates, err := sut.ListRelatedCandidates(ctx, tx)
+	if err != nil {
+		t.Fatalf("failed to list related candidates: %v", err)
+	}
+	got := map[models.BlogId][]string{}
+	for _, c := range candidates {
+		got[c.Id] = c.Tags
+	}
+	want := map[models.BlogId][]string{
+		blogIds[0]: {"related_api", "related_go"},
+		blogIds[2]: nil,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	ids, err := sut.ListBlogIdsByTags(ctx, tx, []string{"related_go"})
+	if err != nil {
+		t.Fatalf("failed to list blog ids by tags: %v", err)
+	}
+	if diff := cmp.Diff([]models.BlogId{blogIds[0], blogIds[1]}, ids); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
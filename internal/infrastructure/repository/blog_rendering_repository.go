@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// UpsertRendering はブログ本文のレンダリング結果を作成・更新する
+func (r *BlogRepository) UpsertRendering(
+	ctx context.Context, tx infrastructure.TX, blog *models.Blog,
+) error {
+	rendering, err := models.NewBlogRendering(blog.Id, blog.Content)
+	if err != nil {
+		return fmt.Errorf("failed to create rendering: %w", err)
+	}
+	sql, params, err := goqu.
+		Insert("blog_renderings").
+		Rows(goqu.Record{
+			"blog_id":              rendering.BlogId,
+			"html":                 rendering.HTML,
+			"toc":                  rendering.Toc,
+			"character_count":      rendering.CharacterCount,
+			"word_count":           rendering.WordCount,
+			"reading_time_minutes": rendering.ReadingTimeMinutes,
+		}).
+		OnConflict(goqu.DoUpdate("blog_id", goqu.Record{
+			"html":                 goqu.L("EXCLUDED.html"),
+			"toc":                  goqu.L("EXCLUDED.toc"),
+			"character_count":      goqu.L("EXCLUDED.character_count"),
+			"word_count":           goqu.L("EXCLUDED.word_count"),
+			"reading_time_minutes": goqu.L("EXCLUDED.reading_time_minutes"),
+		})).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to upsert blog_renderings: %w", err)
+	}
+	return nil
+}
+
+// GetRendering はブログ本文のレンダリング結果を取得する
+// 存在しない場合はnilを返す
+func (r *BlogRepository) GetRendering(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId,
+) (*models.BlogRendering, error) {
+	sql, params, err := goqu.
+		Select(
+			"blog_id", "html", "toc", "character_count", "word_count", "reading_time_minutes",
+		).
+		From("blog_renderings").
+		Where(goqu.Ex{"blog_id": blogId}).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var renderings []*models.BlogRendering
+	if err := tx.SelectContext(ctx, &renderings, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog_renderings: %w", err)
+	}
+	if len(renderings) == 0 {
+		return nil, nil
+	}
+	return renderings[0], nil
+}
@@ -0,0 +1,66 @@
+package repository_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_BlogRepository_Rendering(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	blog := &models.Blog{
+		AuthorId:    1,
+		Title:       "title",
+		Content:     "# 見出し\n\n本文",
+		Description: "description",
+	}
+	id, err := sut.Add(ctx, tx, blog)
+	if err != nil {
+		t.Fatalf("failed to add blog: %v", err)
+	}
+	blog.Id = id
+
+	got, err := sut.GetRendering(ctx, tx, id)
+	if err != nil {
+		t.Fatalf("failed to get rendering: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("want nil before upsert, got %+v", got)
+	}
+
+	if err := sut.UpsertRendering(ctx, tx, blog); err != nil {
+		t.Fatalf("failed to upsert rendering: %v", err)
+	}
+	blog.Content = "# 更新後"
+	if err := sut.UpsertRendering(ctx, tx, blog); err != nil {
+		t.Fatalf("failed to upsert rendering: %v", err)
+	}
+
+	got, err = sut.GetRendering(ctx, tx, id)
+	if err != nil {
+		t.Fatalf("failed to get rendering: %v", err)
+	}
+	if got == nil || !strings.Contains(got.HTML, "更新後") {
+		t.Fatalf("unexpected rendering: %+v", got)
+	}
+	if len(got.Toc) != 1 || got.Toc[0].Text != "更新後" {
+		t.Errorf("unexpected toc: %+v", got.Toc)
+	}
+}
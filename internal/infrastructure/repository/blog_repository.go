@@ -3,8 +3,6 @@ package repository
 import (
 	"context"
 	"fmt"
-	"sort"
-	"strings"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/shoet/blog/internal/clocker"
@@ -23,13 +21,24 @@ func NewBlogRepository(clocker clocker.Clocker) *BlogRepository {
 	}
 }
 
+// Add は、ブログを追加する
+// スラッグが空の場合はDBのトリガーでIDによるスラッグ("blog-ID")とする
+// 既定の言語が空の場合はDefaultBlogLocaleとする
 func (r *BlogRepository) Add(ctx context.Context, tx infrastructure.TX, blog *models.Blog) (models.BlogId, error) {
+	var slug *string
+	if blog.Slug != "" {
+		slug = &blog.Slug
+	}
+	defaultLocale := blog.DefaultLocale
+	if defaultLocale == "" {
+		defaultLocale = models.DefaultBlogLocale
+	}
 	sql, params, err := goqu.
 		Insert("blogs").
-		Cols("author_id", "title", "content", "description", "thumbnail_image_file_name", "is_public").
+		Cols("author_id", "title", "content", "description", "thumbnail_image_file_name", "is_public", "publish_at", "slug", "default_locale").
 		Vals(goqu.Vals{
 			blog.AuthorId, blog.Title, blog.Content, blog.Description,
-			blog.ThumbnailImageFileName, blog.IsPublic,
+			blog.ThumbnailImageFileName, blog.IsPublic, blog.PublishAt, slug, defaultLocale,
 		}).
 		Returning("id").
 		ToSQL()
@@ -75,165 +84,36 @@ func (r *BlogRepository) WithBlogTags(
 	return tagResult, nil
 }
 
-// TODO: List()に１本化する
-// カーソルページング実装
-func (r *BlogRepository) List(
-	ctx context.Context, tx infrastructure.TX, option *options.ListBlogOptions,
-) ([]*models.Blog, error) {
-	builder := goqu.
-		Select(
-			"id", "author_id", "title", "description",
-			"thumbnail_image_file_name", "is_public", "created", "modified",
-		).
-		From("blogs").
-		Order(goqu.I("id").Desc()).
-		Limit(uint(option.Limit))
-	if option.IsPublic {
-		builder = builder.Where(goqu.Ex{"is_public": true})
-	}
-	if option.CursorId != nil {
-		if option.PageDirection == "prev" {
-			builder = builder.Where(goqu.Ex{"id": goqu.Op{"gt": option.CursorId}}).Order(goqu.I("id").Asc())
-		}
-		if option.PageDirection == "next" {
-			builder = builder.Where(goqu.Ex{"id": goqu.Op{"lt": option.CursorId}}).Order(goqu.I("id").Desc())
-		}
+// ListBlogTags は、複数のブログに紐づくタグ名を1回のクエリでまとめて取得する
+// タグ名はブログごとに昇順で、タグのないブログは結果に含まれない
+func (r *BlogRepository) ListBlogTags(
+	ctx context.Context, tx infrastructure.TX, blogIds []models.BlogId,
+) (map[models.BlogId][]string, error) {
+	result := make(map[models.BlogId][]string, len(blogIds))
+	if len(blogIds) == 0 {
+		return result, nil
 	}
-	sql, params, err := builder.ToSQL()
-	if err != nil {
-		return nil, fmt.Errorf("failed to build sql: %w", err)
-	}
-	type data struct {
-		models.Blog
-	}
-	var temp []data
-	if err := tx.SelectContext(ctx, &temp, sql, params...); err != nil {
-		return nil, fmt.Errorf("failed to select blogs: %w", err)
-	}
-	var blogs []*models.Blog
-	for _, t := range temp {
-		blogTag, err := r.WithBlogTags(ctx, tx, t.Id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to select blogs_tags: %w", err)
-		}
-		tags := make([]string, 0, len(blogTag))
-		for _, t := range blogTag {
-			tags = append(tags, t.Tag)
-		}
-		// タグを昇順にソート
-		sort.SliceStable(tags, func(i, j int) bool {
-			return strings.Compare(tags[i], tags[j]) < 0
-		})
-		blogs = append(blogs, &models.Blog{
-			Id:                     t.Id,
-			Title:                  t.Title,
-			Description:            t.Description,
-			Content:                t.Content,
-			AuthorId:               t.AuthorId,
-			ThumbnailImageFileName: t.ThumbnailImageFileName,
-			IsPublic:               t.IsPublic,
-			Tags:                   tags,
-			Created:                t.Created,
-			Modified:               t.Modified,
-		})
-	}
-	sort.SliceStable(blogs, func(i, j int) bool { return blogs[i].Id > blogs[j].Id })
-	return blogs, nil
-}
-
-// ListByTagはタグ名を持つブログを検索する
-// カーソルページング実装
-func (r *BlogRepository) ListByTag(
-	ctx context.Context, tx infrastructure.TX, tag string, option *options.ListBlogOptions,
-) (models.Blogs, error) {
-	builder := goqu.
+	sql, params, err := goqu.
+		Select("blogs_tags.blog_id", goqu.I("tags.name").As("tag")).
 		From("blogs_tags").
 		Join(
 			goqu.T("tags"),
 			goqu.On(goqu.Ex{"blogs_tags.tag_id": goqu.I("tags.id")}),
 		).
-		Where(goqu.Ex{"tags.name": tag}).
-		Select("blogs_tags.blog_id", "blogs_tags.tag_id", "tags.name").
-		As("b_t")
-	builder = goqu.
-		From("blogs").
-		Join(
-			builder,
-			goqu.On(goqu.Ex{"blogs.id": goqu.I("b_t.blog_id")}),
-		).
-		Order(goqu.I("id").Desc()).
-		Select(
-			"id", "author_id", "title", "description",
-			"thumbnail_image_file_name", "is_public", "created", "modified",
-		).
-		Limit(uint(option.Limit))
-	if option.IsPublic {
-		builder = builder.Where(goqu.Ex{"is_public": true})
-	}
-	if option.CursorId != nil {
-		if option.PageDirection == "prev" {
-			builder = builder.Where(goqu.Ex{"id": goqu.Op{"gt": option.CursorId}}).Order(goqu.I("id").Asc())
-		}
-		if option.PageDirection == "next" {
-			builder = builder.Where(goqu.Ex{"id": goqu.Op{"lt": option.CursorId}}).Order(goqu.I("id").Desc())
-		}
-	}
-	sql, params, err := builder.ToSQL()
-	if err != nil {
-		return nil, fmt.Errorf("failed to build sql: %w", err)
-	}
-	var blogs models.Blogs
-	if err := tx.SelectContext(ctx, &blogs, sql, params...); err != nil {
-		return nil, fmt.Errorf("failed to SelectContext: %w", err)
-	}
-	if len(blogs) == 0 {
-		return []*models.Blog{}, nil
-	}
-	sort.SliceStable(blogs, func(i, j int) bool { return blogs[i].Id > blogs[j].Id })
-	return blogs, nil
-}
-
-// TODO: ページング
-// カーソルページング実装
-func (r *BlogRepository) ListByKeyword(
-	ctx context.Context, tx infrastructure.TX, keyword string, option *options.ListBlogOptions,
-) (models.Blogs, error) {
-	builder := goqu.
-		From("blogs").
-		Where(goqu.ExOr{
-			"title":       goqu.Op{"like": "%" + keyword + "%"},
-			"description": goqu.Op{"like": "%" + keyword + "%"},
-		}).
-		Order(goqu.I("id").Desc()).
-		Select(
-			"id", "author_id", "title", "description",
-			"thumbnail_image_file_name", "is_public", "created", "modified",
-		).
-		Limit(uint(option.Limit))
-	if option.IsPublic {
-		builder = builder.Where(goqu.Ex{"is_public": true})
-	}
-	if option.CursorId != nil {
-		if option.PageDirection == "prev" {
-			builder = builder.Where(goqu.Ex{"id": goqu.Op{"gt": option.CursorId}}).Order(goqu.I("id").Asc())
-		}
-		if option.PageDirection == "next" {
-			builder = builder.Where(goqu.Ex{"id": goqu.Op{"lt": option.CursorId}}).Order(goqu.I("id").Desc())
-		}
-	}
-	sql, params, err := builder.ToSQL()
+		Where(goqu.Ex{"blogs_tags.blog_id": blogIds}).
+		Order(goqu.I("blogs_tags.blog_id").Asc(), goqu.I("tags.name").Asc()).
+		ToSQL()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build sql: %w", err)
 	}
-	var blogs models.Blogs
-	if err := tx.SelectContext(ctx, &blogs, sql, params...); err != nil {
-		return nil, fmt.Errorf("failed to SelectContext: %w", err)
+	var tagResult []*BlogTag
+	if err := tx.SelectContext(ctx, &tagResult, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blogs_tags: %w", err)
 	}
-	if len(blogs) == 0 {
-		return []*models.Blog{}, nil
+	for _, t := range tagResult {
+		result[t.BlogId] = append(result[t.BlogId], t.Tag)
 	}
-	sort.SliceStable(blogs, func(i, j int) bool { return blogs[i].Id > blogs[j].Id })
-	return blogs, nil
+	return result, nil
 }
 
 func (r *BlogRepository) Get(
@@ -241,10 +121,11 @@ func (r *BlogRepository) Get(
 ) (*models.Blog, error) {
 	sql, params, err := goqu.
 		Select("id", "author_id", "title", "content", "description",
-			"thumbnail_image_file_name", "is_public", "created", "modified",
+			"thumbnail_image_file_name", "is_public", "created", "modified", "publish_at", "slug", "default_locale",
+			"version",
 		).
 		From("blogs").
-		Where(goqu.Ex{"id": id}).
+		Where(goqu.Ex{"id": id, "deleted_at": nil}).
 		ToSQL()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build sql: %w", err)
@@ -290,53 +171,129 @@ func (r *BlogRepository) Delete(ctx context.Context, tx infrastructure.TX, id mo
 	return nil
 }
 
+// Put は、ブログを更新する
+// versionを指定した場合は、現在のバージョンが一致する場合のみ更新し
+// 一致しない場合はErrVersionMismatch、ブログが存在しない場合はErrResourceNotFoundを返す
 func (r *BlogRepository) Put(
-	ctx context.Context, tx infrastructure.TX, blog *models.Blog,
+	ctx context.Context, tx infrastructure.TX, blog *models.Blog, version *int64,
 ) (models.BlogId, error) {
 	now := r.Clocker.Now()
 	blog.Modified = uint(now.Unix())
+	record := goqu.Record{
+		"author_id":                 blog.AuthorId,
+		"title":                     blog.Title,
+		"content":                   blog.Content,
+		"description":               blog.Description,
+		"thumbnail_image_file_name": blog.ThumbnailImageFileName,
+		"is_public":                 blog.IsPublic,
+		"modified":                  blog.Modified,
+		"publish_at":                blog.PublishAt,
+	}
+	// スラッグが空の場合は変更しない
+	if blog.Slug != "" {
+		record["slug"] = blog.Slug
+	}
+	// 既定の言語が空の場合は変更しない
+	if blog.DefaultLocale != "" {
+		record["default_locale"] = blog.DefaultLocale
+	}
+	where := goqu.Ex{"id": blog.Id}
+	if version != nil {
+		where["version"] = *version
+	}
 	sql, params, err := goqu.
 		Update("blogs").
-		Set(goqu.Record{
-			"author_id":                 blog.AuthorId,
-			"title":                     blog.Title,
-			"content":                   blog.Content,
-			"description":               blog.Description,
-			"thumbnail_image_file_name": blog.ThumbnailImageFileName,
-			"is_public":                 blog.IsPublic,
-			"modified":                  blog.Modified,
-		}).
-		Where(goqu.Ex{"id": blog.Id}).
+		Set(record).
+		Where(where).
 		ToSQL()
 	if err != nil {
 		return 0, fmt.Errorf("failed to build sql: %w", err)
 	}
-	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+	result, err := tx.ExecContext(ctx, sql, params...)
+	if err != nil {
 		return 0, fmt.Errorf("failed to update blog: %w", err)
 	}
+	if version != nil {
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if affected == 0 {
+			return 0, r.notUpdatedError(ctx, tx, blog.Id)
+		}
+	}
 	return blog.Id, nil
 }
 
+// notUpdatedError は、条件付きの更新で対象の行がなかった理由をエラーとして返す
+// ブログが存在しないか、ゴミ箱にある場合はErrResourceNotFound、それ以外はErrVersionMismatchとする
+func (r *BlogRepository) notUpdatedError(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId,
+) error {
+	sql, params, err := goqu.
+		Select("id").
+		From("blogs").
+		Where(goqu.Ex{"id": blogId, "deleted_at": nil}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	var ids []models.BlogId
+	if err := tx.SelectContext(ctx, &ids, sql, params...); err != nil {
+		return fmt.Errorf("failed to select blog: %w", err)
+	}
+	if len(ids) == 0 {
+		return ErrResourceNotFound
+	}
+	return ErrVersionMismatch
+}
+
+// UpdatePublicStatus は、ブログの公開状態を更新する
+// versionを指定した場合は、現在のバージョンが一致する場合のみ更新し
+// 一致しない場合はErrVersionMismatch、ブログが存在しない場合はErrResourceNotFoundを返す
 func (r *BlogRepository) UpdatePublicStatus(
-	ctx context.Context, tx infrastructure.TX, blogId models.BlogId, isPublic bool,
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId, isPublic bool, version *int64,
 ) (*models.Blog, error) {
+	where := goqu.Ex{"id": blogId, "deleted_at": nil}
+	if version != nil {
+		where["version"] = *version
+	}
 	sql, params, err := goqu.
 		Update("blogs").
-		Set(goqu.Record{"is_public": isPublic}).
-		Where(goqu.Ex{"id": blogId}).
+		// 手動で公開状態を変更した場合は予約公開を取り消す
+		Set(goqu.Record{"is_public": isPublic, "publish_at": nil}).
+		Where(where).
 		Returning("*").ToSQL()
 	if err != nil {
 		return nil, fmt.Errorf("faield to build query: %w", err)
 	}
-	row := tx.QueryRowxContext(ctx, sql, params...)
-	if row.Err() != nil {
-		return nil, fmt.Errorf("failed to query: %w", row.Err())
+	var blogs []*models.Blog
+	if err := tx.SelectContext(ctx, &blogs, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
 	}
-	var blog models.Blog
-	if err := row.StructScan(&blog); err != nil {
-		return nil, fmt.Errorf("failed to scan struct: %w", err)
+	if len(blogs) == 0 {
+		return nil, r.notUpdatedError(ctx, tx, blogId)
 	}
-	return &blog, nil
+	return blogs[0], nil
+}
+
+// SetCreated は、ブログの作成日時を変更する
+// 他の環境から取り込んだブログの作成日時を引き継ぐ場合に使用する
+func (r *BlogRepository) SetCreated(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId, created uint,
+) error {
+	sql, params, err := goqu.
+		Update("blogs").
+		Set(goqu.Record{"created": created}).
+		Where(goqu.Ex{"id": blogId}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to update blog: %w", err)
+	}
+	return nil
 }
 
 func (r *BlogRepository) AddBlogTag(
@@ -501,13 +458,26 @@ func (r *BlogRepository) DeleteTag(
 func (r *BlogRepository) ListTags(
 	ctx context.Context, tx infrastructure.TX, option options.ListTagsOptions,
 ) ([]*models.Tag, error) {
+	// 公開済みのブログの件数を合わせて取得する
 	sql := `
 	SELECT
-		id, name
+		tags.id
+		, tags.name
+		, tags.description
+		, tags.color
+		, COUNT(blogs.id) AS count
 	FROM
 		tags
+	LEFT OUTER JOIN blogs_tags
+		ON blogs_tags.tag_id = tags.id
+	LEFT OUTER JOIN blogs
+		ON blogs.id = blogs_tags.blog_id
+		AND blogs.is_public = TRUE
+		AND blogs.deleted_at IS NULL
+	GROUP BY
+		tags.id
 	ORDER BY
-		name
+		tags.name
 	LIMIT $1
 	;
 	`
@@ -2,6 +2,7 @@ package repository_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"testing"
@@ -84,7 +85,7 @@ func Test_BlogRepository_Add(t *testing.T) {
 				t.Fatalf("failed to scan row: %v", err)
 			}
 
-			opt := cmpopts.IgnoreFields(models.Blog{}, "Id", "Created", "Modified")
+			opt := cmpopts.IgnoreFields(models.Blog{}, "Id", "Created", "Modified", "Version", "Slug", "DefaultLocale")
 			if diff := cmp.Diff(tt.want.blog, &got, opt); diff != "" {
 				t.Errorf("differs: (-want +got)\n%s", diff)
 			}
@@ -133,7 +134,7 @@ func generateTestBlogsWithPublic(t *testing.T, count int, now time.Time) []*mode
 	return blogs
 }
 
-func Test_BlogRepository_List(t *testing.T) {
+func Test_BlogRepository_Query(t *testing.T) {
 	clocker := &clocker.FiexedClocker{}
 	ctx := context.Background()
 	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
@@ -306,12 +307,13 @@ func Test_BlogRepository_List(t *testing.T) {
 				t.Fatalf("failed to prepare: %v", err)
 			}
 
-			listOption, err := options.NewListBlogOptions(&tt.args.isPublic, nil, tt.args.limit, nil)
+			filter := &options.BlogFilter{IsPublic: tt.args.isPublic}
+			listOption, err := options.NewListBlogOptions(filter, &options.BlogSort{}, nil, tt.args.limit)
 			if err != nil {
 				t.Fatalf("failed to create list option: %v", err)
 			}
 
-			blogs, err := sut.List(ctx, tx, listOption)
+			blogs, err := sut.Query(ctx, tx, listOption)
 			if err != nil {
 				t.Fatalf("failed to list blogs: %v", err)
 			}
@@ -493,7 +495,7 @@ func Test_BlogRepository_Get(t *testing.T) {
 				t.Fatalf("failed to get blog: %v", err)
 			}
 
-			cmpOptions := cmpopts.IgnoreFields(models.Blog{}, "Id", "Created", "Modified")
+			cmpOptions := cmpopts.IgnoreFields(models.Blog{}, "Id", "Created", "Modified", "Version", "Slug", "DefaultLocale")
 			if diff := cmp.Diff(tt.want.blog, got, cmpOptions); diff != "" {
 				t.Errorf("differs: (-want +got)\n%s", diff)
 			}
@@ -597,7 +599,7 @@ func Test_BlogRepository_Put(t *testing.T) {
 
 			tt.args.blog.Id = blogId
 
-			blogId, err := sut.Put(ctx, tx, tt.args.blog)
+			blogId, err := sut.Put(ctx, tx, tt.args.blog, nil)
 			if err != nil {
 				t.Fatalf("failed to put blog: %v", err)
 			}
@@ -608,7 +610,7 @@ func Test_BlogRepository_Put(t *testing.T) {
 				t.Fatalf("failed to scan row: %v", err)
 			}
 
-			cmpOptions := cmpopts.IgnoreFields(models.Blog{}, "Id", "Created", "Modified")
+			cmpOptions := cmpopts.IgnoreFields(models.Blog{}, "Id", "Created", "Modified", "Version", "Slug", "DefaultLocale")
 			if diff := cmp.Diff(tt.want.blog, got[0], cmpOptions); diff != "" {
 				t.Errorf("differs: (-want +got)\n%s", diff)
 			}
@@ -617,6 +619,81 @@ func Test_BlogRepository_Put(t *testing.T) {
 
 }
 
+func Test_BlogRepository_Put_Version(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	blogId, err := sut.Add(ctx, tx, &models.Blog{AuthorId: 1, Title: "title", IsPublic: true})
+	if err != nil {
+		t.Fatalf("failed to add blog: %v", err)
+	}
+	blog, err := sut.Get(ctx, tx, blogId)
+	if err != nil {
+		t.Fatalf("failed to get blog: %v", err)
+	}
+	stale := blog.Version - 1
+	current := blog.Version
+
+	t.Run("バージョンが一致しない場合は更新しない", func(t *testing.T) {
+		_, err := sut.Put(ctx, tx, &models.Blog{Id: blogId, AuthorId: 1, Title: "stale"}, &stale)
+		if !errors.Is(err, repository.ErrVersionMismatch) {
+			t.Fatalf("want ErrVersionMismatch, got %v", err)
+		}
+		_, err = sut.UpdatePublicStatus(ctx, tx, blogId, false, &stale)
+		if !errors.Is(err, repository.ErrVersionMismatch) {
+			t.Fatalf("want ErrVersionMismatch, got %v", err)
+		}
+		got, err := sut.Get(ctx, tx, blogId)
+		if err != nil {
+			t.Fatalf("failed to get blog: %v", err)
+		}
+		if got.Title != "title" || !got.IsPublic {
+			t.Errorf("blog is updated: %+v", got)
+		}
+	})
+
+	t.Run("ブログが存在しない場合はErrResourceNotFound", func(t *testing.T) {
+		_, err := sut.Put(ctx, tx, &models.Blog{Id: blogId + 1, AuthorId: 1, Title: "title"}, &current)
+		if !errors.Is(err, repository.ErrResourceNotFound) {
+			t.Fatalf("want ErrResourceNotFound, got %v", err)
+		}
+	})
+
+	t.Run("バージョンが一致する場合は更新し、バージョンを増やす", func(t *testing.T) {
+		if _, err := sut.Put(ctx, tx, &models.Blog{Id: blogId, AuthorId: 1, Title: "current"}, &current); err != nil {
+			t.Fatalf("failed to put blog: %v", err)
+		}
+		got, err := sut.Get(ctx, tx, blogId)
+		if err != nil {
+			t.Fatalf("failed to get blog: %v", err)
+		}
+		if got.Title != "current" {
+			t.Errorf("want title current, got %s", got.Title)
+		}
+		if got.Version != current+1 {
+			t.Errorf("want version %d, got %d", current+1, got.Version)
+		}
+	})
+
+	t.Run("同じ秒に更新されても古いバージョンでは更新しない", func(t *testing.T) {
+		// 時刻を固定しているため、前の更新と更新日時は同じになる
+		_, err := sut.Put(ctx, tx, &models.Blog{Id: blogId, AuthorId: 1, Title: "overwrite"}, &current)
+		if !errors.Is(err, repository.ErrVersionMismatch) {
+			t.Fatalf("want ErrVersionMismatch, got %v", err)
+		}
+	})
+}
+
 func Test_BlogRepository_AddBlogTag(t *testing.T) {
 	clocker := &clocker.FiexedClocker{}
 	ctx := context.Background()
@@ -744,7 +821,7 @@ func Test_BlogRepository_AddBlogTag(t *testing.T) {
 
 }
 
-func Test_BlogRepository_ListByTags(t *testing.T) {
+func Test_BlogRepository_Query_Tag(t *testing.T) {
 	clocker := &clocker.FiexedClocker{}
 	ctx := context.Background()
 	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
@@ -825,6 +902,7 @@ func Test_BlogRepository_ListByTags(t *testing.T) {
 						Description:            "description",
 						ThumbnailImageFileName: "thumbnail_image_file_name",
 						IsPublic:               true,
+						Tags:                   []string{"test1"},
 					},
 				},
 				err: nil,
@@ -890,6 +968,7 @@ func Test_BlogRepository_ListByTags(t *testing.T) {
 						Description:            "description",
 						ThumbnailImageFileName: "thumbnail_image_file_name",
 						IsPublic:               true,
+						Tags:                   []string{"test1"},
 					},
 				},
 				err: nil,
@@ -952,18 +1031,18 @@ func Test_BlogRepository_ListByTags(t *testing.T) {
 					t.Fatalf("failed to prepare: %v", err)
 				}
 			}
-			// TODO 修正
-			option, err := options.NewListBlogOptions(&tt.args.isPublicOnly, nil, nil, nil)
+			filter := &options.BlogFilter{IsPublic: tt.args.isPublicOnly, Tags: []string{tt.args.tag}}
+			option, err := options.NewListBlogOptions(filter, &options.BlogSort{}, nil, nil)
 			if err != nil {
 				t.Fatalf("failed to create list option: %v", err)
 			}
 
-			blogs, err := sut.ListByTag(ctx, tx, tt.args.tag, option)
+			blogs, err := sut.Query(ctx, tx, option)
 			if err != tt.wants.err {
-				t.Errorf("failed to ListByTag: %v", err)
+				t.Errorf("failed to Query: %v", err)
 			}
 
-			opt := cmpopts.IgnoreFields(models.Blog{}, "Id", "Created", "Modified")
+			opt := cmpopts.IgnoreFields(models.Blog{}, "Id", "Created", "Modified", "Version", "Slug", "DefaultLocale", "SortValue")
 			if diff := cmp.Diff(tt.wants.blogs, blogs, opt); diff != "" {
 				t.Errorf("differs: (-want +got)\n%s", diff)
 			}
@@ -971,7 +1050,7 @@ func Test_BlogRepository_ListByTags(t *testing.T) {
 	}
 }
 
-func Test_BlogRepository_ListByKeyword(t *testing.T) {
+func Test_BlogRepository_Query_Keyword(t *testing.T) {
 	clocker := &clocker.FiexedClocker{}
 	ctx := context.Background()
 	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
@@ -981,6 +1060,21 @@ func Test_BlogRepository_ListByKeyword(t *testing.T) {
 	testutil.RepositoryTestPrepare(t, ctx, db)
 
 	sut := repository.NewBlogRepository(clocker)
+
+	// addWithIndex はブログを追加し、検索インデックスを作成する
+	addWithIndex := func(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error {
+		id, err := sut.Add(ctx, tx, blog)
+		if err != nil {
+			return fmt.Errorf("failed to Add blog: %w", err)
+		}
+		indexed := *blog
+		indexed.Id = id
+		if err := sut.UpsertSearchIndex(ctx, tx, &indexed); err != nil {
+			return fmt.Errorf("failed to UpsertSearchIndex: %w", err)
+		}
+		return nil
+	}
+
 	type args struct {
 		prepare      func(ctx context.Context, tx infrastructure.TX) error
 		keyword      string
@@ -1009,16 +1103,14 @@ func Test_BlogRepository_ListByKeyword(t *testing.T) {
 						IsPublic:               true,
 					}
 					// blogsにinsert
-					_, err := sut.Add(ctx, tx, blog)
-					if err != nil {
-						return fmt.Errorf("failed to Add blog: %w", err)
+					if err := addWithIndex(ctx, tx, blog); err != nil {
+						return err
 					}
 
 					// 検索対象外のblogを作成
 					blog.Title = "aaa"
-					_, err = sut.Add(ctx, tx, blog)
-					if err != nil {
-						return fmt.Errorf("failed to Add blog: %w", err)
+					if err := addWithIndex(ctx, tx, blog); err != nil {
+						return err
 					}
 					return nil
 				},
@@ -1051,16 +1143,14 @@ func Test_BlogRepository_ListByKeyword(t *testing.T) {
 						IsPublic:               true,
 					}
 					// blogsにinsert
-					_, err := sut.Add(ctx, tx, blog)
-					if err != nil {
-						return fmt.Errorf("failed to Add blog: %w", err)
+					if err := addWithIndex(ctx, tx, blog); err != nil {
+						return err
 					}
 
 					// 検索対象外のblogを作成
 					blog.Description = "aaa"
-					_, err = sut.Add(ctx, tx, blog)
-					if err != nil {
-						return fmt.Errorf("failed to Add blog: %w", err)
+					if err := addWithIndex(ctx, tx, blog); err != nil {
+						return err
 					}
 					return nil
 				},
@@ -1094,15 +1184,13 @@ func Test_BlogRepository_ListByKeyword(t *testing.T) {
 					}
 
 					// blogsにinsert
-					_, err := sut.Add(ctx, tx, blog)
-					if err != nil {
-						return fmt.Errorf("failed to Add blog: %w", err)
+					if err := addWithIndex(ctx, tx, blog); err != nil {
+						return err
 					}
 					blog.IsPublic = false
 					// blogsにinsert
-					_, err = sut.Add(ctx, tx, blog)
-					if err != nil {
-						return fmt.Errorf("failed to Add blog: %w", err)
+					if err := addWithIndex(ctx, tx, blog); err != nil {
+						return err
 					}
 					return nil
 				},
@@ -1122,6 +1210,45 @@ func Test_BlogRepository_ListByKeyword(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			name: "本文の日本語を検索する",
+			args: args{
+				prepare: func(ctx context.Context, tx infrastructure.TX) error {
+					blog := &models.Blog{
+						AuthorId:               1,
+						Title:                  "タイトル",
+						Content:                "PostgreSQLで全文検索を実装する",
+						Description:            "概要",
+						ThumbnailImageFileName: "thumbnail_image_file_name",
+						IsPublic:               true,
+					}
+					if err := addWithIndex(ctx, tx, blog); err != nil {
+						return err
+					}
+
+					// 検索対象外のblogを作成
+					blog.Content = "全文を読む"
+					if err := addWithIndex(ctx, tx, blog); err != nil {
+						return err
+					}
+					return nil
+				},
+				keyword:      "全文検索",
+				isPublicOnly: true,
+			},
+			wants: wants{
+				blogs: models.Blogs{
+					{
+						AuthorId:               1,
+						Title:                  "タイトル",
+						Description:            "概要",
+						ThumbnailImageFileName: "thumbnail_image_file_name",
+						IsPublic:               true,
+					},
+				},
+				err: nil,
+			},
+		},
 		{
 			name: "存在しないkeywordを検索する",
 			args: args{
@@ -1135,9 +1262,8 @@ func Test_BlogRepository_ListByKeyword(t *testing.T) {
 						IsPublic:               true,
 					}
 					// blogsにinsert
-					_, err := sut.Add(ctx, tx, blog)
-					if err != nil {
-						return fmt.Errorf("failed to Add blog: %w", err)
+					if err := addWithIndex(ctx, tx, blog); err != nil {
+						return err
 					}
 					return nil
 				},
@@ -1169,19 +1295,19 @@ func Test_BlogRepository_ListByKeyword(t *testing.T) {
 				}
 			}
 
-			// TODO 修正
-			option, err := options.NewListBlogOptions(&tt.args.isPublicOnly, nil, nil, nil)
+			filter := &options.BlogFilter{IsPublic: tt.args.isPublicOnly, KeyWord: &tt.args.keyword}
+			option, err := options.NewListBlogOptions(filter, &options.BlogSort{}, nil, nil)
 			if err != nil {
 				t.Fatalf("failed to create list option: %v", err)
 			}
 
-			blogs, err := sut.ListByKeyword(ctx, tx, tt.args.keyword, option)
+			blogs, err := sut.Query(ctx, tx, option)
 			if err != tt.wants.err {
-				t.Errorf("failed to ListByTag: %v", err)
+				t.Errorf("failed to Query: %v", err)
 			}
 
-			opt := cmpopts.IgnoreFields(models.Blog{}, "Id", "Created", "Modified")
-			if diff := cmp.Diff(tt.wants.blogs, blogs, opt); diff != "" {
+			opt := cmpopts.IgnoreFields(models.Blog{}, "Id", "Created", "Modified", "Version", "Slug", "DefaultLocale", "Snippet", "SortValue")
+			if diff := cmp.Diff(tt.wants.blogs, blogs, opt, cmpopts.EquateEmpty()); diff != "" {
 				t.Errorf("differs: (-want +got)\n%s", diff)
 			}
 		})
@@ -1270,12 +1396,12 @@ func Test_BlogRepository_UpdatePublicStatus(t *testing.T) {
 				}
 			}
 
-			gotReturningBlog, err := sut.UpdatePublicStatus(ctx, tx, tt.args.blogId, tt.args.isPublic)
+			gotReturningBlog, err := sut.UpdatePublicStatus(ctx, tx, tt.args.blogId, tt.args.isPublic, nil)
 			if err != nil {
 				t.Fatalf("failed to update public status: %v", err)
 			}
 
-			cmpOptions := cmpopts.IgnoreFields(models.Blog{}, "Id", "Created", "Modified")
+			cmpOptions := cmpopts.IgnoreFields(models.Blog{}, "Id", "Created", "Modified", "Version", "Slug", "DefaultLocale")
 			if diff := cmp.Diff(tt.want.blog, gotReturningBlog, cmpOptions); diff != "" {
 				t.Errorf("differs: (-want +got)\n%s", diff)
 			}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type BlogRevisionRepository struct {
+	Clocker clocker.Clocker
+}
+
+func NewBlogRevisionRepository(clocker clocker.Clocker) *BlogRevisionRepository {
+	return &BlogRevisionRepository{
+		Clocker: clocker,
+	}
+}
+
+// Add は、ブログの現在の内容をタグを含めて新しいリビジョンとして保存する
+// リビジョン番号はブログごとに1から採番する
+func (r *BlogRevisionRepository) Add(
+	ctx context.Context, tx infrastructure.TX, blog *models.Blog,
+) (models.BlogRevisionId, error) {
+	tags := make(models.RevisionTags, len(blog.Tags))
+	copy(tags, blog.Tags)
+	sort.Strings(tags)
+	sql, params, err := goqu.
+		Insert("blog_revisions").
+		Rows(goqu.Record{
+			"blog_id": blog.Id,
+			"revision": goqu.L(
+				"(SELECT COALESCE(MAX(revision), 0) + 1 FROM blog_revisions WHERE blog_id = ?)", blog.Id,
+			),
+			"author_id":                 blog.AuthorId,
+			"title":                     blog.Title,
+			"content":                   blog.Content,
+			"description":               blog.Description,
+			"thumbnail_image_file_name": blog.ThumbnailImageFileName,
+			"is_public":                 blog.IsPublic,
+			"tags":                      tags,
+			"created":                   r.Clocker.Now().Unix(),
+		}).
+		Returning("id").
+		ToSQL()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build sql: %w", err)
+	}
+	row := tx.QueryRowxContext(ctx, sql, params...)
+	if row.Err() != nil {
+		return 0, fmt.Errorf("failed to insert blog_revisions: %w", row.Err())
+	}
+	var id models.BlogRevisionId
+	if err := row.Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	return id, nil
+}
+
+// List は、ブログのリビジョンを新しい順に取得する
+// 一覧では本文は取得しない
+func (r *BlogRevisionRepository) List(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId,
+) ([]*models.BlogRevision, error) {
+	sql, params, err := goqu.
+		Select(
+			"id", "blog_id", "revision", "author_id", "title", "description",
+			"thumbnail_image_file_name", "is_public", "tags", "created",
+		).
+		From("blog_revisions").
+		Where(goqu.Ex{"blog_id": blogId}).
+		Order(goqu.I("revision").Desc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	revisions := []*models.BlogRevision{}
+	if err := tx.SelectContext(ctx, &revisions, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog_revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// Get は、ブログの指定したリビジョンを取得する
+// 存在しない場合はErrResourceNotFoundを返す
+func (r *BlogRevisionRepository) Get(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId, revision int64,
+) (*models.BlogRevision, error) {
+	sql, params, err := goqu.
+		Select(
+			"id", "blog_id", "revision", "author_id", "title", "content", "description",
+			"thumbnail_image_file_name", "is_public", "tags", "created",
+		).
+		From("blog_revisions").
+		Where(goqu.Ex{"blog_id": blogId, "revision": revision}).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var revisions []*models.BlogRevision
+	if err := tx.SelectContext(ctx, &revisions, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog_revisions: %w", err)
+	}
+	if len(revisions) == 0 {
+		return nil, ErrResourceNotFound
+	}
+	return revisions[0], nil
+}
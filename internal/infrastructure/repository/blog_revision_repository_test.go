@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_BlogRevisionRepository(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	blogRepo := repository.NewBlogRepository(clocker)
+	sut := repository.NewBlogRevisionRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	blog := &models.Blog{
+		AuthorId:               1,
+		Title:                  "title",
+		Content:                "content",
+		Description:            "description",
+		ThumbnailImageFileName: "thumbnail_image_file_name",
+		IsPublic:               false,
+		Tags:                   []string{"tag2", "tag1"},
+	}
+	blogId, err := blogRepo.Add(ctx, tx, blog)
+	if err != nil {
+		t.Fatalf("failed to add blog: %v", err)
+	}
+	blog.Id = blogId
+	if _, err := sut.Add(ctx, tx, blog); err != nil {
+		t.Fatalf("failed to add revision: %v", err)
+	}
+	blog.Title = "title2"
+	blog.Content = "content2"
+	blog.Tags = []string{"tag3"}
+	if _, err := sut.Add(ctx, tx, blog); err != nil {
+		t.Fatalf("failed to add revision: %v", err)
+	}
+
+	t.Run("リビジョンを新しい順に一覧できる", func(t *testing.T) {
+		got, err := sut.List(ctx, tx, blogId)
+		if err != nil {
+			t.Fatalf("failed to list revisions: %v", err)
+		}
+		want := []*models.BlogRevision{
+			{
+				BlogId: blogId, Revision: 2, AuthorId: 1, Title: "title2", Description: "description",
+				ThumbnailImageFileName: "thumbnail_image_file_name", Tags: models.RevisionTags{"tag3"},
+				Created: uint(clocker.Now().Unix()),
+			},
+			{
+				BlogId: blogId, Revision: 1, AuthorId: 1, Title: "title", Description: "description",
+				ThumbnailImageFileName: "thumbnail_image_file_name", Tags: models.RevisionTags{"tag1", "tag2"},
+				Created: uint(clocker.Now().Unix()),
+			},
+		}
+		opt := cmpopts.IgnoreFields(models.BlogRevision{}, "Id")
+		if diff := cmp.Diff(want, got, opt); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+
+	t.Run("リビジョンを本文を含めて取得できる", func(t *testing.T) {
+		got, err := sut.Get(ctx, tx, blogId, 1)
+		if err != nil {
+			t.Fatalf("failed to get revision: %v", err)
+		}
+		want := &models.BlogRevision{
+			BlogId: blogId, Revision: 1, AuthorId: 1, Title: "title", Content: "content", Description: "description",
+			ThumbnailImageFileName: "thumbnail_image_file_name", Tags: models.RevisionTags{"tag1", "tag2"},
+			Created: uint(clocker.Now().Unix()),
+		}
+		opt := cmpopts.IgnoreFields(models.BlogRevision{}, "Id")
+		if diff := cmp.Diff(want, got, opt); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	})
+
+	t.Run("存在しないリビジョンはErrResourceNotFound", func(t *testing.T) {
+		_, err := sut.Get(ctx, tx, blogId, 3)
+		if !errors.Is(err, repository.ErrResourceNotFound) {
+			t.Errorf("want ErrResourceNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// ListScheduledBlogs は、予約公開待ちのブログを公開予定日時の早い順に取得する
+func (r *BlogRepository) ListScheduledBlogs(
+	ctx context.Context, tx infrastructure.TX,
+) ([]*models.Blog, error) {
+	sql, params, err := goqu.
+		Select(
+			"id", "author_id", "title", "description",
+			"thumbnail_image_file_name", "is_public", "created", "modified", "publish_at", "slug",
+		).
+		From("blogs").
+		Where(
+			goqu.Ex{"is_public": false, "deleted_at": nil},
+			goqu.C("publish_at").IsNotNull(),
+		).
+		Order(goqu.I("publish_at").Asc(), goqu.I("id").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	blogs := []*models.Blog{}
+	if err := tx.SelectContext(ctx, &blogs, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blogs: %w", err)
+	}
+	return blogs, nil
+}
+
+// UpdatePublishAt は、ブログの公開予定日時を更新する
+// publishAtにnilを指定すると予約を取り消す
+func (r *BlogRepository) UpdatePublishAt(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId, publishAt *uint,
+) (*models.Blog, error) {
+	sql, params, err := goqu.
+		Update("blogs").
+		Set(goqu.Record{"publish_at": publishAt}).
+		Where(goqu.Ex{"id": blogId, "is_public": false, "deleted_at": nil}).
+		Returning(
+			"id", "author_id", "title", "description",
+			"thumbnail_image_file_name", "is_public", "created", "modified", "publish_at", "slug",
+		).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var blogs []*models.Blog
+	if err := tx.SelectContext(ctx, &blogs, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to update publish_at: %w", err)
+	}
+	if len(blogs) == 0 {
+		return nil, ErrResourceNotFound
+	}
+	return blogs[0], nil
+}
+
+// PublishScheduledBlogs は、公開予定日時がnow以前のブログを公開し、公開したブログのIDを返す
+func (r *BlogRepository) PublishScheduledBlogs(
+	ctx context.Context, tx infrastructure.TX, now time.Time,
+) ([]models.BlogId, error) {
+	sql, params, err := goqu.
+		Update("blogs").
+		Set(goqu.Record{"is_public": true, "publish_at": nil}).
+		Where(
+			goqu.Ex{"is_public": false, "deleted_at": nil},
+			goqu.C("publish_at").Lte(now.Unix()),
+		).
+		Returning("id").
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	ids := []models.BlogId{}
+	if err := tx.SelectContext(ctx, &ids, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to publish scheduled blogs: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,138 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_BlogRepository_PublishScheduledBlogs(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+	now := uint(clocker.Now().Unix())
+	uintPtr := func(v uint) *uint { return &v }
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	add := func(publishAt *uint) models.BlogId {
+		t.Helper()
+		id, err := sut.Add(ctx, tx, &models.Blog{
+			AuthorId:    1,
+			Title:       "title",
+			Content:     "content",
+			Description: "description",
+			IsPublic:    false,
+			PublishAt:   publishAt,
+		})
+		if err != nil {
+			t.Fatalf("failed to add blog: %v", err)
+		}
+		return id
+	}
+	pastId := add(uintPtr(now - 60))
+	justId := add(uintPtr(now))
+	futureId := add(uintPtr(now + 60))
+	unscheduledId := add(nil)
+
+	scheduled, err := sut.ListScheduledBlogs(ctx, tx)
+	if err != nil {
+		t.Fatalf("failed to list scheduled blogs: %v", err)
+	}
+	if len(scheduled) != 3 || scheduled[0].Id != pastId || scheduled[2].Id != futureId {
+		t.Errorf("unexpected scheduled blogs: %+v", scheduled)
+	}
+
+	ids, err := sut.PublishScheduledBlogs(ctx, tx, clocker.Now())
+	if err != nil {
+		t.Fatalf("failed to publish scheduled blogs: %v", err)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("want 2 published blogs, got %v", ids)
+	}
+
+	tests := []struct {
+		name          string
+		id            models.BlogId
+		wantIsPublic  bool
+		wantPublishAt *uint
+	}{
+		{name: "公開予定日時を過ぎたブログは公開される", id: pastId, wantIsPublic: true, wantPublishAt: nil},
+		{name: "公開予定日時ちょうどのブログは公開される", id: justId, wantIsPublic: true, wantPublishAt: nil},
+		{name: "公開予定日時前のブログは公開されない", id: futureId, wantIsPublic: false, wantPublishAt: uintPtr(now + 60)},
+		{name: "予約のないブログは公開されない", id: unscheduledId, wantIsPublic: false, wantPublishAt: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blog, err := sut.Get(ctx, tx, tt.id)
+			if err != nil {
+				t.Fatalf("failed to get blog: %v", err)
+			}
+			if blog.IsPublic != tt.wantIsPublic {
+				t.Errorf("want isPublic %v, got %v", tt.wantIsPublic, blog.IsPublic)
+			}
+			if (blog.PublishAt == nil) != (tt.wantPublishAt == nil) ||
+				(blog.PublishAt != nil && *blog.PublishAt != *tt.wantPublishAt) {
+				t.Errorf("want publishAt %v, got %v", tt.wantPublishAt, blog.PublishAt)
+			}
+		})
+	}
+}
+
+func Test_BlogRepository_UpdatePublishAt(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	publicId, err := sut.Add(ctx, tx, &models.Blog{AuthorId: 1, Title: "title", IsPublic: true})
+	if err != nil {
+		t.Fatalf("failed to add blog: %v", err)
+	}
+	privateId, err := sut.Add(ctx, tx, &models.Blog{AuthorId: 1, Title: "title", IsPublic: false})
+	if err != nil {
+		t.Fatalf("failed to add blog: %v", err)
+	}
+
+	publishAt := uint(clocker.Now().Unix() + 3600)
+	blog, err := sut.UpdatePublishAt(ctx, tx, privateId, &publishAt)
+	if err != nil {
+		t.Fatalf("failed to update publish_at: %v", err)
+	}
+	if blog.PublishAt == nil || *blog.PublishAt != publishAt {
+		t.Errorf("want publishAt %d, got %v", publishAt, blog.PublishAt)
+	}
+
+	blog, err = sut.UpdatePublishAt(ctx, tx, privateId, nil)
+	if err != nil {
+		t.Fatalf("failed to cancel publish_at: %v", err)
+	}
+	if blog.PublishAt != nil {
+		t.Errorf("want publishAt nil, got %v", *blog.PublishAt)
+	}
+
+	// 公開済みのブログは予約できない
+	if _, err := sut.UpdatePublishAt(ctx, tx, publicId, &publishAt); !errors.Is(err, repository.ErrResourceNotFound) {
+		t.Errorf("want ErrResourceNotFound, got %v", err)
+	}
+}
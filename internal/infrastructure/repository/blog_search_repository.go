@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/search"
+)
+
+// blogSearchRow は全文検索の結果を受け取るための構造体
+type blogSearchRow struct {
+	models.Blog
+	Rank float64 `db:"rank"`
+}
+
+// buildSearchDocument はブログのタイトル・タグ・概要・本文からtsvectorのリテラルを生成する
+// 重みはタイトル > タグ > 概要 > 本文の順
+func buildSearchDocument(blog *models.Blog) string {
+	fields := []search.Field{
+		{Text: blog.Title, Weight: search.WeightA},
+	}
+	for _, tag := range blog.Tags {
+		fields = append(fields, search.Field{Text: tag, Weight: search.WeightB})
+	}
+	fields = append(fields,
+		search.Field{Text: blog.Description, Weight: search.WeightC},
+		search.Field{Text: blog.Content, Weight: search.WeightD},
+	)
+	return search.BuildDocument(fields...)
+}
+
+// searchDataset はキーワードに一致するブログを検索するクエリのベースを返す
+// query は search.BuildQuery で生成したtsqueryのリテラル
+func searchDataset(query string) *goqu.SelectDataset {
+	return goqu.
+		From("blogs").
+		Join(
+			goqu.T("blog_search_index"),
+			goqu.On(goqu.Ex{"blogs.id": goqu.I("blog_search_index.blog_id")}),
+		).
+		Where(goqu.L("blog_search_index.document @@ ?::tsquery", query))
+}
+
+// searchColumns は検索結果として取得するカラム
+// スニペット生成のためcontentも取得する
+func searchColumns(query string) []interface{} {
+	return []interface{}{
+		"blogs.id", "blogs.author_id", "blogs.title", "blogs.description", "blogs.content",
+		"blogs.thumbnail_image_file_name", "blogs.is_public", "blogs.created", "blogs.modified", "blogs.slug",
+		"blogs.default_locale",
+		goqu.L("ts_rank(blog_search_index.document, ?::tsquery)", query).As("rank"),
+	}
+}
+
+// toSearchResult は検索結果の行をブログに変換し、スニペットを付与する
+// 一覧のレスポンスには本文を含めないためcontentは空にする
+func toSearchResult(rows []*blogSearchRow, keyword string) models.Blogs {
+	blogs := make(models.Blogs, 0, len(rows))
+	for _, row := range rows {
+		blog := row.Blog
+		blog.Snippet = search.Snippet(blog.Content, blog.Description, keyword)
+		blog.Content = ""
+		blogs = append(blogs, &blog)
+	}
+	return blogs
+}
+
+// UpsertSearchIndex はブログの検索インデックスを作成・更新する
+// blog.Tags を含めてインデックスするため、タグの更新後に呼び出す
+func (r *BlogRepository) UpsertSearchIndex(
+	ctx context.Context, tx infrastructure.TX, blog *models.Blog,
+) error {
+	document := buildSearchDocument(blog)
+	sql, params, err := goqu.
+		Insert("blog_search_index").
+		Rows(goqu.Record{
+			"blog_id":  blog.Id,
+			"document": goqu.L("?::tsvector", document),
+		}).
+		OnConflict(goqu.DoUpdate("blog_id", goqu.Record{
+			"document": goqu.L("EXCLUDED.document"),
+		})).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to upsert blog_search_index: %w", err)
+	}
+	return nil
+}
+
+// DeleteAllSearchIndex は検索インデックスをすべて削除する
+func (r *BlogRepository) DeleteAllSearchIndex(ctx context.Context, tx infrastructure.TX) error {
+	sql, params, err := goqu.Delete("blog_search_index").ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to delete blog_search_index: %w", err)
+	}
+	return nil
+}
+
+// ListAllIds はゴミ箱にあるものを除くすべてのブログのIDを昇順で取得する
+func (r *BlogRepository) ListAllIds(ctx context.Context, tx infrastructure.TX) ([]models.BlogId, error) {
+	sql, params, err := goqu.
+		Select("id").
+		From("blogs").
+		Where(goqu.Ex{"deleted_at": nil}).
+		Order(goqu.I("id").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var ids []models.BlogId
+	if err := tx.SelectContext(ctx, &ids, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog ids: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// ListPublicBlogsForSitemap は、sitemapに掲載する公開済みのブログを全件取得する
+// 本文やタグは取得しない
+func (r *BlogRepository) ListPublicBlogsForSitemap(
+	ctx context.Context, tx infrastructure.TX,
+) ([]*models.Blog, error) {
+	sql, params, err := goqu.
+		Select("id", "slug", "created", "modified").
+		From("blogs").
+		Where(goqu.Ex{"is_public": true, "deleted_at": nil}).
+		Order(goqu.I("id").Desc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	blogs := []*models.Blog{}
+	if err := tx.SelectContext(ctx, &blogs, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blogs: %w", err)
+	}
+	return blogs, nil
+}
+
+// ListPublicTagsForSitemap は、公開済みのブログに紐づくタグと、その最終更新日時を取得する
+func (r *BlogRepository) ListPublicTagsForSitemap(
+	ctx context.Context, tx infrastructure.TX,
+) ([]*models.TagLastModified, error) {
+	sql, params, err := goqu.
+		Select(
+			goqu.I("tags.name"),
+			goqu.MAX("blogs.modified").As("modified"),
+		).
+		From("tags").
+		Join(
+			goqu.T("blogs_tags"),
+			goqu.On(goqu.Ex{"blogs_tags.tag_id": goqu.I("tags.id")}),
+		).
+		Join(
+			goqu.T("blogs"),
+			goqu.On(goqu.Ex{"blogs.id": goqu.I("blogs_tags.blog_id")}),
+		).
+		Where(goqu.Ex{"blogs.is_public": true, "blogs.deleted_at": nil}).
+		GroupBy(goqu.I("tags.name")).
+		Order(goqu.I("tags.name").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	tags := []*models.TagLastModified{}
+	if err := tx.SelectContext(ctx, &tags, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select tags: %w", err)
+	}
+	return tags, nil
+}
@@ -0,0 +1,68 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_BlogRepository_Sitemap(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	add := func(isPublic bool, tags ...string) models.BlogId {
+		t.Helper()
+		id, err := sut.Add(ctx, tx, &models.Blog{
+			AuthorId:    1,
+			Title:       "title",
+			Content:     "content",
+			Description: "description",
+			IsPublic:    isPublic,
+		})
+		if err != nil {
+			t.Fatalf("failed to add blog: %v", err)
+		}
+		for _, tag := range tags {
+			tagId, err := sut.AddTag(ctx, tx, tag)
+			if err != nil {
+				t.Fatalf("failed to add tag: %v", err)
+			}
+			if _, err := sut.AddBlogTag(ctx, tx, id, tagId); err != nil {
+				t.Fatalf("failed to add blogs_tags: %v", err)
+			}
+		}
+		return id
+	}
+	publicId := add(true, "public")
+	add(false, "private")
+
+	blogs, err := sut.ListPublicBlogsForSitemap(ctx, tx)
+	if err != nil {
+		t.Fatalf("failed to list blogs: %v", err)
+	}
+	if len(blogs) != 1 || blogs[0].Id != publicId {
+		t.Errorf("unexpected blogs: %+v", blogs)
+	}
+
+	tags, err := sut.ListPublicTagsForSitemap(ctx, tx)
+	if err != nil {
+		t.Fatalf("failed to list tags: %v", err)
+	}
+	if len(tags) != 1 || tags[0].Name != "public" {
+		t.Errorf("unexpected tags: %+v", tags)
+	}
+}
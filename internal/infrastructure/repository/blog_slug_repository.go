@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// GetIdBySlug は、スラッグに一致するブログのIDを取得する
+// 存在しない場合はnilを返す
+func (r *BlogRepository) GetIdBySlug(
+	ctx context.Context, tx infrastructure.TX, slug string,
+) (*models.BlogId, error) {
+	sql, params, err := goqu.
+		Select("id").
+		From("blogs").
+		Where(goqu.Ex{"slug": slug, "deleted_at": nil}).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var ids []models.BlogId
+	if err := tx.SelectContext(ctx, &ids, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return &ids[0], nil
+}
+
+// GetSlugOwner は、スラッグを現在または過去に使用しているブログのIDを取得する
+// 使用されていない場合はnilを返す
+func (r *BlogRepository) GetSlugOwner(
+	ctx context.Context, tx infrastructure.TX, slug string,
+) (*models.BlogId, error) {
+	sql, params, err := goqu.
+		Select("id").
+		From("blogs").
+		Where(goqu.Ex{"slug": slug}).
+		UnionAll(
+			goqu.
+				Select("blog_id").
+				From("blog_slug_redirects").
+				Where(goqu.Ex{"slug": slug}),
+		).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var ids []models.BlogId
+	if err := tx.SelectContext(ctx, &ids, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select slug owner: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return &ids[0], nil
+}
+
+// GetRedirectSlug は、過去のスラッグからブログの現在のスラッグを取得する
+// リダイレクト先がない場合はnilを返す
+func (r *BlogRepository) GetRedirectSlug(
+	ctx context.Context, tx infrastructure.TX, slug string,
+) (*string, error) {
+	sql, params, err := goqu.
+		Select("blogs.slug").
+		From("blog_slug_redirects").
+		Join(
+			goqu.T("blogs"),
+			goqu.On(goqu.Ex{"blog_slug_redirects.blog_id": goqu.I("blogs.id")}),
+		).
+		Where(goqu.Ex{"blog_slug_redirects.slug": slug, "blogs.deleted_at": nil}).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var slugs []string
+	if err := tx.SelectContext(ctx, &slugs, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog_slug_redirects: %w", err)
+	}
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+	return &slugs[0], nil
+}
+
+// AddSlugRedirect は、過去のスラッグをリダイレクト元として登録する
+func (r *BlogRepository) AddSlugRedirect(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId, slug string,
+) error {
+	sql, params, err := goqu.
+		Insert("blog_slug_redirects").
+		Rows(goqu.Record{
+			"slug":    slug,
+			"blog_id": blogId,
+			"created": r.Clocker.Now().Unix(),
+		}).
+		OnConflict(goqu.DoUpdate("slug", goqu.Record{
+			"blog_id": goqu.I("EXCLUDED.blog_id"),
+			"created": goqu.I("EXCLUDED.created"),
+		})).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to insert blog_slug_redirects: %w", err)
+	}
+	return nil
+}
+
+// DeleteSlugRedirect は、リダイレクト元のスラッグを削除する
+func (r *BlogRepository) DeleteSlugRedirect(
+	ctx context.Context, tx infrastructure.TX, slug string,
+) error {
+	sql, params, err := goqu.
+		Delete("blog_slug_redirects").
+		Where(goqu.Ex{"slug": slug}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to delete blog_slug_redirects: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_BlogRepository_Slug(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	add := func(slug string) models.BlogId {
+		t.Helper()
+		id, err := sut.Add(ctx, tx, &models.Blog{
+			AuthorId:    1,
+			Title:       "title",
+			Content:     "content",
+			Description: "description",
+			IsPublic:    true,
+			Slug:        slug,
+		})
+		if err != nil {
+			t.Fatalf("failed to add blog: %v", err)
+		}
+		return id
+	}
+	sluggedId := add("hello-world")
+	defaultId := add("")
+
+	t.Run("スラッグ未指定の場合はIDによるスラッグになる", func(t *testing.T) {
+		blog, err := sut.Get(ctx, tx, defaultId)
+		if err != nil {
+			t.Fatalf("failed to get blog: %v", err)
+		}
+		if want := fmt.Sprintf("blog-%d", defaultId); blog.Slug != want {
+			t.Errorf("want slug %q, got %q", want, blog.Slug)
+		}
+	})
+
+	t.Run("スラッグからブログのIDを取得できる", func(t *testing.T) {
+		id, err := sut.GetIdBySlug(ctx, tx, "hello-world")
+		if err != nil {
+			t.Fatalf("failed to get blog id by slug: %v", err)
+		}
+		if id == nil || *id != sluggedId {
+			t.Errorf("unexpected blog id: %v", id)
+		}
+		notFound, err := sut.GetIdBySlug(ctx, tx, "not-found")
+		if err != nil {
+			t.Fatalf("failed to get blog id by slug: %v", err)
+		}
+		if notFound != nil {
+			t.Errorf("want nil, got %v", *notFound)
+		}
+	})
+
+	t.Run("旧スラッグから現在のスラッグを取得できる", func(t *testing.T) {
+		if err := sut.AddSlugRedirect(ctx, tx, sluggedId, "old-slug"); err != nil {
+			t.Fatalf("failed to add slug redirect: %v", err)
+		}
+		redirect, err := sut.GetRedirectSlug(ctx, tx, "old-slug")
+		if err != nil {
+			t.Fatalf("failed to get redirect slug: %v", err)
+		}
+		if redirect == nil || *redirect != "hello-world" {
+			t.Errorf("unexpected redirect slug: %v", redirect)
+		}
+
+		owner, err := sut.GetSlugOwner(ctx, tx, "old-slug")
+		if err != nil {
+			t.Fatalf("failed to get slug owner: %v", err)
+		}
+		if owner == nil || *owner != sluggedId {
+			t.Errorf("unexpected slug owner: %v", owner)
+		}
+
+		if err := sut.DeleteSlugRedirect(ctx, tx, "old-slug"); err != nil {
+			t.Fatalf("failed to delete slug redirect: %v", err)
+		}
+		redirect, err = sut.GetRedirectSlug(ctx, tx, "old-slug")
+		if err != nil {
+			t.Fatalf("failed to get redirect slug: %v", err)
+		}
+		if redirect != nil {
+			t.Errorf("want nil, got %v", *redirect)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// GetTag は、タグを公開済みのブログの件数とあわせて取得する
+// 存在しない場合はnilを返す
+func (r *BlogRepository) GetTag(
+	ctx context.Context, tx infrastructure.TX, tagId models.TagId,
+) (*models.Tag, error) {
+	sql, params, err := goqu.
+		From("tags").
+		Select(
+			goqu.I("tags.id"),
+			goqu.I("tags.name"),
+			goqu.I("tags.description"),
+			goqu.I("tags.color"),
+			goqu.COUNT(goqu.I("blogs.id")).As("count"),
+		).
+		LeftJoin(
+			goqu.T("blogs_tags"),
+			goqu.On(goqu.Ex{"blogs_tags.tag_id": goqu.I("tags.id")}),
+		).
+		LeftJoin(
+			goqu.T("blogs"),
+			goqu.On(goqu.Ex{
+				"blogs.id":         goqu.I("blogs_tags.blog_id"),
+				"blogs.is_public":  true,
+				"blogs.deleted_at": nil,
+			}),
+		).
+		Where(goqu.Ex{"tags.id": tagId}).
+		GroupBy(goqu.I("tags.id")).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var tags []*models.Tag
+	if err := tx.SelectContext(ctx, &tags, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select tags: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return tags[0], nil
+}
+
+// PutTag は、タグの名前・説明・表示色を更新する
+func (r *BlogRepository) PutTag(
+	ctx context.Context, tx infrastructure.TX, tag *models.Tag,
+) error {
+	sql, params, err := goqu.
+		Update("tags").
+		Set(goqu.Record{
+			"name":        tag.Name,
+			"description": tag.Description,
+			"color":       tag.Color,
+		}).
+		Where(goqu.Ex{"id": tag.Id}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to update tags: %w", err)
+	}
+	return nil
+}
+
+// MergeTag は、fromのタグが付けられたブログをintoのタグに付け替え、fromのタグを削除する
+// 両方のタグが付けられていたブログは、intoのタグのリレーションのみが残る
+func (r *BlogRepository) MergeTag(
+	ctx context.Context, tx infrastructure.TX, from models.TagId, into models.TagId,
+) error {
+	insertSQL, insertParams, err := goqu.
+		Insert("blogs_tags").
+		Cols("blog_id", "tag_id").
+		FromQuery(
+			goqu.
+				From("blogs_tags").
+				Select(goqu.I("blog_id"), goqu.V(into)).
+				Where(goqu.Ex{"tag_id": from}),
+		).
+		OnConflict(goqu.DoNothing()).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, insertSQL, insertParams...); err != nil {
+		return fmt.Errorf("failed to insert blogs_tags: %w", err)
+	}
+
+	deleteRelationSQL, deleteRelationParams, err := goqu.
+		Delete("blogs_tags").
+		Where(goqu.Ex{"tag_id": from}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, deleteRelationSQL, deleteRelationParams...); err != nil {
+		return fmt.Errorf("failed to delete blogs_tags: %w", err)
+	}
+
+	if err := r.DeleteTag(ctx, tx, from); err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	return nil
+}
+
+// ListBlogIdsByTagId は、指定したタグが付けられたブログのIDを取得する
+func (r *BlogRepository) ListBlogIdsByTagId(
+	ctx context.Context, tx infrastructure.TX, tagId models.TagId,
+) ([]models.BlogId, error) {
+	sql, params, err := goqu.
+		From("blogs_tags").
+		Select("blog_id").
+		Where(goqu.Ex{"tag_id": tagId}).
+		Order(goqu.I("blog_id").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	ids := []models.BlogId{}
+	if err := tx.SelectContext(ctx, &ids, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blogs_tags: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_BlogRepository_MergeTag(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	fromTagId, err := sut.AddTag(ctx, tx, "golang")
+	if err != nil {
+		t.Fatalf("failed to add tag: %v", err)
+	}
+	intoTagId, err := sut.AddTag(ctx, tx, "go")
+	if err != nil {
+		t.Fatalf("failed to add tag: %v", err)
+	}
+
+	// blog1はfromのみ、blog2は両方、blog3は非公開でfromのみ
+	testdata := []struct {
+		isPublic bool
+		tagIds   []models.TagId
+	}{
+		{true, []models.TagId{fromTagId}},
+		{true, []models.TagId{fromTagId, intoTagId}},
+		{false, []models.TagId{fromTagId}},
+	}
+	var blogIds []models.BlogId
+	for _, d := range testdata {
+		id, err := sut.Add(ctx, tx, &models.Blog{
+			AuthorId:    1,
+			Title:       "title",
+			Content:     "content",
+			Description: "description",
+			IsPublic:    d.isPublic,
+		})
+		if err != nil {
+			t.Fatalf("failed to add blog: %v", err)
+		}
+		for _, tagId := range d.tagIds {
+			if _, err := sut.AddBlogTag(ctx, tx, id, tagId); err != nil {
+				t.Fatalf("failed to add blogs_tags: %v", err)
+			}
+		}
+		blogIds = append(blogIds, id)
+	}
+
+	if err := sut.MergeTag(ctx, tx, fromTagId, intoTagId); err != nil {
+		t.Fatalf("failed to merge tag: %v", err)
+	}
+
+	from, err := sut.GetTag(ctx, tx, fromTagId)
+	if err != nil {
+		t.Fatalf("failed to get tag: %v", err)
+	}
+	if from != nil {
+		t.Errorf("want merged tag to be deleted, got %+v", from)
+	}
+
+	into, err := sut.GetTag(ctx, tx, intoTagId)
+	if err != nil {
+		t.Fatalf("failed to get tag: %v", err)
+	}
+	if into == nil {
+		t.Fatalf("tag not found")
+	}
+	if into.Count != 2 {
+		t.Errorf("want count 2, got %d", into.Count)
+	}
+
+	ids, err := sut.ListBlogIdsByTagId(ctx, tx, intoTagId)
+	if err != nil {
+		t.Fatalf("failed to list blog ids: %v", err)
+	}
+	if len(ids) != len(blogIds) {
+		t.Fatalf("want %d blogs, got %d", len(blogIds), len(ids))
+	}
+	for i, id := range blogIds {
+		if ids[i] != id {
+			t.Errorf("want blog id %d, got %d", id, ids[i])
+		}
+	}
+}
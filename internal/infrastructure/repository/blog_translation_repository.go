@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// UpsertTranslation は、ブログの言語ごとの翻訳を作成・更新する
+func (r *BlogRepository) UpsertTranslation(
+	ctx context.Context, tx infrastructure.TX, translation *models.BlogTranslation,
+) error {
+	sql, params, err := goqu.
+		Insert("blog_translations").
+		Rows(goqu.Record{
+			"blog_id":     translation.BlogId,
+			"locale":      translation.Locale,
+			"title":       translation.Title,
+			"description": translation.Description,
+			"content":     translation.Content,
+		}).
+		OnConflict(goqu.DoUpdate("blog_id, locale", goqu.Record{
+			"title":       goqu.L("EXCLUDED.title"),
+			"description": goqu.L("EXCLUDED.description"),
+			"content":     goqu.L("EXCLUDED.content"),
+		})).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to upsert blog_translations: %w", err)
+	}
+	return nil
+}
+
+// GetTranslation は、ブログの指定した言語の翻訳を取得する
+// 存在しない場合はnilを返す
+func (r *BlogRepository) GetTranslation(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId, locale string,
+) (*models.BlogTranslation, error) {
+	sql, params, err := goqu.
+		Select("blog_id", "locale", "title", "description", "content", "created", "modified").
+		From("blog_translations").
+		Where(goqu.Ex{"blog_id": blogId, "locale": locale}).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var translations []*models.BlogTranslation
+	if err := tx.SelectContext(ctx, &translations, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog_translations: %w", err)
+	}
+	if len(translations) == 0 {
+		return nil, nil
+	}
+	return translations[0], nil
+}
+
+// DeleteTranslation は、ブログの指定した言語の翻訳を削除する
+func (r *BlogRepository) DeleteTranslation(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId, locale string,
+) error {
+	sql, params, err := goqu.
+		Delete("blog_translations").
+		Where(goqu.Ex{"blog_id": blogId, "locale": locale}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to delete blog_translations: %w", err)
+	}
+	return nil
+}
+
+// ListTranslationLocales は、複数のブログの翻訳がある言語を1回のクエリでまとめて取得する
+// 言語はブログごとに昇順で、翻訳のないブログは結果に含まれない
+func (r *BlogRepository) ListTranslationLocales(
+	ctx context.Context, tx infrastructure.TX, blogIds []models.BlogId,
+) (map[models.BlogId][]string, error) {
+	result := make(map[models.BlogId][]string, len(blogIds))
+	if len(blogIds) == 0 {
+		return result, nil
+	}
+	sql, params, err := goqu.
+		Select("blog_id", "locale").
+		From("blog_translations").
+		Where(goqu.Ex{"blog_id": blogIds}).
+		Order(goqu.I("blog_id").Asc(), goqu.I("locale").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var translations []*models.BlogTranslation
+	if err := tx.SelectContext(ctx, &translations, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog_translations: %w", err)
+	}
+	for _, t := range translations {
+		result[t.BlogId] = append(result[t.BlogId], t.Locale)
+	}
+	return result, nil
+}
+
+// ListTranslationModified は、複数のブログの翻訳の最終更新日時を1回のクエリでまとめて取得する
+// 翻訳のないブログは結果に含まれない
+func (r *BlogRepository) ListTranslationModified(
+	ctx context.Context, tx infrastructure.TX, blogIds []models.BlogId,
+) (map[models.BlogId]uint, error) {
+	result := make(map[models.BlogId]uint, len(blogIds))
+	if len(blogIds) == 0 {
+		return result, nil
+	}
+	sql, params, err := goqu.
+		Select("blog_id", goqu.MAX("modified").As("modified")).
+		From("blog_translations").
+		Where(goqu.Ex{"blog_id": blogIds}).
+		GroupBy("blog_id").
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var translations []*models.BlogTranslation
+	if err := tx.SelectContext(ctx, &translations, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog_translations: %w", err)
+	}
+	for _, t := range translations {
+		result[t.BlogId] = t.Modified
+	}
+	return result, nil
+}
+
+// ListTranslations は、複数のブログの指定した言語の翻訳を1回のクエリでまとめて取得する
+// 一覧のレスポンスには本文を含めないため、本文は取得しない
+func (r *BlogRepository) ListTranslations(
+	ctx context.Context, tx infrastructure.TX, blogIds []models.BlogId, locale string,
+) (map[models.BlogId]*models.BlogTranslation, error) {
+	result := make(map[models.BlogId]*models.BlogTranslation, len(blogIds))
+	if len(blogIds) == 0 {
+		return result, nil
+	}
+	sql, params, err := goqu.
+		Select("blog_id", "locale", "title", "description", "created", "modified").
+		From("blog_translations").
+		Where(goqu.Ex{"blog_id": blogIds, "locale": locale}).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var translations []*models.BlogTranslation
+	if err := tx.SelectContext(ctx, &translations, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog_translations: %w", err)
+	}
+	for _, t := range translations {
+		result[t.BlogId] = t
+	}
+	return result, nil
+}
@@ -0,0 +1,165 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/options"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_BlogRepository_Translation(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	id, err := sut.Add(ctx, tx, &models.Blog{
+		AuthorId:    1,
+		Title:       "タイトル",
+		Content:     "本文",
+		Description: "概要",
+	})
+	if err != nil {
+		t.Fatalf("failed to add blog: %v", err)
+	}
+	blog, err := sut.Get(ctx, tx, id)
+	if err != nil {
+		t.Fatalf("failed to get blog: %v", err)
+	}
+	if blog.DefaultLocale != models.DefaultBlogLocale {
+		t.Errorf("unexpected default locale: %s", blog.DefaultLocale)
+	}
+
+	got, err := sut.GetTranslation(ctx, tx, id, "en")
+	if err != nil {
+		t.Fatalf("failed to get translation: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("want nil before upsert, got %+v", got)
+	}
+
+	for _, translation := range []*models.BlogTranslation{
+		{BlogId: id, Locale: "en", Title: "title", Description: "description", Content: "content"},
+		{BlogId: id, Locale: "en", Title: "new title", Description: "new description", Content: "new content"},
+		{BlogId: id, Locale: "de", Title: "Titel", Description: "Beschreibung", Content: "Inhalt"},
+	} {
+		if err := sut.UpsertTranslation(ctx, tx, translation); err != nil {
+			t.Fatalf("failed to upsert translation: %v", err)
+		}
+	}
+
+	got, err = sut.GetTranslation(ctx, tx, id, "en")
+	if err != nil {
+		t.Fatalf("failed to get translation: %v", err)
+	}
+	if got == nil || got.Title != "new title" || got.Content != "new content" {
+		t.Fatalf("unexpected translation: %+v", got)
+	}
+
+	locales, err := sut.ListTranslationLocales(ctx, tx, []models.BlogId{id})
+	if err != nil {
+		t.Fatalf("failed to list translation locales: %v", err)
+	}
+	if diff := cmp.Diff(locales, map[models.BlogId][]string{id: {"de", "en"}}); diff != "" {
+		t.Errorf("differs: (-got +want)\n%s", diff)
+	}
+
+	modified, err := sut.ListTranslationModified(ctx, tx, []models.BlogId{id})
+	if err != nil {
+		t.Fatalf("failed to list translation modified: %v", err)
+	}
+	if diff := cmp.Diff(modified, map[models.BlogId]uint{id: got.Modified}); diff != "" {
+		t.Errorf("differs: (-got +want)\n%s", diff)
+	}
+
+	if err := sut.DeleteTranslation(ctx, tx, id, "de"); err != nil {
+		t.Fatalf("failed to delete translation: %v", err)
+	}
+	got, err = sut.GetTranslation(ctx, tx, id, "de")
+	if err != nil {
+		t.Fatalf("failed to get translation: %v", err)
+	}
+	if got != nil {
+		t.Errorf("want nil after delete, got %+v", got)
+	}
+}
+
+func Test_BlogRepository_Query_Locale(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	add := func(title string, defaultLocale string) models.BlogId {
+		id, err := sut.Add(ctx, tx, &models.Blog{
+			AuthorId:      1,
+			Title:         title,
+			Content:       "content",
+			Description:   "description",
+			IsPublic:      true,
+			DefaultLocale: defaultLocale,
+		})
+		if err != nil {
+			t.Fatalf("failed to add blog: %v", err)
+		}
+		return id
+	}
+	translated := add("翻訳あり", "ja")
+	add("翻訳なし", "ja")
+	english := add("english", "en")
+	if err := sut.UpsertTranslation(ctx, tx, &models.BlogTranslation{
+		BlogId: translated, Locale: "en", Title: "translated", Description: "translated description", Content: "content",
+	}); err != nil {
+		t.Fatalf("failed to upsert translation: %v", err)
+	}
+
+	locale := "en"
+	option, err := options.NewListBlogOptions(
+		&options.BlogFilter{Locale: &locale}, &options.BlogSort{Key: options.BlogSortId, Asc: true}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create options: %v", err)
+	}
+	blogs, err := sut.Query(ctx, tx, option)
+	if err != nil {
+		t.Fatalf("failed to query: %v", err)
+	}
+
+	type result struct {
+		Id          models.BlogId
+		Title       string
+		Description string
+		Locale      string
+	}
+	got := make([]result, 0, len(blogs))
+	for _, blog := range blogs {
+		got = append(got, result{blog.Id, blog.Title, blog.Description, blog.Locale})
+	}
+	want := []result{
+		{translated, "translated", "translated description", "en"},
+		{english, "english", "description", "en"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("differs: (-got +want)\n%s", diff)
+	}
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// Trash は、ブログをゴミ箱に移動する
+// ゴミ箱にあるブログは一覧・詳細・フィードなどから除かれる
+// ブログが存在しないか、すでにゴミ箱にある場合はErrResourceNotFoundを返す
+// versionを指定した場合は、現在のバージョンが一致しない場合にErrVersionMismatchを返す
+func (r *BlogRepository) Trash(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId, deletedAt uint, version *int64,
+) error {
+	where := goqu.Ex{"id": blogId, "deleted_at": nil}
+	if version != nil {
+		where["version"] = *version
+	}
+	sql, params, err := goqu.
+		Update("blogs").
+		Set(goqu.Record{"deleted_at": deletedAt}).
+		Where(where).
+		Returning("id").
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	var ids []models.BlogId
+	if err := tx.SelectContext(ctx, &ids, sql, params...); err != nil {
+		return fmt.Errorf("failed to update deleted_at: %w", err)
+	}
+	if len(ids) == 0 {
+		return r.notUpdatedError(ctx, tx, blogId)
+	}
+	return nil
+}
+
+// Restore は、ゴミ箱にあるブログを元に戻す
+// ブログがゴミ箱にない場合はErrResourceNotFoundを返す
+func (r *BlogRepository) Restore(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId,
+) error {
+	sql, params, err := goqu.
+		Update("blogs").
+		Set(goqu.Record{"deleted_at": nil}).
+		Where(
+			goqu.Ex{"id": blogId},
+			goqu.C("deleted_at").IsNotNull(),
+		).
+		Returning("id").
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	var ids []models.BlogId
+	if err := tx.SelectContext(ctx, &ids, sql, params...); err != nil {
+		return fmt.Errorf("failed to update deleted_at: %w", err)
+	}
+	if len(ids) == 0 {
+		return ErrResourceNotFound
+	}
+	return nil
+}
+
+// GetTrashed は、ゴミ箱にあるブログを取得する
+// ブログがゴミ箱にない場合はnilを返す
+func (r *BlogRepository) GetTrashed(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId,
+) (*models.Blog, error) {
+	sql, params, err := goqu.
+		Select(
+			"id", "author_id", "title", "content", "description",
+			"thumbnail_image_file_name", "is_public", "created", "modified", "publish_at", "slug", "default_locale",
+			"deleted_at",
+		).
+		From("blogs").
+		Where(
+			goqu.Ex{"id": blogId},
+			goqu.C("deleted_at").IsNotNull(),
+		).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var blogs []*models.Blog
+	if err := tx.SelectContext(ctx, &blogs, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog: %w", err)
+	}
+	if len(blogs) == 0 {
+		return nil, nil
+	}
+	tags, err := r.ListBlogTags(ctx, tx, []models.BlogId{blogId})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blog tags: %w", err)
+	}
+	blogs[0].Tags = tags[blogId]
+	return blogs[0], nil
+}
+
+// ListTrashedBlogs は、著者のゴミ箱にあるブログをゴミ箱に移動した日時の降順で取得する
+// 一覧のレスポンスには本文を含めない
+func (r *BlogRepository) ListTrashedBlogs(
+	ctx context.Context, tx infrastructure.TX, authorId models.UserId,
+) ([]*models.Blog, error) {
+	sql, params, err := goqu.
+		Select(
+			"id", "author_id", "title", "description",
+			"thumbnail_image_file_name", "is_public", "created", "modified", "publish_at", "slug", "default_locale",
+			"deleted_at",
+		).
+		From("blogs").
+		Where(
+			goqu.Ex{"author_id": authorId},
+			goqu.C("deleted_at").IsNotNull(),
+		).
+		Order(goqu.I("deleted_at").Desc(), goqu.I("id").Desc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	blogs := []*models.Blog{}
+	if err := tx.SelectContext(ctx, &blogs, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blogs: %w", err)
+	}
+	ids := make([]models.BlogId, 0, len(blogs))
+	for _, blog := range blogs {
+		ids = append(ids, blog.Id)
+	}
+	tags, err := r.ListBlogTags(ctx, tx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blog tags: %w", err)
+	}
+	for _, blog := range blogs {
+		blog.Tags = tags[blog.Id]
+	}
+	return blogs, nil
+}
+
+// ListTrashedIdsBefore は、before以前にゴミ箱に移動したブログのIDを昇順で取得する
+func (r *BlogRepository) ListTrashedIdsBefore(
+	ctx context.Context, tx infrastructure.TX, before uint,
+) ([]models.BlogId, error) {
+	sql, params, err := goqu.
+		Select("id").
+		From("blogs").
+		Where(goqu.C("deleted_at").Lte(before)).
+		Order(goqu.I("id").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	ids := []models.BlogId{}
+	if err := tx.SelectContext(ctx, &ids, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog ids: %w", err)
+	}
+	return ids, nil
+}
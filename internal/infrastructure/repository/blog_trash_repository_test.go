@@ -0,0 +1,125 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/options"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_BlogRepository_Trash(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+	now := uint(clocker.Now().Unix())
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	trashedId, err := sut.Add(ctx, tx, &models.Blog{
+		AuthorId: 1, Title: "trashed", Slug: "trashed", IsPublic: true, Tags: []string{"go"},
+	})
+	if err != nil {
+		t.Fatalf("failed to add blog: %v", err)
+	}
+	if _, err := sut.Add(ctx, tx, &models.Blog{AuthorId: 1, Title: "alive", IsPublic: true}); err != nil {
+		t.Fatalf("failed to add blog: %v", err)
+	}
+
+	if err := sut.Trash(ctx, tx, trashedId, now, nil); err != nil {
+		t.Fatalf("failed to trash blog: %v", err)
+	}
+	if err := sut.Trash(ctx, tx, trashedId, now, nil); !errors.Is(err, repository.ErrResourceNotFound) {
+		t.Errorf("want ErrResourceNotFound for trashed blog, got %v", err)
+	}
+
+	t.Run("ゴミ箱にあるブログは取得できない", func(t *testing.T) {
+		blog, err := sut.Get(ctx, tx, trashedId)
+		if err != nil {
+			t.Fatalf("failed to get blog: %v", err)
+		}
+		if blog != nil {
+			t.Errorf("want nil, got %+v", blog)
+		}
+		id, err := sut.GetIdBySlug(ctx, tx, "trashed")
+		if err != nil {
+			t.Fatalf("failed to get id by slug: %v", err)
+		}
+		if id != nil {
+			t.Errorf("want nil, got %v", *id)
+		}
+	})
+
+	t.Run("ゴミ箱にあるブログは一覧に含まれない", func(t *testing.T) {
+		blogs, err := sut.Query(ctx, tx, &options.ListBlogOptions{Limit: 10})
+		if err != nil {
+			t.Fatalf("failed to query blogs: %v", err)
+		}
+		for _, blog := range blogs {
+			if blog.Id == trashedId {
+				t.Errorf("trashed blog is listed")
+			}
+		}
+	})
+
+	t.Run("ゴミ箱にあるブログを取得できる", func(t *testing.T) {
+		blog, err := sut.GetTrashed(ctx, tx, trashedId)
+		if err != nil {
+			t.Fatalf("failed to get trashed blog: %v", err)
+		}
+		if blog == nil || blog.DeletedAt == nil || *blog.DeletedAt != now {
+			t.Fatalf("unexpected trashed blog: %+v", blog)
+		}
+		if len(blog.Tags) != 1 || blog.Tags[0] != "go" {
+			t.Errorf("want tags [go], got %v", blog.Tags)
+		}
+		blogs, err := sut.ListTrashedBlogs(ctx, tx, 1)
+		if err != nil {
+			t.Fatalf("failed to list trashed blogs: %v", err)
+		}
+		if len(blogs) != 1 || blogs[0].Id != trashedId {
+			t.Errorf("unexpected trashed blogs: %+v", blogs)
+		}
+		ids, err := sut.ListTrashedIdsBefore(ctx, tx, now-1)
+		if err != nil {
+			t.Fatalf("failed to list trashed ids: %v", err)
+		}
+		if len(ids) != 0 {
+			t.Errorf("want no ids before trashed, got %v", ids)
+		}
+		ids, err = sut.ListTrashedIdsBefore(ctx, tx, now)
+		if err != nil {
+			t.Fatalf("failed to list trashed ids: %v", err)
+		}
+		if len(ids) != 1 || ids[0] != trashedId {
+			t.Errorf("want [%d], got %v", trashedId, ids)
+		}
+	})
+
+	t.Run("ゴミ箱から元に戻せる", func(t *testing.T) {
+		if err := sut.Restore(ctx, tx, trashedId); err != nil {
+			t.Fatalf("failed to restore blog: %v", err)
+		}
+		blog, err := sut.Get(ctx, tx, trashedId)
+		if err != nil {
+			t.Fatalf("failed to get blog: %v", err)
+		}
+		if blog == nil {
+			t.Fatalf("restored blog is not found")
+		}
+		if err := sut.Restore(ctx, tx, trashedId); !errors.Is(err, repository.ErrResourceNotFound) {
+			t.Errorf("want ErrResourceNotFound for restored blog, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// AddViews は、日別の閲覧数を加算する
+// 削除済みのブログの閲覧数は無視する
+func (r *BlogRepository) AddViews(
+	ctx context.Context, tx infrastructure.TX, views []*models.BlogViewDaily,
+) error {
+	if len(views) == 0 {
+		return nil
+	}
+	ids := make([]models.BlogId, 0, len(views))
+	for _, v := range views {
+		ids = append(ids, v.BlogId)
+	}
+	sql, params, err := goqu.
+		Select("id").
+		From("blogs").
+		Where(goqu.Ex{"id": ids}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	var existIds []models.BlogId
+	if err := tx.SelectContext(ctx, &existIds, sql, params...); err != nil {
+		return fmt.Errorf("failed to select blogs: %w", err)
+	}
+	exists := make(map[models.BlogId]struct{}, len(existIds))
+	for _, id := range existIds {
+		exists[id] = struct{}{}
+	}
+
+	rows := make([]any, 0, len(views))
+	for _, v := range views {
+		if _, ok := exists[v.BlogId]; !ok {
+			continue
+		}
+		rows = append(rows, goqu.Record{
+			"blog_id":   v.BlogId,
+			"view_date": v.Date,
+			"views":     v.Views,
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	sql, params, err = goqu.
+		Insert("blog_view_daily").
+		Rows(rows...).
+		OnConflict(goqu.DoUpdate("blog_id, view_date", goqu.Record{
+			"views": goqu.L("blog_view_daily.views + EXCLUDED.views"),
+		})).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to upsert blog_view_daily: %w", err)
+	}
+	return nil
+}
+
+// ListDailyViews は、期間内の日別の閲覧数を日付の昇順で取得する
+// blogIdがnilの場合はサイト全体の閲覧数を集計する
+// from, toは YYYY-MM-DD 形式で、どちらも期間に含む
+func (r *BlogRepository) ListDailyViews(
+	ctx context.Context, tx infrastructure.TX, blogId *models.BlogId, from string, to string,
+) ([]*models.ViewCount, error) {
+	builder := goqu.
+		Select(
+			goqu.L("to_char(view_date, 'YYYY-MM-DD')").As("view_date"),
+			goqu.L("SUM(views)::bigint").As("views"),
+		).
+		From("blog_view_daily").
+		Where(goqu.L("view_date BETWEEN ?::date AND ?::date", from, to)).
+		GroupBy(goqu.I("blog_view_daily.view_date")).
+		Order(goqu.I("blog_view_daily.view_date").Asc())
+	if blogId != nil {
+		builder = builder.Where(goqu.Ex{"blog_id": *blogId})
+	}
+	sql, params, err := builder.ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	views := []*models.ViewCount{}
+	if err := tx.SelectContext(ctx, &views, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog_view_daily: %w", err)
+	}
+	return views, nil
+}
+
+// ListTopViewedBlogs は、期間内の閲覧数が多いブログを最大limit件取得する
+// from, toは YYYY-MM-DD 形式で、どちらも期間に含む
+func (r *BlogRepository) ListTopViewedBlogs(
+	ctx context.Context, tx infrastructure.TX, from string, to string, limit uint,
+) ([]*models.BlogViewCount, error) {
+	sql, params, err := goqu.
+		Select(
+			goqu.I("blogs.id").As("blog_id"),
+			goqu.I("blogs.title"),
+			goqu.L("SUM(blog_view_daily.views)::bigint").As("views"),
+		).
+		From("blog_view_daily").
+		Join(
+			goqu.T("blogs"),
+			goqu.On(goqu.Ex{"blogs.id": goqu.I("blog_view_daily.blog_id")}),
+		).
+		Where(
+			goqu.L("blog_view_daily.view_date BETWEEN ?::date AND ?::date", from, to),
+			goqu.Ex{"blogs.deleted_at": nil},
+		).
+		GroupBy(goqu.I("blogs.id"), goqu.I("blogs.title")).
+		Order(goqu.L("views").Desc(), goqu.I("blogs.id").Desc()).
+		Limit(limit).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	blogs := []*models.BlogViewCount{}
+	if err := tx.SelectContext(ctx, &blogs, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select blog_view_daily: %w", err)
+	}
+	return blogs, nil
+}
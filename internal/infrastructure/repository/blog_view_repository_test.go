@@ -0,0 +1,96 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_BlogRepository_Views(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	sut := repository.NewBlogRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM blog_view_daily"); err != nil {
+		t.Fatalf("failed to delete blog_view_daily: %v", err)
+	}
+
+	var blogIds []models.BlogId
+	for _, title := range []string{"title1", "title2"} {
+		id, err := sut.Add(ctx, tx, &models.Blog{
+			AuthorId:    1,
+			Title:       title,
+			Content:     "content",
+			Description: "description",
+			IsPublic:    true,
+		})
+		if err != nil {
+			t.Fatalf("failed to add blog: %v", err)
+		}
+		blogIds = append(blogIds, id)
+	}
+
+	views := []*models.BlogViewDaily{
+		{BlogId: blogIds[0], Date: "2024-01-01", Views: 2},
+		{BlogId: blogIds[1], Date: "2024-01-01", Views: 1},
+		{BlogId: blogIds[0], Date: "2024-01-02", Views: 1},
+		// 存在しないブログは無視される
+		{BlogId: blogIds[1] + 100, Date: "2024-01-02", Views: 1},
+	}
+	if err := sut.AddViews(ctx, tx, views); err != nil {
+		t.Fatalf("failed to add views: %v", err)
+	}
+	// 同じ日付の閲覧数は加算される
+	if err := sut.AddViews(ctx, tx, views[:1]); err != nil {
+		t.Fatalf("failed to add views: %v", err)
+	}
+
+	got, err := sut.ListDailyViews(ctx, tx, &blogIds[0], "2024-01-01", "2024-01-31")
+	if err != nil {
+		t.Fatalf("failed to list daily views: %v", err)
+	}
+	want := []*models.ViewCount{
+		{Date: "2024-01-01", Views: 4},
+		{Date: "2024-01-02", Views: 1},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	got, err = sut.ListDailyViews(ctx, tx, nil, "2024-01-01", "2024-01-01")
+	if err != nil {
+		t.Fatalf("failed to list daily views: %v", err)
+	}
+	want = []*models.ViewCount{
+		{Date: "2024-01-01", Views: 5},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	top, err := sut.ListTopViewedBlogs(ctx, tx, "2024-01-01", "2024-01-31", 10)
+	if err != nil {
+		t.Fatalf("failed to list top viewed blogs: %v", err)
+	}
+	wantTop := []*models.BlogViewCount{
+		{BlogId: blogIds[0], Title: "title1", Views: 5},
+		{BlogId: blogIds[1], Title: "title2", Views: 1},
+	}
+	if diff := cmp.Diff(wantTop, top); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
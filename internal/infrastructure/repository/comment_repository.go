@@ -109,6 +109,10 @@ func (r *CommentRepository) GetByBlogId(
 		From("comments").
 		Where(goqu.Ex{"blog_id": blogId}).
 		Where(goqu.Ex{"is_deleted": !excludeDeleted}).
+		// ゴミ箱にあるブログのコメントは取得しない
+		Where(goqu.I("blog_id").In(
+			goqu.From("blogs").Select("id").Where(goqu.Ex{"deleted_at": nil}),
+		)).
 		Order(goqu.I("created").Asc())
 	query, params, err := builder.ToSQL()
 	if err != nil {
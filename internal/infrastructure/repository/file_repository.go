@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/shoet/blog/internal/config"
 	"github.com/shoet/blog/internal/infrastructure/adapter"
@@ -49,3 +50,21 @@ func (r *FileRepository) GetUploadURL(ctx context.Context, file *models.File) (u
 	}
 	return r.S3Adapter.GetPresignedURL(bucketName, key, file.FileName)
 }
+
+// PutFile は、ファイルをストレージに直接アップロードする
+// CLIからの取り込みなど、クライアントを経由しない場合に使用する
+func (r *FileRepository) PutFile(ctx context.Context, file *models.File, body io.Reader, contentType string) error {
+	bucketName, err := file.GetBucketName(r.Config)
+	if err != nil {
+		return fmt.Errorf("failed to get bucket name")
+	}
+	key, err := file.GetBucketKey(r.Config)
+	if err != nil {
+		return fmt.Errorf("failed to get file key")
+	}
+	fileKey := fmt.Sprintf("%s/%s", key, file.FileName)
+	if err := r.S3Adapter.PutObject(ctx, bucketName, fileKey, body, contentType); err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+	return nil
+}
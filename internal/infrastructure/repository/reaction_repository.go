@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type ReactionRepository struct {
+	Clocker clocker.Clocker
+}
+
+func NewReactionRepository(clocker clocker.Clocker) *ReactionRepository {
+	return &ReactionRepository{
+		Clocker: clocker,
+	}
+}
+
+// reactionTable は、リアクションの対象ごとのテーブル名と対象のIDのカラム名を返す
+func reactionTable(target models.ReactionTarget) (string, string, error) {
+	switch target {
+	case models.ReactionTargetBlog:
+		return "blog_reactions", "blog_id", nil
+	case models.ReactionTargetComment:
+		return "comment_reactions", "comment_id", nil
+	}
+	return "", "", fmt.Errorf("unknown reaction target: %s", target)
+}
+
+// reactorCondition は、読者のリアクションを特定する条件を返す
+// ログインユーザーのリアクションはclient_idに関わらずuser_idで特定する
+func reactorCondition(reactor *models.Reactor) exp.Expression {
+	if reactor.UserId != nil {
+		return goqu.Ex{"user_id": *reactor.UserId}
+	}
+	return goqu.Ex{"user_id": nil, "client_id": reactor.ClientId}
+}
+
+// Add は、リアクションを追加する
+// 同じ読者が同じ絵文字で既にリアクションしている場合は追加せず、falseを返す
+func (r *ReactionRepository) Add(
+	ctx context.Context,
+	tx infrastructure.TX,
+	target models.ReactionTarget,
+	targetId int64,
+	emoji string,
+	reactor *models.Reactor,
+) (bool, error) {
+	table, column, err := reactionTable(target)
+	if err != nil {
+		return false, err
+	}
+	record := goqu.Record{
+		column:    targetId,
+		"emoji":   emoji,
+		"created": r.Clocker.Now().Unix(),
+	}
+	if reactor.UserId != nil {
+		record["user_id"] = *reactor.UserId
+	} else {
+		record["client_id"] = reactor.ClientId
+	}
+	// 同時にリアクションした場合も一意制約違反とならないよう、重複は無視する
+	sql, params, err := goqu.Insert(table).Rows(record).OnConflict(goqu.DoNothing()).ToSQL()
+	if err != nil {
+		return false, fmt.Errorf("failed to build sql: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, sql, params...)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert %s: %w", table, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// Delete は、読者のリアクションを削除する
+// 削除した場合はtrueを返す
+func (r *ReactionRepository) Delete(
+	ctx context.Context,
+	tx infrastructure.TX,
+	target models.ReactionTarget,
+	targetId int64,
+	emoji string,
+	reactor *models.Reactor,
+) (bool, error) {
+	table, column, err := reactionTable(target)
+	if err != nil {
+		return false, err
+	}
+	sql, params, err := goqu.
+		Delete(table).
+		Where(
+			goqu.Ex{column: targetId, "emoji": emoji},
+			reactorCondition(reactor),
+		).
+		ToSQL()
+	if err != nil {
+		return false, fmt.Errorf("failed to build sql: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, sql, params...)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete %s: %w", table, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// CountByTargets は、対象ごとに絵文字ごとのリアクション数を取得する
+// リアクション数は多い順、同数の場合は絵文字の昇順に並べる
+func (r *ReactionRepository) CountByTargets(
+	ctx context.Context,
+	tx infrastructure.TX,
+	target models.ReactionTarget,
+	targetIds []int64,
+) (map[int64][]*models.ReactionCount, error) {
+	counts := map[int64][]*models.ReactionCount{}
+	if len(targetIds) == 0 {
+		return counts, nil
+	}
+	table, column, err := reactionTable(target)
+	if err != nil {
+		return nil, err
+	}
+	sql, params, err := goqu.
+		Select(
+			goqu.I(column).As("target_id"),
+			"emoji",
+			goqu.COUNT("*").As("count"),
+		).
+		From(table).
+		Where(goqu.Ex{column: targetIds}).
+		GroupBy(goqu.I(column), goqu.I("emoji")).
+		Order(goqu.I(column).Asc(), goqu.L("count").Desc(), goqu.I("emoji").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var rows []*struct {
+		TargetId int64 `db:"target_id"`
+		models.ReactionCount
+	}
+	if err := tx.SelectContext(ctx, &rows, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select %s: %w", table, err)
+	}
+	for _, row := range rows {
+		count := row.ReactionCount
+		counts[row.TargetId] = append(counts[row.TargetId], &count)
+	}
+	return counts, nil
+}
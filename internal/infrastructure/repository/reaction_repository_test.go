@@ -0,0 +1,117 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_ReactionRepository(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	blogRepo := repository.NewBlogRepository(clocker)
+	commentRepo := repository.NewCommentRepository(clocker)
+	sut := repository.NewReactionRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	blogId, err := blogRepo.Add(ctx, tx, &models.Blog{
+		AuthorId:    1,
+		Title:       "title",
+		Content:     "content",
+		Description: "description",
+		IsPublic:    true,
+	})
+	if err != nil {
+		t.Fatalf("failed to add blog: %v", err)
+	}
+	clientId := "client"
+	commentId, err := commentRepo.CreateComment(ctx, tx, blogId, nil, &clientId, nil, "comment")
+	if err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+
+	userId := models.UserId(1)
+	user := &models.Reactor{UserId: &userId}
+	anonymous := &models.Reactor{ClientId: &clientId}
+	reactions := []struct {
+		target   models.ReactionTarget
+		targetId int64
+		emoji    string
+		reactor  *models.Reactor
+	}{
+		{models.ReactionTargetBlog, int64(blogId), "👍", user},
+		{models.ReactionTargetBlog, int64(blogId), "👍", anonymous},
+		{models.ReactionTargetBlog, int64(blogId), "🎉", anonymous},
+		{models.ReactionTargetComment, int64(commentId), "👍", user},
+	}
+	for _, r := range reactions {
+		added, err := sut.Add(ctx, tx, r.target, r.targetId, r.emoji, r.reactor)
+		if err != nil {
+			t.Fatalf("failed to add reaction: %v", err)
+		}
+		if !added {
+			t.Errorf("want added: %+v", r)
+		}
+	}
+
+	// 同じ読者は同じ絵文字で2回リアクションできない
+	added, err := sut.Add(ctx, tx, models.ReactionTargetBlog, int64(blogId), "👍", user)
+	if err != nil {
+		t.Fatalf("failed to add duplicate reaction: %v", err)
+	}
+	if added {
+		t.Errorf("want not added on duplicate reaction")
+	}
+
+	counts, err := sut.CountByTargets(ctx, tx, models.ReactionTargetBlog, []int64{int64(blogId)})
+	if err != nil {
+		t.Fatalf("failed to count reactions: %v", err)
+	}
+	want := []*models.ReactionCount{
+		{Emoji: "👍", Count: 2},
+		{Emoji: "🎉", Count: 1},
+	}
+	if diff := cmp.Diff(want, counts[int64(blogId)]); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	// 匿名の読者のリアクションのみ削除される
+	deleted, err := sut.Delete(ctx, tx, models.ReactionTargetBlog, int64(blogId), "👍", anonymous)
+	if err != nil {
+		t.Fatalf("failed to delete reaction: %v", err)
+	}
+	if !deleted {
+		t.Errorf("want deleted")
+	}
+	deleted, err = sut.Delete(ctx, tx, models.ReactionTargetBlog, int64(blogId), "👍", anonymous)
+	if err != nil {
+		t.Fatalf("failed to delete reaction: %v", err)
+	}
+	if deleted {
+		t.Errorf("want not deleted")
+	}
+
+	counts, err = sut.CountByTargets(ctx, tx, models.ReactionTargetComment, []int64{int64(commentId)})
+	if err != nil {
+		t.Fatalf("failed to count reactions: %v", err)
+	}
+	want = []*models.ReactionCount{
+		{Emoji: "👍", Count: 1},
+	}
+	if diff := cmp.Diff(want, counts[int64(commentId)]); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
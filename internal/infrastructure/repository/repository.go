@@ -3,3 +3,7 @@ package repository
 import "fmt"
 
 var ErrResourceNotFound = fmt.Errorf("resource not found")
+
+// ErrVersionMismatch は、更新時に指定したバージョンが現在のバージョンと一致しない場合のエラー
+// 取得後に他のリクエストで更新されたことを表す
+var ErrVersionMismatch = fmt.Errorf("version does not match")
@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type SeriesRepository struct {
+	Clocker clocker.Clocker
+}
+
+func NewSeriesRepository(clocker clocker.Clocker) *SeriesRepository {
+	return &SeriesRepository{
+		Clocker: clocker,
+	}
+}
+
+var seriesColumns = []any{"id", "author_id", "title", "description", "created", "modified"}
+
+// Add は、シリーズを作成する
+// 含まれるブログはReplaceBlogsで設定する
+func (r *SeriesRepository) Add(
+	ctx context.Context, tx infrastructure.TX, series *models.Series,
+) (models.SeriesId, error) {
+	now := r.Clocker.Now().Unix()
+	sql, params, err := goqu.
+		Insert("series").
+		Rows(goqu.Record{
+			"author_id":   series.AuthorId,
+			"title":       series.Title,
+			"description": series.Description,
+			"created":     now,
+			"modified":    now,
+		}).
+		Returning("id").
+		ToSQL()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build sql: %w", err)
+	}
+	row := tx.QueryRowxContext(ctx, sql, params...)
+	if row.Err() != nil {
+		return 0, fmt.Errorf("failed to insert series: %w", row.Err())
+	}
+	var id models.SeriesId
+	if err := row.Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	return id, nil
+}
+
+// Put は、シリーズのタイトルと説明を更新する
+// 更新対象が存在しない場合はErrResourceNotFoundを返す
+func (r *SeriesRepository) Put(
+	ctx context.Context, tx infrastructure.TX, series *models.Series,
+) error {
+	sql, params, err := goqu.
+		Update("series").
+		Set(goqu.Record{
+			"title":       series.Title,
+			"description": series.Description,
+		}).
+		Where(goqu.Ex{"id": series.Id}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, sql, params...)
+	if err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if affected == 0 {
+		return ErrResourceNotFound
+	}
+	return nil
+}
+
+// Delete は、シリーズを削除する
+// シリーズとブログの関連は外部キーの制約により削除される
+func (r *SeriesRepository) Delete(
+	ctx context.Context, tx infrastructure.TX, id models.SeriesId,
+) error {
+	sql, params, err := goqu.
+		Delete("series").
+		Where(goqu.Ex{"id": id}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to delete series: %w", err)
+	}
+	return nil
+}
+
+// Get は、シリーズを含まれるブログとともに取得する
+// 存在しない場合はnilを返す
+func (r *SeriesRepository) Get(
+	ctx context.Context, tx infrastructure.TX, id models.SeriesId,
+) (*models.Series, error) {
+	sql, params, err := goqu.
+		Select(seriesColumns...).
+		From("series").
+		Where(goqu.Ex{"id": id}).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var series []*models.Series
+	if err := tx.SelectContext(ctx, &series, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select series: %w", err)
+	}
+	if len(series) == 0 {
+		return nil, nil
+	}
+	entries, err := r.ListEntries(ctx, tx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series entries: %w", err)
+	}
+	series[0].Entries = entries
+	return series[0], nil
+}
+
+// GetByBlogId は、ブログが所属するシリーズを取得する
+// 所属していない場合はnilを返す
+func (r *SeriesRepository) GetByBlogId(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId,
+) (*models.Series, error) {
+	sql, params, err := goqu.
+		Select("series_id").
+		From("series_blogs").
+		Where(goqu.Ex{"blog_id": blogId}).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	var ids []models.SeriesId
+	if err := tx.SelectContext(ctx, &ids, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select series_blogs: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.Get(ctx, tx, ids[0])
+}
+
+// List は、シリーズを新しい順に取得する
+// 一覧では含まれるブログは取得しない
+func (r *SeriesRepository) List(
+	ctx context.Context, tx infrastructure.TX,
+) ([]*models.Series, error) {
+	sql, params, err := goqu.
+		Select(seriesColumns...).
+		From("series").
+		Order(goqu.I("id").Desc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	series := []*models.Series{}
+	if err := tx.SelectContext(ctx, &series, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select series: %w", err)
+	}
+	return series, nil
+}
+
+// ListEntries は、シリーズに含まれるブログを並び順に取得する
+// ゴミ箱にあるブログは所属したまま除き、並び順はゴミ箱にないブログで1から数え直す
+func (r *SeriesRepository) ListEntries(
+	ctx context.Context, tx infrastructure.TX, id models.SeriesId,
+) ([]*models.SeriesEntry, error) {
+	sql, params, err := goqu.
+		Select(
+			"series_blogs.blog_id", "series_blogs.position",
+			"blogs.title", "blogs.slug", "blogs.is_public", "blogs.created",
+		).
+		From("series_blogs").
+		Join(
+			goqu.T("blogs"),
+			goqu.On(goqu.Ex{"series_blogs.blog_id": goqu.I("blogs.id")}),
+		).
+		Where(goqu.Ex{"series_blogs.series_id": id, "blogs.deleted_at": nil}).
+		Order(goqu.I("series_blogs.position").Asc()).
+		ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sql: %w", err)
+	}
+	entries := []*models.SeriesEntry{}
+	if err := tx.SelectContext(ctx, &entries, sql, params...); err != nil {
+		return nil, fmt.Errorf("failed to select series_blogs: %w", err)
+	}
+	for i, entry := range entries {
+		entry.Position = i + 1
+	}
+	return entries, nil
+}
+
+// ReplaceBlogs は、シリーズに含まれるブログを指定された順序で置き換える
+// ゴミ箱にあるブログは一覧に表示されず指定できないため、シリーズに残したまま指定されたブログの後ろに並べる
+func (r *SeriesRepository) ReplaceBlogs(
+	ctx context.Context, tx infrastructure.TX, id models.SeriesId, blogIds []models.BlogId,
+) error {
+	sql, params, err := goqu.
+		Select("series_blogs.blog_id").
+		From("series_blogs").
+		Join(
+			goqu.T("blogs"),
+			goqu.On(goqu.Ex{"series_blogs.blog_id": goqu.I("blogs.id")}),
+		).
+		Where(
+			goqu.Ex{"series_blogs.series_id": id},
+			goqu.I("blogs.deleted_at").IsNotNull(),
+		).
+		Order(goqu.I("series_blogs.position").Asc()).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	var trashedIds []models.BlogId
+	if err := tx.SelectContext(ctx, &trashedIds, sql, params...); err != nil {
+		return fmt.Errorf("failed to select series_blogs: %w", err)
+	}
+	blogIds = slices.Clone(blogIds)
+	for _, trashedId := range trashedIds {
+		if !slices.Contains(blogIds, trashedId) {
+			blogIds = append(blogIds, trashedId)
+		}
+	}
+
+	sql, params, err = goqu.
+		Delete("series_blogs").
+		Where(goqu.Ex{"series_id": id}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to delete series_blogs: %w", err)
+	}
+	if len(blogIds) == 0 {
+		return r.touch(ctx, tx, id)
+	}
+	rows := make([]any, 0, len(blogIds))
+	for i, blogId := range blogIds {
+		rows = append(rows, goqu.Record{
+			"series_id": id,
+			"blog_id":   blogId,
+			"position":  i + 1,
+		})
+	}
+	sql, params, err = goqu.Insert("series_blogs").Rows(rows...).ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to insert series_blogs: %w", err)
+	}
+	return r.touch(ctx, tx, id)
+}
+
+// RemoveBlog は、ブログをシリーズから外し、後続のブログの並び順を詰める
+// ブログがシリーズに所属していない場合は何もしない
+func (r *SeriesRepository) RemoveBlog(
+	ctx context.Context, tx infrastructure.TX, blogId models.BlogId,
+) error {
+	sql, params, err := goqu.
+		Delete("series_blogs").
+		Where(goqu.Ex{"blog_id": blogId}).
+		Returning("series_id", "position").
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	var removed []struct {
+		SeriesId models.SeriesId `db:"series_id"`
+		Position int             `db:"position"`
+	}
+	if err := tx.SelectContext(ctx, &removed, sql, params...); err != nil {
+		return fmt.Errorf("failed to delete series_blogs: %w", err)
+	}
+	for _, rm := range removed {
+		sql, params, err := goqu.
+			Update("series_blogs").
+			Set(goqu.Record{"position": goqu.L("position - 1")}).
+			Where(
+				goqu.Ex{"series_id": rm.SeriesId},
+				goqu.Ex{"position": goqu.Op{"gt": rm.Position}},
+			).
+			ToSQL()
+		if err != nil {
+			return fmt.Errorf("failed to build sql: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+			return fmt.Errorf("failed to update series_blogs: %w", err)
+		}
+		if err := r.touch(ctx, tx, rm.SeriesId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// touch は、シリーズの更新日時を更新する
+func (r *SeriesRepository) touch(
+	ctx context.Context, tx infrastructure.TX, id models.SeriesId,
+) error {
+	sql, params, err := goqu.
+		Update("series").
+		Set(goqu.Record{"modified": r.Clocker.Now().Unix()}).
+		Where(goqu.Ex{"id": id}).
+		ToSQL()
+	if err != nil {
+		return fmt.Errorf("failed to build sql: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, sql, params...); err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/testutil"
+)
+
+func Test_SeriesRepository_RemoveBlog(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	blogRepo := repository.NewBlogRepository(clocker)
+	sut := repository.NewSeriesRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	var blogIds []models.BlogId
+	for _, title := range []string{"part1", "part2", "part3"} {
+		id, err := blogRepo.Add(ctx, tx, &models.Blog{
+			AuthorId:    1,
+			Title:       title,
+			Content:     "content",
+			Description: "description",
+		})
+		if err != nil {
+			t.Fatalf("failed to add blog: %v", err)
+		}
+		blogIds = append(blogIds, id)
+	}
+
+	seriesId, err := sut.Add(ctx, tx, &models.Series{AuthorId: 1, Title: "series"})
+	if err != nil {
+		t.Fatalf("failed to add series: %v", err)
+	}
+	if err := sut.ReplaceBlogs(ctx, tx, seriesId, blogIds); err != nil {
+		t.Fatalf("failed to replace blogs: %v", err)
+	}
+
+	if err := sut.RemoveBlog(ctx, tx, blogIds[1]); err != nil {
+		t.Fatalf("failed to remove blog: %v", err)
+	}
+
+	got, err := sut.Get(ctx, tx, seriesId)
+	if err != nil {
+		t.Fatalf("failed to get series: %v", err)
+	}
+	if got == nil {
+		t.Fatalf("series not found")
+	}
+	want := []struct {
+		blogId   models.BlogId
+		position int
+	}{
+		{blogIds[0], 1},
+		{blogIds[2], 2},
+	}
+	if len(got.Entries) != len(want) {
+		t.Fatalf("want %d entries, got %d", len(want), len(got.Entries))
+	}
+	for i, w := range want {
+		if got.Entries[i].BlogId != w.blogId || got.Entries[i].Position != w.position {
+			t.Errorf("entry %d: want %+v, got %+v", i, w, got.Entries[i])
+		}
+	}
+
+	removed, err := sut.GetByBlogId(ctx, tx, blogIds[1])
+	if err != nil {
+		t.Fatalf("failed to get series by blog id: %v", err)
+	}
+	if removed != nil {
+		t.Errorf("want nil for removed blog, got %+v", removed)
+	}
+}
+
+func Test_SeriesRepository_TrashedBlog(t *testing.T) {
+	clocker := &clocker.FiexedClocker{}
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	testutil.RepositoryTestPrepare(t, ctx, db)
+
+	blogRepo := repository.NewBlogRepository(clocker)
+	sut := repository.NewSeriesRepository(clocker)
+
+	tx := db.MustBegin()
+	defer tx.Rollback()
+
+	var blogIds []models.BlogId
+	for _, title := range []string{"part1", "part2", "part3"} {
+		id, err := blogRepo.Add(ctx, tx, &models.Blog{AuthorId: 1, Title: title, IsPublic: true})
+		if err != nil {
+			t.Fatalf("failed to add blog: %v", err)
+		}
+		blogIds = append(blogIds, id)
+	}
+	seriesId, err := sut.Add(ctx, tx, &models.Series{AuthorId: 1, Title: "series"})
+	if err != nil {
+		t.Fatalf("failed to add series: %v", err)
+	}
+	if err := sut.ReplaceBlogs(ctx, tx, seriesId, blogIds); err != nil {
+		t.Fatalf("failed to replace blogs: %v", err)
+	}
+
+	assertEntries := func(t *testing.T, want []models.BlogId) {
+		t.Helper()
+		entries, err := sut.ListEntries(ctx, tx, seriesId)
+		if err != nil {
+			t.Fatalf("failed to list entries: %v", err)
+		}
+		if len(entries) != len(want) {
+			t.Fatalf("want %d entries, got %d", len(want), len(entries))
+		}
+		for i, id := range want {
+			if entries[i].BlogId != id || entries[i].Position != i+1 {
+				t.Errorf("entry %d: want blog %d at %d, got %+v", i, id, i+1, entries[i])
+			}
+		}
+	}
+
+	if err := blogRepo.Trash(ctx, tx, blogIds[1], 1, nil); err != nil {
+		t.Fatalf("failed to trash blog: %v", err)
+	}
+
+	t.Run("ゴミ箱にあるブログはシリーズに含まれない", func(t *testing.T) {
+		assertEntries(t, []models.BlogId{blogIds[0], blogIds[2]})
+	})
+
+	t.Run("並び替えてもゴミ箱にあるブログはシリーズに残る", func(t *testing.T) {
+		if err := sut.ReplaceBlogs(ctx, tx, seriesId, []models.BlogId{blogIds[2], blogIds[0]}); err != nil {
+			t.Fatalf("failed to replace blogs: %v", err)
+		}
+		assertEntries(t, []models.BlogId{blogIds[2], blogIds[0]})
+	})
+
+	t.Run("ゴミ箱から元に戻すとシリーズに戻る", func(t *testing.T) {
+		if err := blogRepo.Restore(ctx, tx, blogIds[1]); err != nil {
+			t.Fatalf("failed to restore blog: %v", err)
+		}
+		assertEntries(t, []models.BlogId{blogIds[2], blogIds[0], blogIds[1]})
+	})
+}
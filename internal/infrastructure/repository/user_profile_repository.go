@@ -55,20 +55,67 @@ func (r *UserProfileRepository) Get(
 		return nil, fmt.Errorf("failed to scan struct: %w", err)
 	}
 
-	if userProfile.AvatarImageFileName != nil {
-		file, err := models.NewFile("avatar_image", *userProfile.AvatarImageFileName)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get file: %w", err)
-		}
-		avatarImageFileURL, err := file.GetFileURL(r.config)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get file url: %w", err)
-		}
-		userProfile.AvatarImageFileURL = &avatarImageFileURL
+	if err := r.setAvatarImageFileURL(&userProfile); err != nil {
+		return nil, err
 	}
 	return &userProfile, nil
 }
 
+/*
+ListByUserIds は、userIdsに一致するユーザープロフィールを1回のクエリでまとめて取得する。
+
+プロフィールが存在しないユーザーは、結果に含まれない。
+*/
+func (r *UserProfileRepository) ListByUserIds(
+	ctx context.Context,
+	tx infrastructure.TX,
+	userIds []models.UserId,
+) (map[models.UserId]*models.UserProfile, error) {
+	result := make(map[models.UserId]*models.UserProfile, len(userIds))
+	if len(userIds) == 0 {
+		return result, nil
+	}
+
+	builder := goqu.
+		Select("id", "user_id", "nickname", "avatar_image_file_name", "bio", "created", "modified").
+		From("user_profile").
+		Where(goqu.Ex{"user_id": userIds})
+
+	query, params, err := builder.ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var userProfiles []*models.UserProfile
+	if err := tx.SelectContext(ctx, &userProfiles, query, params...); err != nil {
+		return nil, fmt.Errorf("failed to select user_profile: %w", err)
+	}
+	for _, userProfile := range userProfiles {
+		if err := r.setAvatarImageFileURL(userProfile); err != nil {
+			return nil, err
+		}
+		result[userProfile.UserId] = userProfile
+	}
+	return result, nil
+}
+
+// setAvatarImageFileURL は、アバター画像のファイル名から公開URLを設定する
+func (r *UserProfileRepository) setAvatarImageFileURL(userProfile *models.UserProfile) error {
+	if userProfile.AvatarImageFileName == nil {
+		return nil
+	}
+	file, err := models.NewFile("avatar_image", *userProfile.AvatarImageFileName)
+	if err != nil {
+		return fmt.Errorf("failed to get file: %w", err)
+	}
+	avatarImageFileURL, err := file.GetFileURL(r.config)
+	if err != nil {
+		return fmt.Errorf("failed to get file url: %w", err)
+	}
+	userProfile.AvatarImageFileURL = &avatarImageFileURL
+	return nil
+}
+
 func (r *UserProfileRepository) Create(
 	ctx context.Context,
 	tx infrastructure.TX,
@@ -3,6 +3,7 @@ package repository_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/doug-martin/goqu/v9"
@@ -424,3 +425,58 @@ func Test_UserProfileRepository_Update(t *testing.T) {
 		})
 	}
 }
+
+func Test_UserProfileRepository_ListByUserIds(t *testing.T) {
+	ctx := context.Background()
+	db, err := testutil.NewDBPostgreSQLForTest(t, ctx)
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+
+	cfg := &config.Config{}
+	sut := repository.NewUserProfileRepository(cfg)
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	for _, id := range []models.UserId{1, 2, 3} {
+		query, params, err := goqu.
+			Insert("users").
+			Rows(goqu.Record{
+				"id":       id,
+				"name":     "test",
+				"email":    fmt.Sprintf("test%d@example.com", id),
+				"password": "test",
+			}).ToSQL()
+		if err != nil {
+			t.Fatalf("failed to build query: %v", err)
+		}
+		if _, err := tx.ExecContext(ctx, query, params...); err != nil {
+			t.Fatalf("failed to insert user: %v", err)
+		}
+	}
+	// ユーザー3はプロフィールを作成しない
+	for _, id := range []models.UserId{1, 2} {
+		if _, err := sut.Create(ctx, tx, id, fmt.Sprintf("nickname%d", id), nil, nil); err != nil {
+			t.Fatalf("failed to create user profile: %v", err)
+		}
+	}
+
+	got, err := sut.ListByUserIds(ctx, tx, []models.UserId{1, 3})
+	if err != nil {
+		t.Fatalf("failed to list user profiles: %v", err)
+	}
+	want := map[models.UserId]*models.UserProfile{
+		1: {UserId: 1, Nickname: "nickname1"},
+	}
+	if diff := cmp.Diff(
+		want,
+		got,
+		cmpopts.IgnoreFields(models.UserProfile{}, "UserProfileId", "Created", "Modified"),
+	); diff != "" {
+		t.Errorf("userProfiles mismatch (-want +got):\n%s", diff)
+	}
+}
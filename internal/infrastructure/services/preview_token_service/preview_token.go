@@ -0,0 +1,191 @@
+package preview_token_service
+
+import (
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+const previewTokenSubject = "preview"
+
+var ErrInvalidPreviewToken = errors.New("preview token is invalid")
+var ErrPreviewTokenNotFound = errors.New("preview token is not found")
+
+type KVSer interface {
+	SaveHashField(ctx context.Context, key string, field string, value string, expiration time.Duration) error
+	LoadHash(ctx context.Context, key string) (map[string]string, error)
+	LoadHashField(ctx context.Context, key string, field string) (*string, error)
+	DeleteHashField(ctx context.Context, key string, field string) (bool, error)
+}
+
+// PreviewTokenService は、非公開ブログのプレビュー用トークンを発行・検証する
+// 発行済みトークンはブログごとのKVSのハッシュにトークンIDをフィールドとして保存し、KVSから削除されたトークンは失効とみなす
+// トークンごとにフィールドを追加・削除するため、同時に発行・失効しても他のトークンを上書きしない
+type PreviewTokenService struct {
+	kvs       KVSer
+	clocker   clocker.Clocker
+	secretKey []byte
+}
+
+// NewPreviewTokenService は、プレビュー用の署名鍵でサービスを生成する
+// previewSecretが空の場合は、ログイン用のJWTと区別するためjwtSecretから派生した鍵を使う
+func NewPreviewTokenService(
+	kvs KVSer,
+	clocker clocker.Clocker,
+	previewSecret string,
+	jwtSecret string,
+) *PreviewTokenService {
+	secretKey := []byte(previewSecret)
+	if previewSecret == "" {
+		mac := hmac.New(sha256.New, []byte(jwtSecret))
+		mac.Write([]byte(previewTokenSubject))
+		secretKey = mac.Sum(nil)
+	}
+	return &PreviewTokenService{
+		kvs:       kvs,
+		clocker:   clocker,
+		secretKey: secretKey,
+	}
+}
+
+// Generate は、ブログのプレビュー用トークンを発行する
+func (s *PreviewTokenService) Generate(
+	ctx context.Context, blogId models.BlogId, expiresIn time.Duration,
+) (*models.PreviewToken, error) {
+	now := s.clocker.Now()
+	expiresAt := now.Add(expiresIn)
+	id := uuid.New().String()
+	claims := jwt.RegisteredClaims{
+		ID:        id,
+		Subject:   previewTokenSubject,
+		Audience:  jwt.ClaimStrings{strconv.Itoa(int(blogId))},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	ss, err := token.SignedString(s.secretKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	previewToken := &models.PreviewToken{
+		Id:        models.PreviewTokenId(id),
+		BlogId:    blogId,
+		ExpiresAt: uint(expiresAt.Unix()),
+		Created:   uint(now.Unix()),
+	}
+	b, err := json.Marshal(previewToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal preview token: %w", err)
+	}
+	if err := s.kvs.SaveHashField(ctx, previewTokensKey(blogId), id, string(b), expiresIn); err != nil {
+		return nil, fmt.Errorf("failed to save preview token: %w", err)
+	}
+
+	result := *previewToken
+	result.Token = ss
+	return &result, nil
+}
+
+// Verify は、トークンを検証し、プレビューを許可するブログのIDを返す
+func (s *PreviewTokenService) Verify(ctx context.Context, token string) (models.BlogId, error) {
+	parsed, err := jwt.ParseWithClaims(
+		token,
+		&jwt.RegisteredClaims{},
+		func(token *jwt.Token) (interface{}, error) {
+			return s.secretKey, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithSubject(previewTokenSubject),
+		jwt.WithTimeFunc(s.clocker.Now),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidPreviewToken, err)
+	}
+	claims := parsed.Claims.(*jwt.RegisteredClaims)
+	if len(claims.Audience) != 1 {
+		return 0, ErrInvalidPreviewToken
+	}
+	blogIdInt, err := strconv.Atoi(claims.Audience[0])
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidPreviewToken, err)
+	}
+	blogId := models.BlogId(blogIdInt)
+
+	// 失効していないかKVSで確認する
+	// 有効期限はJWTの検証で確認済みのため、フィールドの有無のみ確認する
+	v, err := s.kvs.LoadHashField(ctx, previewTokensKey(blogId), claims.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load preview token: %w", err)
+	}
+	if v == nil {
+		return 0, ErrInvalidPreviewToken
+	}
+	return blogId, nil
+}
+
+// List は、ブログの有効なプレビュー用トークンを発行順に返す
+func (s *PreviewTokenService) List(ctx context.Context, blogId models.BlogId) ([]*models.PreviewToken, error) {
+	tokens, err := s.load(ctx, blogId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load preview tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// Revoke は、プレビュー用トークンを失効させる
+func (s *PreviewTokenService) Revoke(ctx context.Context, blogId models.BlogId, id models.PreviewTokenId) error {
+	deleted, err := s.kvs.DeleteHashField(ctx, previewTokensKey(blogId), string(id))
+	if err != nil {
+		return fmt.Errorf("failed to delete preview token: %w", err)
+	}
+	if !deleted {
+		return ErrPreviewTokenNotFound
+	}
+	return nil
+}
+
+func previewTokensKey(blogId models.BlogId) string {
+	return fmt.Sprintf(config.KVS_PREVIEW_TOKENS, blogId)
+}
+
+// load は、KVSからブログのトークン一覧を取得し、有効期限切れのものを除いて発行順に返す
+// 有効期限切れのフィールドは、キーの有効期限が切れた際にまとめて削除される
+func (s *PreviewTokenService) load(ctx context.Context, blogId models.BlogId) ([]*models.PreviewToken, error) {
+	values, err := s.kvs.LoadHash(ctx, previewTokensKey(blogId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load: %w", err)
+	}
+	now := uint(s.clocker.Now().Unix())
+	tokens := make([]*models.PreviewToken, 0, len(values))
+	for _, v := range values {
+		var token models.PreviewToken
+		if err := json.Unmarshal([]byte(v), &token); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal: %w", err)
+		}
+		if token.ExpiresAt <= now {
+			continue
+		}
+		tokens = append(tokens, &token)
+	}
+	slices.SortFunc(tokens, func(a, b *models.PreviewToken) int {
+		if c := cmp.Compare(a.Created, b.Created); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Id, b.Id)
+	})
+	return tokens, nil
+}
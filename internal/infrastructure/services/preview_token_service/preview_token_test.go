@@ -0,0 +1,155 @@
+package preview_token_service_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shoet/blog/internal/infrastructure/services/preview_token_service"
+)
+
+type kvsFake struct {
+	mu     sync.Mutex
+	hashes map[string]map[string]string
+}
+
+func (k *kvsFake) SaveHashField(ctx context.Context, key string, field string, value string, expiration time.Duration) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.hashes[key] == nil {
+		k.hashes[key] = map[string]string{}
+	}
+	k.hashes[key][field] = value
+	return nil
+}
+
+func (k *kvsFake) LoadHash(ctx context.Context, key string) (map[string]string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return maps.Clone(k.hashes[key]), nil
+}
+
+func (k *kvsFake) LoadHashField(ctx context.Context, key string, field string) (*string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	v, ok := k.hashes[key][field]
+	if !ok {
+		return nil, nil
+	}
+	return &v, nil
+}
+
+func (k *kvsFake) DeleteHashField(ctx context.Context, key string, field string) (bool, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, ok := k.hashes[key][field]; !ok {
+		return false, nil
+	}
+	delete(k.hashes[key], field)
+	return true, nil
+}
+
+type clockerFake struct {
+	now time.Time
+}
+
+func (c *clockerFake) Now() time.Time {
+	return c.now
+}
+
+func Test_PreviewTokenService(t *testing.T) {
+	ctx := context.Background()
+	kvs := &kvsFake{hashes: map[string]map[string]string{}}
+	clocker := &clockerFake{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	sut := preview_token_service.NewPreviewTokenService(kvs, clocker, "", "12345678")
+
+	generated, err := sut.Generate(ctx, 1, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	t.Run("発行したトークンでブログIDを取得できる", func(t *testing.T) {
+		blogId, err := sut.Verify(ctx, generated.Token)
+		if err != nil {
+			t.Fatalf("failed to verify: %v", err)
+		}
+		if blogId != 1 {
+			t.Errorf("want blogId 1, got %d", blogId)
+		}
+	})
+
+	t.Run("別の鍵で署名したトークンは無効", func(t *testing.T) {
+		other := preview_token_service.NewPreviewTokenService(kvs, clocker, "other", "12345678")
+		if _, err := other.Verify(ctx, generated.Token); !errors.Is(err, preview_token_service.ErrInvalidPreviewToken) {
+			t.Errorf("want ErrInvalidPreviewToken, got %v", err)
+		}
+	})
+
+	t.Run("一覧に署名済みトークンは含まれない", func(t *testing.T) {
+		tokens, err := sut.List(ctx, 1)
+		if err != nil {
+			t.Fatalf("failed to list: %v", err)
+		}
+		if len(tokens) != 1 || tokens[0].Id != generated.Id || tokens[0].Token != "" {
+			t.Errorf("unexpected tokens: %+v", tokens)
+		}
+	})
+
+	t.Run("失効したトークンは無効", func(t *testing.T) {
+		revoked, err := sut.Generate(ctx, 1, time.Hour)
+		if err != nil {
+			t.Fatalf("failed to generate: %v", err)
+		}
+		if err := sut.Revoke(ctx, 1, revoked.Id); err != nil {
+			t.Fatalf("failed to revoke: %v", err)
+		}
+		if _, err := sut.Verify(ctx, revoked.Token); !errors.Is(err, preview_token_service.ErrInvalidPreviewToken) {
+			t.Errorf("want ErrInvalidPreviewToken, got %v", err)
+		}
+		if err := sut.Revoke(ctx, 1, revoked.Id); !errors.Is(err, preview_token_service.ErrPreviewTokenNotFound) {
+			t.Errorf("want ErrPreviewTokenNotFound, got %v", err)
+		}
+		// 他のトークンは有効なまま
+		if _, err := sut.Verify(ctx, generated.Token); err != nil {
+			t.Errorf("failed to verify: %v", err)
+		}
+	})
+
+	t.Run("同時に発行してもトークンが失われない", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := sut.Generate(ctx, 2, time.Hour); err != nil {
+					t.Errorf("failed to generate: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		tokens, err := sut.List(ctx, 2)
+		if err != nil {
+			t.Fatalf("failed to list: %v", err)
+		}
+		if len(tokens) != 10 {
+			t.Errorf("want 10 tokens, got %d", len(tokens))
+		}
+	})
+
+	t.Run("有効期限切れのトークンは無効", func(t *testing.T) {
+		clocker.now = clocker.now.Add(2 * time.Hour)
+		if _, err := sut.Verify(ctx, generated.Token); !errors.Is(err, preview_token_service.ErrInvalidPreviewToken) {
+			t.Errorf("want ErrInvalidPreviewToken, got %v", err)
+		}
+		tokens, err := sut.List(ctx, 1)
+		if err != nil {
+			t.Fatalf("failed to list: %v", err)
+		}
+		if len(tokens) != 0 {
+			t.Errorf("want no tokens, got %+v", tokens)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package related_blogs_cache_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type KVSer interface {
+	SaveWithExpiration(ctx context.Context, key string, value string, expiration time.Duration) error
+	Load(ctx context.Context, key string) (*string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// RelatedBlogsCacheService は、ブログごとの関連ブログの算出結果をKVSにキャッシュする
+// タグの変更時に破棄されなかった場合でも、有効期限の経過後に再計算される
+type RelatedBlogsCacheService struct {
+	kvs        KVSer
+	expiration time.Duration
+}
+
+func NewRelatedBlogsCacheService(kvs KVSer, expiration time.Duration) *RelatedBlogsCacheService {
+	return &RelatedBlogsCacheService{
+		kvs:        kvs,
+		expiration: expiration,
+	}
+}
+
+func (s *RelatedBlogsCacheService) key(blogId models.BlogId) string {
+	return fmt.Sprintf(config.KVS_RELATED_BLOGS, blogId)
+}
+
+// Load は、キャッシュされた関連ブログを取得する
+// キャッシュがない場合はnilを返す
+func (s *RelatedBlogsCacheService) Load(ctx context.Context, blogId models.BlogId) ([]*models.Blog, error) {
+	v, err := s.kvs.Load(ctx, s.key(blogId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load related blogs: %w", err)
+	}
+	if v == nil {
+		return nil, nil
+	}
+	blogs := []*models.Blog{}
+	if err := json.Unmarshal([]byte(*v), &blogs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal related blogs: %w", err)
+	}
+	return blogs, nil
+}
+
+// Save は、算出した関連ブログをキャッシュする
+func (s *RelatedBlogsCacheService) Save(ctx context.Context, blogId models.BlogId, blogs []*models.Blog) error {
+	b, err := json.Marshal(blogs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal related blogs: %w", err)
+	}
+	if err := s.kvs.SaveWithExpiration(ctx, s.key(blogId), string(b), s.expiration); err != nil {
+		return fmt.Errorf("failed to save related blogs: %w", err)
+	}
+	return nil
+}
+
+// Invalidate は、指定したブログの関連ブログのキャッシュを破棄する
+func (s *RelatedBlogsCacheService) Invalidate(ctx context.Context, blogIds ...models.BlogId) error {
+	for _, blogId := range blogIds {
+		if err := s.kvs.Delete(ctx, s.key(blogId)); err != nil {
+			return fmt.Errorf("failed to delete related blogs: %w", err)
+		}
+	}
+	return nil
+}
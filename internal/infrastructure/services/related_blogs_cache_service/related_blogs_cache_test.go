@@ -0,0 +1,85 @@
+package related_blogs_cache_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/services/related_blogs_cache_service"
+)
+
+type kvsFake struct {
+	values map[string]string
+}
+
+func (k *kvsFake) SaveWithExpiration(ctx context.Context, key string, value string, expiration time.Duration) error {
+	k.values[key] = value
+	return nil
+}
+
+func (k *kvsFake) Load(ctx context.Context, key string) (*string, error) {
+	v, ok := k.values[key]
+	if !ok {
+		return nil, nil
+	}
+	return &v, nil
+}
+
+func (k *kvsFake) Delete(ctx context.Context, key string) error {
+	delete(k.values, key)
+	return nil
+}
+
+func Test_RelatedBlogsCacheService(t *testing.T) {
+	ctx := context.Background()
+	sut := related_blogs_cache_service.NewRelatedBlogsCacheService(&kvsFake{values: map[string]string{}}, time.Hour)
+
+	got, err := sut.Load(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("want nil, got %+v", got)
+	}
+
+	want := []*models.Blog{
+		{Id: 2, Title: "title2", Tags: []string{"go"}},
+		{Id: 3, Title: "title3"},
+	}
+	if err := sut.Save(ctx, 1, want); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	// 関連ブログがない場合も空のスライスとしてキャッシュする
+	if err := sut.Save(ctx, 2, []*models.Blog{}); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	got, err = sut.Load(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+	got, err = sut.Load(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if got == nil || len(got) != 0 {
+		t.Errorf("want empty, got %+v", got)
+	}
+
+	if err := sut.Invalidate(ctx, 1, 2); err != nil {
+		t.Fatalf("failed to invalidate: %v", err)
+	}
+	for _, id := range []models.BlogId{1, 2} {
+		got, err = sut.Load(ctx, id)
+		if err != nil {
+			t.Fatalf("failed to load: %v", err)
+		}
+		if got != nil {
+			t.Errorf("want nil after invalidate, got %+v", got)
+		}
+	}
+}
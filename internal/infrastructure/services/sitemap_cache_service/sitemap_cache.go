@@ -0,0 +1,68 @@
+package sitemap_cache_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/sitemap"
+)
+
+type KVSer interface {
+	SaveWithExpiration(ctx context.Context, key string, value string, expiration time.Duration) error
+	Load(ctx context.Context, key string) (*string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// SitemapCacheService は、生成済みのsitemap.xmlとrobots.txtをKVSにキャッシュする
+// ブログの更新時に破棄されなかった場合でも、有効期限の経過後に再生成される
+type SitemapCacheService struct {
+	kvs        KVSer
+	expiration time.Duration
+}
+
+func NewSitemapCacheService(kvs KVSer, expiration time.Duration) *SitemapCacheService {
+	return &SitemapCacheService{
+		kvs:        kvs,
+		expiration: expiration,
+	}
+}
+
+// Load は、キャッシュされたsitemapを取得する
+// キャッシュがない場合はnilを返す
+func (s *SitemapCacheService) Load(ctx context.Context) (*sitemap.Documents, error) {
+	v, err := s.kvs.Load(ctx, config.KVS_SITEMAP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sitemap: %w", err)
+	}
+	if v == nil {
+		return nil, nil
+	}
+	var docs sitemap.Documents
+	if err := json.Unmarshal([]byte(*v), &docs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sitemap: %w", err)
+	}
+	return &docs, nil
+}
+
+// Save は、生成したsitemapをキャッシュする
+func (s *SitemapCacheService) Save(ctx context.Context, docs *sitemap.Documents) error {
+	b, err := json.Marshal(docs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sitemap: %w", err)
+	}
+	if err := s.kvs.SaveWithExpiration(ctx, config.KVS_SITEMAP, string(b), s.expiration); err != nil {
+		return fmt.Errorf("failed to save sitemap: %w", err)
+	}
+	return nil
+}
+
+// Invalidate は、キャッシュされたsitemapを破棄する
+func (s *SitemapCacheService) Invalidate(ctx context.Context) error {
+	if err := s.kvs.Delete(ctx, config.KVS_SITEMAP); err != nil {
+		return fmt.Errorf("failed to delete sitemap: %w", err)
+	}
+	return nil
+}
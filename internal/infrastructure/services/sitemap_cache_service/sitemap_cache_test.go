@@ -0,0 +1,74 @@
+package sitemap_cache_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/infrastructure/services/sitemap_cache_service"
+	"github.com/shoet/blog/internal/sitemap"
+)
+
+type kvsFake struct {
+	values map[string]string
+}
+
+func (k *kvsFake) SaveWithExpiration(ctx context.Context, key string, value string, expiration time.Duration) error {
+	k.values[key] = value
+	return nil
+}
+
+func (k *kvsFake) Load(ctx context.Context, key string) (*string, error) {
+	v, ok := k.values[key]
+	if !ok {
+		return nil, nil
+	}
+	return &v, nil
+}
+
+func (k *kvsFake) Delete(ctx context.Context, key string) error {
+	delete(k.values, key)
+	return nil
+}
+
+func Test_SitemapCacheService(t *testing.T) {
+	ctx := context.Background()
+	sut := sitemap_cache_service.NewSitemapCacheService(&kvsFake{values: map[string]string{}}, time.Hour)
+
+	got, err := sut.Load(ctx)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("want nil, got %+v", got)
+	}
+
+	want := &sitemap.Documents{
+		Sitemap:      "<urlset></urlset>",
+		Pages:        []string{"<urlset></urlset>"},
+		Robots:       "User-agent: *\n",
+		LastModified: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if err := sut.Save(ctx, want); err != nil {
+		t.Fatalf("failed to save: %v", err)
+	}
+	got, err = sut.Load(ctx)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if err := sut.Invalidate(ctx); err != nil {
+		t.Fatalf("failed to invalidate: %v", err)
+	}
+	got, err = sut.Load(ctx)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if got != nil {
+		t.Errorf("want nil after invalidate, got %+v", got)
+	}
+}
@@ -0,0 +1,129 @@
+package view_counter_service
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// visitorExpiration は、同じ訪問者の閲覧を重複して数えない期間
+// 日付が変わるとソルトも変わるため、日付の境界をまたいでも重複しない長さとする
+const visitorExpiration = 48 * time.Hour
+
+type KVSer interface {
+	Load(ctx context.Context, key string) (*string, error)
+	SetIfNotExists(ctx context.Context, key string, value string, expiration time.Duration) (bool, error)
+	IncrementHash(ctx context.Context, key string, field string, n int64) error
+	PopHash(ctx context.Context, key string) (map[string]string, error)
+}
+
+// ViewCounterService は、ブログの閲覧数をKVSで日別に集計する
+// 訪問者はIPアドレスとUser-Agentを日ごとのソルトでハッシュ化して識別し、元の値は保存しない
+type ViewCounterService struct {
+	kvs KVSer
+}
+
+func NewViewCounterService(kvs KVSer) *ViewCounterService {
+	return &ViewCounterService{
+		kvs: kvs,
+	}
+}
+
+// Count は、訪問者によるブログの閲覧を数える
+// 同じ日に同じ訪問者が閲覧済みの場合は数えずにfalseを返す
+func (s *ViewCounterService) Count(
+	ctx context.Context, blogId models.BlogId, visitor string, now time.Time,
+) (bool, error) {
+	date := models.ViewDate(now)
+	salt, err := s.salt(ctx, date)
+	if err != nil {
+		return false, fmt.Errorf("failed to get salt: %w", err)
+	}
+	h := sha256.New()
+	h.Write([]byte(fmt.Sprintf("%d.%s.%s", blogId, visitor, salt)))
+	visitorKey := fmt.Sprintf(config.KVS_VIEW_VISITOR, fmt.Sprintf("%x", h.Sum(nil)))
+	ok, err := s.kvs.SetIfNotExists(ctx, visitorKey, date, visitorExpiration)
+	if err != nil {
+		return false, fmt.Errorf("failed to save visitor: %w", err)
+	}
+	if !ok {
+		return false, nil
+	}
+	if err := s.kvs.IncrementHash(ctx, config.KVS_VIEW_COUNTS, countField(date, blogId), 1); err != nil {
+		return false, fmt.Errorf("failed to increment views: %w", err)
+	}
+	return true, nil
+}
+
+// salt は、日付ごとのソルトを取得する
+// 存在しない場合は生成する。複数インスタンスで同時に生成された場合は先に保存された値を使用する
+func (s *ViewCounterService) salt(ctx context.Context, date string) (string, error) {
+	key := fmt.Sprintf(config.KVS_VIEW_SALT, date)
+	if _, err := s.kvs.SetIfNotExists(ctx, key, uuid.NewString(), visitorExpiration); err != nil {
+		return "", fmt.Errorf("failed to save salt: %w", err)
+	}
+	salt, err := s.kvs.Load(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to load salt: %w", err)
+	}
+	if salt == nil {
+		return "", fmt.Errorf("salt not found")
+	}
+	return *salt, nil
+}
+
+// Pop は、集計中の閲覧数を取得し、KVSから削除する
+func (s *ViewCounterService) Pop(ctx context.Context) ([]*models.BlogViewDaily, error) {
+	counts, err := s.kvs.PopHash(ctx, config.KVS_VIEW_COUNTS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pop views: %w", err)
+	}
+	views := make([]*models.BlogViewDaily, 0, len(counts))
+	for field, value := range counts {
+		date, blogId, err := parseCountField(field)
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse views: %w", err)
+		}
+		views = append(views, &models.BlogViewDaily{BlogId: blogId, Date: date, Views: n})
+	}
+	return views, nil
+}
+
+// Restore は、Popで取得した閲覧数を集計中の閲覧数に戻す
+// 永続化に失敗した場合に、閲覧数が失われないようにするために使用する
+func (s *ViewCounterService) Restore(ctx context.Context, views []*models.BlogViewDaily) error {
+	for _, v := range views {
+		if err := s.kvs.IncrementHash(ctx, config.KVS_VIEW_COUNTS, countField(v.Date, v.BlogId), v.Views); err != nil {
+			return fmt.Errorf("failed to restore views: %w", err)
+		}
+	}
+	return nil
+}
+
+// countField は、集計中の閲覧数を保持するハッシュのfield名を返す
+func countField(date string, blogId models.BlogId) string {
+	return fmt.Sprintf("%s.%d", date, blogId)
+}
+
+func parseCountField(field string) (string, models.BlogId, error) {
+	date, id, ok := strings.Cut(field, ".")
+	if !ok {
+		return "", 0, fmt.Errorf("invalid views field: %s", field)
+	}
+	blogId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid views field: %s", field)
+	}
+	return date, models.BlogId(blogId), nil
+}
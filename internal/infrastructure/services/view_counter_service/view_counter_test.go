@@ -0,0 +1,124 @@
+package view_counter_service_test
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/services/view_counter_service"
+)
+
+type kvsFake struct {
+	values map[string]string
+	hashes map[string]map[string]int64
+}
+
+func newKVSFake() *kvsFake {
+	return &kvsFake{
+		values: map[string]string{},
+		hashes: map[string]map[string]int64{},
+	}
+}
+
+func (k *kvsFake) Load(ctx context.Context, key string) (*string, error) {
+	v, ok := k.values[key]
+	if !ok {
+		return nil, nil
+	}
+	return &v, nil
+}
+
+func (k *kvsFake) SetIfNotExists(ctx context.Context, key string, value string, expiration time.Duration) (bool, error) {
+	if _, ok := k.values[key]; ok {
+		return false, nil
+	}
+	k.values[key] = value
+	return true, nil
+}
+
+func (k *kvsFake) IncrementHash(ctx context.Context, key string, field string, n int64) error {
+	if _, ok := k.hashes[key]; !ok {
+		k.hashes[key] = map[string]int64{}
+	}
+	k.hashes[key][field] += n
+	return nil
+}
+
+func (k *kvsFake) PopHash(ctx context.Context, key string) (map[string]string, error) {
+	result := map[string]string{}
+	for field, v := range k.hashes[key] {
+		result[field] = strconv.FormatInt(v, 10)
+	}
+	delete(k.hashes, key)
+	return result, nil
+}
+
+func Test_ViewCounterService(t *testing.T) {
+	ctx := context.Background()
+	sut := view_counter_service.NewViewCounterService(newKVSFake())
+
+	day1 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	counts := []struct {
+		blogId  models.BlogId
+		visitor string
+		now     time.Time
+		want    bool
+	}{
+		{blogId: 1, visitor: "ip1.ua", now: day1, want: true},
+		// 同じ日の同じ訪問者は数えない
+		{blogId: 1, visitor: "ip1.ua", now: day1, want: false},
+		{blogId: 1, visitor: "ip2.ua", now: day1, want: true},
+		{blogId: 2, visitor: "ip1.ua", now: day1, want: true},
+		// 日付が変わると同じ訪問者でも数える
+		{blogId: 1, visitor: "ip1.ua", now: day2, want: true},
+	}
+	for i, c := range counts {
+		got, err := sut.Count(ctx, c.blogId, c.visitor, c.now)
+		if err != nil {
+			t.Fatalf("failed to count: %v", err)
+		}
+		if got != c.want {
+			t.Errorf("count %d: want %v, got %v", i, c.want, got)
+		}
+	}
+
+	views, err := sut.Pop(ctx)
+	if err != nil {
+		t.Fatalf("failed to pop: %v", err)
+	}
+	sortViews := func(views []*models.BlogViewDaily) {
+		sort.Slice(views, func(i, j int) bool {
+			if views[i].Date != views[j].Date {
+				return views[i].Date < views[j].Date
+			}
+			return views[i].BlogId < views[j].BlogId
+		})
+	}
+	sortViews(views)
+	want := []*models.BlogViewDaily{
+		{BlogId: 1, Date: "2024-01-01", Views: 2},
+		{BlogId: 2, Date: "2024-01-01", Views: 1},
+		{BlogId: 1, Date: "2024-01-02", Views: 1},
+	}
+	if diff := cmp.Diff(want, views); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	// 戻した閲覧数は次回のPopで取得できる
+	if err := sut.Restore(ctx, views); err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+	restored, err := sut.Pop(ctx)
+	if err != nil {
+		t.Fatalf("failed to pop: %v", err)
+	}
+	sortViews(restored)
+	if diff := cmp.Diff(want, restored); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/get_archive_blogs"
+	"github.com/shoet/blog/internal/usecase/get_archives"
+)
+
+type ArchiveListHandler struct {
+	Usecase *get_archives.Usecase
+}
+
+func NewArchiveListHandler(usecase *get_archives.Usecase) *ArchiveListHandler {
+	return &ArchiveListHandler{
+		Usecase: usecase,
+	}
+}
+
+/*
+RequestBody:
+
+	path: /archives
+
+Response:
+
+	[]{year: int, month: int, count: int}
+*/
+func (h *ArchiveListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+
+	archives, err := h.Usecase.Run(ctx)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to get archives: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, archives); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type ArchiveBlogListHandler struct {
+	Usecase *get_archive_blogs.Usecase
+}
+
+func NewArchiveBlogListHandler(usecase *get_archive_blogs.Usecase) *ArchiveBlogListHandler {
+	return &ArchiveBlogListHandler{
+		Usecase: usecase,
+	}
+}
+
+/*
+RequestBody:
+
+	path: /archives/{year}/{month}
+	query: limit, page, with_author
+
+Response:
+
+	blogs: []Blog
+	totalCount: int
+*/
+func (h *ArchiveBlogListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+
+	year, err := strconv.Atoi(strings.TrimSpace(chi.URLParam(r, "year")))
+	if err != nil {
+		err := fmt.Errorf("year is invalid")
+		logger.Error(err.Error())
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	month, err := strconv.Atoi(strings.TrimSpace(chi.URLParam(r, "month")))
+	if err != nil {
+		err := fmt.Errorf("month is invalid")
+		logger.Error(err.Error())
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	input := &get_archive_blogs.Input{
+		Year:       year,
+		Month:      month,
+		WithAuthor: withAuthor(r),
+	}
+	v := r.URL.Query()
+	limit := v.Get("limit")
+	if limit != "" {
+		v, err := strconv.Atoi(limit)
+		if err != nil {
+			err := fmt.Errorf("limit is invalid")
+			logger.Error(err.Error())
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		l := int64(v)
+		input.Limit = &l
+	}
+	page := v.Get("page")
+	if page != "" {
+		v, err := strconv.Atoi(page)
+		if err != nil {
+			err := fmt.Errorf("page is invalid")
+			logger.Error(err.Error())
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		p := int64(v)
+		input.Page = &p
+	}
+
+	blogs, blogsCount, err := h.Usecase.Run(ctx, input)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidArchiveMonth) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to get archive blogs: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+
+	type ResponseBody struct {
+		Blog       []*models.Blog `json:"blogs"`
+		TotalCount int64          `json:"totalCount"`
+	}
+	body := &ResponseBody{
+		Blog:       blogs,
+		TotalCount: blogsCount,
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, body); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
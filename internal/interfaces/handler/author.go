@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/options"
+	"github.com/shoet/blog/internal/usecase/get_blogs"
+	"github.com/shoet/blog/internal/usecase/get_user_profile"
+)
+
+// parseUserId は、パスパラメータからユーザーIDを取得する
+func parseUserId(r *http.Request) (models.UserId, error) {
+	id, err := strconv.Atoi(strings.TrimSpace(chi.URLParam(r, "id")))
+	if err != nil {
+		return 0, fmt.Errorf("id is invalid: %w", err)
+	}
+	return models.UserId(id), nil
+}
+
+type AuthorGetHandler struct {
+	Usecase *get_user_profile.Usecase
+}
+
+func NewAuthorGetHandler(usecase *get_user_profile.Usecase) *AuthorGetHandler {
+	return &AuthorGetHandler{
+		Usecase: usecase,
+	}
+}
+
+/*
+RequestBody:
+
+	path: /users/{id}
+
+Response:
+
+	UserProfile
+*/
+func (h *AuthorGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+
+	userId, err := parseUserId(r)
+	if err != nil {
+		logger.Error(err.Error())
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	userProfile, err := h.Usecase.Run(ctx, userId)
+	if err != nil {
+		if errors.Is(err, get_user_profile.ErrNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to get user profile: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, userProfile); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type AuthorBlogListHandler struct {
+	Usecase *get_blogs.Usecase
+}
+
+func NewAuthorBlogListHandler(usecase *get_blogs.Usecase) *AuthorBlogListHandler {
+	return &AuthorBlogListHandler{
+		Usecase: usecase,
+	}
+}
+
+/*
+RequestBody:
+
+	path: /users/{id}/blogs
+	query: cursor, limit, sort, order, with_author
+
+Response:
+
+	blogs: []Blog
+	prevCursor: string | null
+	nextCursor: string | null
+*/
+func (h *AuthorBlogListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+
+	userId, err := parseUserId(r)
+	if err != nil {
+		logger.Error(err.Error())
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	isPublicOnly := true
+	v := r.URL.Query()
+	input := &get_blogs.GetBlogsInput{
+		AuthorId:     &userId,
+		IsPublicOnly: &isPublicOnly,
+		WithAuthor:   withAuthor(r),
+	}
+	if sort := v.Get("sort"); sort != "" {
+		input.Sort = &sort
+	}
+	if order := v.Get("order"); order != "" {
+		input.Order = &order
+	}
+	if cursor := v.Get("cursor"); cursor != "" {
+		input.Cursor = &cursor
+	}
+	if limit := v.Get("limit"); limit != "" {
+		v, err := strconv.Atoi(limit)
+		if err != nil {
+			err := fmt.Errorf("limit is invalid")
+			logger.Error(err.Error())
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		l := int64(v)
+		input.Limit = &l
+	}
+
+	blogs, prevCursor, nextCursor, err := h.Usecase.Run(ctx, input)
+	if err != nil {
+		if errors.Is(err, options.ErrInvalidSort) || errors.Is(err, options.ErrInvalidCursor) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to list author blogs: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if blogs == nil {
+		blogs = []*models.Blog{}
+	}
+
+	type ResponseBody struct {
+		Blog       []*models.Blog `json:"blogs"`
+		PrevCursor *string        `json:"prevCursor"`
+		NextCursor *string        `json:"nextCursor"`
+	}
+	body := &ResponseBody{
+		Blog:       blogs,
+		PrevCursor: prevCursor,
+		NextCursor: nextCursor,
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, body); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
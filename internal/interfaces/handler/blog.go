@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -10,10 +12,12 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/shoet/blog/internal/infrastructure/models"
 	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/locale"
 	"github.com/shoet/blog/internal/logging"
 	"github.com/shoet/blog/internal/options"
 	"github.com/shoet/blog/internal/usecase/create_blog"
 	"github.com/shoet/blog/internal/usecase/delete_blog"
+	"github.com/shoet/blog/internal/usecase/get_blog_by_slug"
 	"github.com/shoet/blog/internal/usecase/get_blog_detail"
 	"github.com/shoet/blog/internal/usecase/get_blogs"
 	"github.com/shoet/blog/internal/usecase/get_blogs_offset_paging"
@@ -23,14 +27,18 @@ import (
 )
 
 type BlogGetHandler struct {
-	Usecase *get_blog_detail.Usecase
-	jwter   JWTService
+	Usecase   *get_blog_detail.Usecase
+	jwter     JWTService
+	previewer PreviewTokenService
 }
 
-func NewBlogGetHandler(usecase *get_blog_detail.Usecase, jwter JWTService) *BlogGetHandler {
+func NewBlogGetHandler(
+	usecase *get_blog_detail.Usecase, jwter JWTService, previewer PreviewTokenService,
+) *BlogGetHandler {
 	return &BlogGetHandler{
-		Usecase: usecase,
-		jwter:   jwter,
+		Usecase:   usecase,
+		jwter:     jwter,
+		previewer: previewer,
 	}
 }
 
@@ -53,7 +61,13 @@ func (l *BlogGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		response.RespondBadRequest(w, r, err)
 		return
 	}
-	blog, err := l.Usecase.Run(ctx, models.BlogId(idInt))
+	locales, err := preferredLocales(r)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to parse lang: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	blog, err := l.Usecase.Run(ctx, models.BlogId(idInt), withRendered(r), withAuthor(r), locales)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to get blog: %v", err))
 		response.RespondInternalServerError(w, r, err)
@@ -63,31 +77,165 @@ func (l *BlogGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		response.RespondNotFound(w, r, err)
 		return
 	}
-	// 非公開のBlogは認証が必要
-	if !blog.IsPublic {
-		token := r.Header.Get("Authorization")
-		if token == "" {
-			logger.Error("failed to get authorization header")
-			response.RespondNotFound(w, r, err)
-			return
-		}
+	if !canReadBlog(r, blog, l.jwter, l.previewer) {
+		response.RespondNotFound(w, r, nil)
+		return
+	}
+	setLocaleHeaders(w, blog)
+	// 更新・削除時にIf-Matchで指定するバージョン
+	response.SetVersion(w, blog.Version)
+	res := &BlogGetResponse{
+		Blog: blog,
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, res); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
 
-		if !strings.HasPrefix(token, "Bearer ") {
-			logger.Error("failed to get authorization token")
-			response.RespondNotFound(w, r, err)
-			return
-		}
+// withRendered は、本文のレンダリング結果を返すかをクエリパラメータから判定する
+func withRendered(r *http.Request) bool {
+	render, err := strconv.ParseBool(r.URL.Query().Get("render"))
+	return err == nil && render
+}
+
+// withAuthor は、著者のプロフィールを埋め込むかをクエリパラメータから判定する
+func withAuthor(r *http.Request) bool {
+	author, err := strconv.ParseBool(r.URL.Query().Get("with_author"))
+	return err == nil && author
+}
+
+// preferredLocales は、希望する言語を優先順にクエリパラメータとAccept-Languageヘッダーから取得する
+// langが指定された場合は最優先とし、翻訳がない場合はAccept-Languageの言語で代替する
+func preferredLocales(r *http.Request) ([]string, error) {
+	locales := locale.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	lang := strings.TrimSpace(r.URL.Query().Get("lang"))
+	if lang == "" {
+		return locales, nil
+	}
+	normalized, err := locale.Normalize(lang)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{normalized}, locales...), nil
+}
+
+// setLocaleHeaders は、返すブログの言語をContent-Languageヘッダーに設定する
+// 言語はAccept-Languageヘッダーによって変わるため、Varyヘッダーにも追加する
+func setLocaleHeaders(w http.ResponseWriter, blog *models.Blog) {
+	if blog.Locale != "" {
+		w.Header().Set("Content-Language", blog.Locale)
+	}
+	w.Header().Add("Vary", "Accept-Language")
+}
+
+// ifMatchVersion は、If-Matchヘッダから更新の条件とするバージョンを取り出す
+// 一致するブログがないETagのみが指定された場合は412を返し、okをfalseとする
+func ifMatchVersion(w http.ResponseWriter, r *http.Request) (version *int64, ok bool) {
+	version, ok = response.IfMatchVersion(r)
+	if !ok {
+		response.RespondPreconditionFailed(w, r, nil)
+	}
+	return version, ok
+}
 
-		token = strings.TrimPrefix(token, "Bearer ")
-		_, err := l.jwter.VerifyToken(ctx, token)
+// canReadBlog は、ブログを閲覧できるかを判定する
+// 非公開のBlogはプレビュー用トークンか認証が必要
+func canReadBlog(
+	r *http.Request, blog *models.Blog, jwter JWTService, previewer PreviewTokenService,
+) bool {
+	if blog.IsPublic {
+		return true
+	}
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	if r.URL.Query().Has("preview") {
+		previewBlogId, err := previewer.Verify(ctx, r.URL.Query().Get("preview"))
 		if err != nil {
-			logger.Error(fmt.Sprintf("failed to verify token: %v", err))
-			response.RespondNotFound(w, r, err)
-			return
+			logger.Error(fmt.Sprintf("failed to verify preview token: %v", err))
+			return false
 		}
+		if previewBlogId != blog.Id {
+			logger.Error("preview token is not for this blog")
+			return false
+		}
+		return true
+	}
+
+	token := r.Header.Get("Authorization")
+	if token == "" {
+		logger.Error("failed to get authorization header")
+		return false
+	}
+	if !strings.HasPrefix(token, "Bearer ") {
+		logger.Error("failed to get authorization token")
+		return false
+	}
+	token = strings.TrimPrefix(token, "Bearer ")
+	if _, err := jwter.VerifyToken(ctx, token); err != nil {
+		logger.Error(fmt.Sprintf("failed to verify token: %v", err))
+		return false
+	}
+	return true
+}
+
+type BlogGetBySlugHandler struct {
+	Usecase   *get_blog_by_slug.Usecase
+	jwter     JWTService
+	previewer PreviewTokenService
+}
+
+func NewBlogGetBySlugHandler(
+	usecase *get_blog_by_slug.Usecase, jwter JWTService, previewer PreviewTokenService,
+) *BlogGetBySlugHandler {
+	return &BlogGetBySlugHandler{
+		Usecase:   usecase,
+		jwter:     jwter,
+		previewer: previewer,
+	}
+}
+
+func (l *BlogGetBySlugHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	slug := strings.TrimSpace(chi.URLParam(r, "slug"))
+	if slug == "" {
+		logger.Error("failed to get slug from url")
+		response.RespondBadRequest(w, r, nil)
+		return
+	}
+	locales, err := preferredLocales(r)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to parse lang: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	result, err := l.Usecase.Run(ctx, slug, withRendered(r), withAuthor(r), locales)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to get blog by slug: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
 	}
+	if result == nil {
+		response.RespondNotFound(w, r, nil)
+		return
+	}
+	// 旧スラッグの場合は現在のスラッグへリダイレクトする
+	if result.RedirectSlug != nil {
+		location := "/blogs/slug/" + url.PathEscape(*result.RedirectSlug)
+		if r.URL.RawQuery != "" {
+			location += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, location, http.StatusMovedPermanently)
+		return
+	}
+	if !canReadBlog(r, result.Blog, l.jwter, l.previewer) {
+		response.RespondNotFound(w, r, nil)
+		return
+	}
+	setLocaleHeaders(w, result.Blog)
+	response.SetVersion(w, result.Blog.Version)
 	res := &BlogGetResponse{
-		Blog: blog,
+		Blog: result.Blog,
 	}
 	if err := response.RespondJSON(w, r, http.StatusOK, res); err != nil {
 		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
@@ -120,6 +268,9 @@ func (a *BlogAddHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ThumbnailImageFileName string        `json:"thumbnailImageFileName"`
 		IsPublic               bool          `json:"isPublic" default:"false"`
 		Tags                   []string      `json:"tags" default:"[]"`
+		PublishAt              *uint         `json:"publishAt"`
+		Slug                   string        `json:"slug"`
+		DefaultLocale          string        `json:"defaultLocale"`
 	}
 	defer r.Body.Close()
 	if err := response.JsonToStruct(r, &reqBody); err != nil {
@@ -142,11 +293,20 @@ func (a *BlogAddHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ThumbnailImageFileName: reqBody.ThumbnailImageFileName,
 		IsPublic:               reqBody.IsPublic,
 		Tags:                   reqBody.Tags,
+		PublishAt:              reqBody.PublishAt,
+		Slug:                   reqBody.Slug,
+		DefaultLocale:          reqBody.DefaultLocale,
 	}
 
 	newBlog, err := a.Usecase.Run(ctx, blog)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to add blog: %v", err))
+		if errors.Is(err, create_blog.ErrInvalidSlug) ||
+			errors.Is(err, create_blog.ErrSlugConflict) ||
+			errors.Is(err, create_blog.ErrInvalidLocale) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
 		response.RespondInternalServerError(w, r, err)
 		return
 	}
@@ -186,8 +346,20 @@ func (d *BlogDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		response.RespondBadRequest(w, r, err)
 		return
 	}
-	blogId, err := d.Usecase.Run(ctx, models.BlogId(idInt))
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+	blogId, err := d.Usecase.Run(ctx, models.BlogId(idInt), version)
 	if err != nil {
+		if errors.Is(err, delete_blog.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		if errors.Is(err, delete_blog.ErrPreconditionFailed) {
+			response.RespondPreconditionFailed(w, r, err)
+			return
+		}
 		logger.Error(fmt.Sprintf("failed to delete blog: %v", err))
 		response.RespondInternalServerError(w, r, err)
 		return
@@ -217,15 +389,15 @@ func (l *BlogListAdminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	logger := logging.GetLogger(ctx)
 
 	input := &get_blogs.GetBlogsInput{}
-	resp, prevEOF, nextEOF, err := l.Usecase.Run(ctx, input)
+	resp, prevCursor, nextCursor, err := l.Usecase.Run(ctx, input)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to list blog: %v", err))
 		response.RespondInternalServerError(w, r, err)
 		return
 	}
-	// TODO: 直近は管理画面ではページネーションを使わないため、EOFフラグは使わない
-	_ = prevEOF
-	_ = nextEOF
+	// TODO: 直近は管理画面ではページネーションを使わないため、カーソルは使わない
+	_ = prevCursor
+	_ = nextCursor
 	if resp == nil {
 		if err := response.RespondJSON(w, r, http.StatusOK, []interface{}{}); err != nil {
 			logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
@@ -252,37 +424,29 @@ func (l *BlogListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	logger := logging.GetLogger(ctx)
 
 	isPublicOnly := func() *bool { var v = true; return &v }()
-	input := &get_blogs.GetBlogsInput{IsPublicOnly: isPublicOnly}
 	v := r.URL.Query()
-	tag := v.Get("tag")
-	if tag != "" {
-		input.Tag = &tag
-	}
-	keyword := v.Get("keyword")
-	if keyword != "" {
-		input.KeyWord = &keyword
-	}
-	cursor_id := v.Get("cursor_id") // ページネーションのカーソルID
-	if cursor_id != "" {
-		v, err := strconv.Atoi(cursor_id)
-		if err != nil {
-			err := fmt.Errorf("cursor_id is invalid")
-			logger.Error(err.Error())
-			response.RespondBadRequest(w, r, err)
-			return
-		}
-		blogId := models.BlogId(v)
-		input.CursorId = &blogId
+	filter, err := parseBlogListQuery(v)
+	if err != nil {
+		logger.Error(err.Error())
+		response.RespondBadRequest(w, r, err)
+		return
 	}
-	direction := v.Get("direction") // ページネーションの方向
-	if direction != "" {
-		if direction != "prev" && direction != "next" {
-			err := fmt.Errorf("direction is invalid")
-			logger.Error(err.Error())
-			response.RespondBadRequest(w, r, err)
-			return
-		}
-		input.PageDirection = &direction
+	input := &get_blogs.GetBlogsInput{
+		Tags:         filter.Tags,
+		TagMatch:     filter.TagMatch,
+		KeyWord:      filter.KeyWord,
+		CreatedFrom:  filter.CreatedFrom,
+		CreatedTo:    filter.CreatedTo,
+		AuthorId:     filter.AuthorId,
+		Sort:         filter.Sort,
+		Order:        filter.Order,
+		IsPublicOnly: isPublicOnly,
+		WithAuthor:   withAuthor(r),
+		Locale:       filter.Locale,
+	}
+	cursor := v.Get("cursor") // ページネーションのカーソル
+	if cursor != "" {
+		input.Cursor = &cursor
 	}
 	limit := v.Get("limit")
 	if limit != "" {
@@ -297,8 +461,16 @@ func (l *BlogListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		input.Limit = &l
 	}
 
-	blogs, prevEOF, nextEOF, err := l.Usecase.Run(ctx, input)
+	blogs, prevCursor, nextCursor, err := l.Usecase.Run(ctx, input)
 	if err != nil {
+		if errors.Is(err, options.ErrInvalidTagMatch) ||
+			errors.Is(err, options.ErrInvalidCreatedRange) ||
+			errors.Is(err, options.ErrInvalidSort) ||
+			errors.Is(err, options.ErrInvalidCursor) ||
+			errors.Is(err, locale.ErrInvalidLocale) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
 		logger.Error(fmt.Sprintf("failed to list blog: %v", err))
 		response.RespondInternalServerError(w, r, err)
 		return
@@ -311,15 +483,15 @@ func (l *BlogListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	type ResponseBody struct {
-		Blog    []*models.Blog `json:"blogs"`
-		PrevEOF bool           `json:"prevEOF"`
-		NextEOF bool           `json:"nextEOF"`
+		Blog       []*models.Blog `json:"blogs"`
+		PrevCursor *string        `json:"prevCursor"`
+		NextCursor *string        `json:"nextCursor"`
 	}
 
 	body := &ResponseBody{
-		Blog:    blogs,
-		PrevEOF: prevEOF,
-		NextEOF: nextEOF,
+		Blog:       blogs,
+		PrevCursor: prevCursor,
+		NextCursor: nextCursor,
 	}
 
 	if err := response.RespondJSON(w, r, http.StatusOK, body); err != nil {
@@ -327,6 +499,52 @@ func (l *BlogListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// blogListQuery は、ブログ一覧の絞り込み条件と並び順のクエリパラメータ
+type blogListQuery struct {
+	Tags        []string
+	TagMatch    *string
+	KeyWord     *string
+	CreatedFrom *string
+	CreatedTo   *string
+	AuthorId    *models.UserId
+	Sort        *string
+	Order       *string
+	Locale      *string
+}
+
+// parseBlogListQuery は、ブログ一覧の絞り込み条件と並び順をクエリパラメータから取得する
+// tagは複数指定でき、tag_matchがandの場合はすべて、orの場合はいずれかのタグを持つブログとする
+// sortはid・created・modified・title・popularity・relevance、orderはasc・descを指定する
+// langは言語で、その言語で閲覧できるブログに絞り込む
+func parseBlogListQuery(v url.Values) (*blogListQuery, error) {
+	query := &blogListQuery{
+		Tags: v["tag"],
+	}
+	optional := func(key string) *string {
+		value := strings.TrimSpace(v.Get(key))
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	query.TagMatch = optional("tag_match")
+	query.KeyWord = optional("keyword")
+	query.CreatedFrom = optional("created_from")
+	query.CreatedTo = optional("created_to")
+	query.Sort = optional("sort")
+	query.Order = optional("order")
+	query.Locale = optional("lang")
+	if authorId := optional("author_id"); authorId != nil {
+		id, err := strconv.Atoi(*authorId)
+		if err != nil {
+			return nil, fmt.Errorf("author_id is invalid")
+		}
+		userId := models.UserId(id)
+		query.AuthorId = &userId
+	}
+	return query, nil
+}
+
 type BlogGetOffsetPagingHandler struct {
 	Usecase *get_blogs_offset_paging.Usecase
 }
@@ -342,15 +560,25 @@ func (l *BlogGetOffsetPagingHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 	logger := logging.GetLogger(ctx)
 
 	isPublicOnly := func() *bool { var v = true; return &v }()
-	input := &get_blogs_offset_paging.Input{IsPublicOnly: isPublicOnly}
 	v := r.URL.Query()
-	tag := v.Get("tag")
-	if tag != "" {
-		input.Tag = &tag
+	filter, err := parseBlogListQuery(v)
+	if err != nil {
+		logger.Error(err.Error())
+		response.RespondBadRequest(w, r, err)
+		return
 	}
-	keyword := v.Get("keyword")
-	if keyword != "" {
-		input.KeyWord = &keyword
+	input := &get_blogs_offset_paging.Input{
+		Tags:         filter.Tags,
+		TagMatch:     filter.TagMatch,
+		KeyWord:      filter.KeyWord,
+		CreatedFrom:  filter.CreatedFrom,
+		CreatedTo:    filter.CreatedTo,
+		AuthorId:     filter.AuthorId,
+		Sort:         filter.Sort,
+		Order:        filter.Order,
+		IsPublicOnly: isPublicOnly,
+		WithAuthor:   withAuthor(r),
+		Locale:       filter.Locale,
 	}
 	limit := v.Get("limit")
 	if limit != "" {
@@ -379,6 +607,13 @@ func (l *BlogGetOffsetPagingHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 
 	blogs, blogsCount, err := l.Usecase.Run(ctx, input)
 	if err != nil {
+		if errors.Is(err, options.ErrInvalidTagMatch) ||
+			errors.Is(err, options.ErrInvalidCreatedRange) ||
+			errors.Is(err, options.ErrInvalidSort) ||
+			errors.Is(err, locale.ErrInvalidLocale) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
 		logger.Error(fmt.Sprintf("failed to list blog: %v", err))
 		response.RespondInternalServerError(w, r, err)
 		return
@@ -432,6 +667,9 @@ func (p *BlogPutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ThumbnailImageFileName string        `json:"thumbnailImageFileName"`
 		IsPublic               bool          `json:"isPublic"`
 		Tags                   []string      `json:"tags"`
+		PublishAt              *uint         `json:"publishAt"`
+		Slug                   string        `json:"slug"`
+		DefaultLocale          string        `json:"defaultLocale"`
 	}
 	defer r.Body.Close()
 	if err := response.JsonToStruct(r, &reqBody); err != nil {
@@ -455,14 +693,37 @@ func (p *BlogPutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ThumbnailImageFileName: reqBody.ThumbnailImageFileName,
 		IsPublic:               reqBody.IsPublic,
 		Tags:                   reqBody.Tags,
+		PublishAt:              reqBody.PublishAt,
+		Slug:                   reqBody.Slug,
+		DefaultLocale:          reqBody.DefaultLocale,
 	}
 
-	newBlog, err := p.Usecase.Run(ctx, blog)
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+	newBlog, err := p.Usecase.Run(ctx, blog, version)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to put blog: %v", err))
+		if errors.Is(err, put_blog.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		if errors.Is(err, put_blog.ErrPreconditionFailed) {
+			response.RespondPreconditionFailed(w, r, err)
+			return
+		}
+		if errors.Is(err, put_blog.ErrInvalidSlug) ||
+			errors.Is(err, put_blog.ErrSlugConflict) ||
+			errors.Is(err, put_blog.ErrInvalidLocale) ||
+			errors.Is(err, put_blog.ErrLocaleConflict) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
 		response.RespondInternalServerError(w, r, err)
 		return
 	}
+	response.SetVersion(w, newBlog.Version)
 	if err := response.RespondJSON(w, r, http.StatusOK, newBlog); err != nil {
 		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
 	}
@@ -504,8 +765,16 @@ func (h *BlogUpdatePublicStatusHandler) ServeHTTP(w http.ResponseWriter, r *http
 		response.RespondBadRequest(w, r, err)
 		return
 	}
-	blog, err := h.Usecase.Run(ctx, reqBody.BlogId, *reqBody.IsPublic)
+	version, ok := ifMatchVersion(w, r)
+	if !ok {
+		return
+	}
+	blog, err := h.Usecase.Run(ctx, reqBody.BlogId, *reqBody.IsPublic, version)
 	if err != nil {
+		if errors.Is(err, update_public_status.ErrPreconditionFailed) {
+			response.RespondPreconditionFailed(w, r, err)
+			return
+		}
 		logger.Error(fmt.Sprintf("failed to update blog public status: %v", err))
 		response.RespondInternalServerError(w, r, err)
 		return
@@ -514,6 +783,7 @@ func (h *BlogUpdatePublicStatusHandler) ServeHTTP(w http.ResponseWriter, r *http
 		response.RespondNotFound(w, r, fmt.Errorf("blog not found"))
 		return
 	}
+	response.SetVersion(w, blog.Version)
 	if err := response.RespondJSON(w, r, http.StatusOK, blog); err != nil {
 		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
 	}
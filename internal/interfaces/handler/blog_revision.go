@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/diff_blog_revisions"
+	"github.com/shoet/blog/internal/usecase/get_blog_revision"
+	"github.com/shoet/blog/internal/usecase/get_blog_revisions"
+	"github.com/shoet/blog/internal/usecase/restore_blog_revision"
+)
+
+type BlogRevisionListHandler struct {
+	Usecase *get_blog_revisions.Usecase
+}
+
+func NewBlogRevisionListHandler(usecase *get_blog_revisions.Usecase) *BlogRevisionListHandler {
+	return &BlogRevisionListHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *BlogRevisionListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	revisions, err := h.Usecase.Run(ctx, models.BlogId(idInt))
+	if err != nil {
+		if errors.Is(err, get_blog_revisions.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to list blog revisions: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, revisions); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type BlogRevisionGetHandler struct {
+	Usecase *get_blog_revision.Usecase
+}
+
+func NewBlogRevisionGetHandler(usecase *get_blog_revision.Usecase) *BlogRevisionGetHandler {
+	return &BlogRevisionGetHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *BlogRevisionGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	revision := chi.URLParam(r, "revision")
+	revisionInt, err := strconv.ParseInt(strings.TrimSpace(revision), 10, 64)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert revision to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	blogRevision, err := h.Usecase.Run(ctx, models.BlogId(idInt), revisionInt)
+	if err != nil {
+		if errors.Is(err, get_blog_revision.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to get blog revision: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, blogRevision); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type BlogRevisionDiffHandler struct {
+	Usecase *diff_blog_revisions.Usecase
+}
+
+func NewBlogRevisionDiffHandler(usecase *diff_blog_revisions.Usecase) *BlogRevisionDiffHandler {
+	return &BlogRevisionDiffHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *BlogRevisionDiffHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	v := r.URL.Query()
+	from, err := strconv.ParseInt(v.Get("from"), 10, 64)
+	if err != nil {
+		err := fmt.Errorf("from is invalid")
+		logger.Error(err.Error())
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	to, err := strconv.ParseInt(v.Get("to"), 10, 64)
+	if err != nil {
+		err := fmt.Errorf("to is invalid")
+		logger.Error(err.Error())
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	diff, err := h.Usecase.Run(ctx, models.BlogId(idInt), from, to)
+	if err != nil {
+		if errors.Is(err, diff_blog_revisions.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to diff blog revisions: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	res := struct {
+		From int64  `json:"from"`
+		To   int64  `json:"to"`
+		Diff string `json:"diff"`
+	}{
+		From: from,
+		To:   to,
+		Diff: diff,
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, res); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type BlogRevisionRestoreHandler struct {
+	Usecase *restore_blog_revision.Usecase
+}
+
+func NewBlogRevisionRestoreHandler(usecase *restore_blog_revision.Usecase) *BlogRevisionRestoreHandler {
+	return &BlogRevisionRestoreHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *BlogRevisionRestoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	revision := chi.URLParam(r, "revision")
+	revisionInt, err := strconv.ParseInt(strings.TrimSpace(revision), 10, 64)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert revision to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	blog, err := h.Usecase.Run(ctx, models.BlogId(idInt), revisionInt)
+	if err != nil {
+		if errors.Is(err, restore_blog_revision.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to restore blog revision: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, blog); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/delete_blog_translation"
+	"github.com/shoet/blog/internal/usecase/put_blog_translation"
+)
+
+type BlogTranslationPutHandler struct {
+	Usecase   *put_blog_translation.Usecase
+	Validator *validator.Validate
+}
+
+func NewBlogTranslationPutHandler(
+	usecase *put_blog_translation.Usecase,
+	validator *validator.Validate,
+) *BlogTranslationPutHandler {
+	return &BlogTranslationPutHandler{
+		Usecase:   usecase,
+		Validator: validator,
+	}
+}
+
+/*
+RequestBody:
+
+	path: /blogs/{id}/translations/{locale}
+	title: string
+	description: string
+	content: string
+
+Response:
+
+	blogId: int
+	locale: string
+	title: string
+	description: string
+	content: string
+	created: int
+	modified: int
+*/
+func (h *BlogTranslationPutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	var reqBody struct {
+		Title       string `json:"title" validate:"required"`
+		Description string `json:"description" validate:"required"`
+		Content     string `json:"content" validate:"required"`
+	}
+	defer r.Body.Close()
+	if err := response.JsonToStruct(r, &reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to parse request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	if err := h.Validator.Struct(reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to validate request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	translation := &models.BlogTranslation{
+		BlogId:      models.BlogId(idInt),
+		Locale:      chi.URLParam(r, "locale"),
+		Title:       reqBody.Title,
+		Description: reqBody.Description,
+		Content:     reqBody.Content,
+	}
+	updated, err := h.Usecase.Run(ctx, translation)
+	if err != nil {
+		if errors.Is(err, put_blog_translation.ErrInvalidLocale) ||
+			errors.Is(err, put_blog_translation.ErrDefaultLocale) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		if errors.Is(err, put_blog_translation.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to put blog translation: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, updated); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type BlogTranslationDeleteHandler struct {
+	Usecase *delete_blog_translation.Usecase
+}
+
+func NewBlogTranslationDeleteHandler(usecase *delete_blog_translation.Usecase) *BlogTranslationDeleteHandler {
+	return &BlogTranslationDeleteHandler{
+		Usecase: usecase,
+	}
+}
+
+/*
+RequestBody:
+
+	path: /blogs/{id}/translations/{locale}
+
+Response:
+
+	id: int
+	locale: string
+*/
+func (h *BlogTranslationDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	localeTag := chi.URLParam(r, "locale")
+	if err := h.Usecase.Run(ctx, models.BlogId(idInt), localeTag); err != nil {
+		if errors.Is(err, delete_blog_translation.ErrInvalidLocale) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		if errors.Is(err, delete_blog_translation.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to delete blog translation: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	resp := struct {
+		Id     int    `json:"id"`
+		Locale string `json:"locale"`
+	}{
+		Id:     idInt,
+		Locale: localeTag,
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, resp); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
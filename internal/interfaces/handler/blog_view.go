@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/count_blog_view"
+	"github.com/shoet/blog/internal/usecase/get_blog_views"
+	"github.com/shoet/blog/internal/usecase/get_site_views"
+)
+
+type BlogViewCountHandler struct {
+	Usecase *count_blog_view.Usecase
+}
+
+func NewBlogViewCountHandler(usecase *count_blog_view.Usecase) *BlogViewCountHandler {
+	return &BlogViewCountHandler{
+		Usecase: usecase,
+	}
+}
+
+/*
+RequestBody:
+
+	path: /blogs/{id}/views
+
+Response:
+
+	counted: bool
+*/
+func (h *BlogViewCountHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	ip := r.Header.Get("x-forwarded-for")
+	if ip == "" {
+		ip = r.RemoteAddr
+	}
+	counted, err := h.Usecase.Run(ctx, models.BlogId(idInt), ip, r.UserAgent())
+	if err != nil {
+		if errors.Is(err, count_blog_view.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to count blog view: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	resp := struct {
+		Counted bool `json:"counted"`
+	}{
+		Counted: counted,
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, resp); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type BlogViewsHandler struct {
+	Usecase *get_blog_views.Usecase
+}
+
+func NewBlogViewsHandler(usecase *get_blog_views.Usecase) *BlogViewsHandler {
+	return &BlogViewsHandler{
+		Usecase: usecase,
+	}
+}
+
+/*
+RequestBody:
+
+	path: /admin/blogs/{id}/views?from=YYYY-MM-DD&to=YYYY-MM-DD
+
+Response:
+
+	blogId: int
+	from: string
+	to: string
+	total: int
+	daily: []
+		date: string
+		views: int
+*/
+func (h *BlogViewsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	q := r.URL.Query()
+	views, err := h.Usecase.Run(ctx, models.BlogId(idInt), q.Get("from"), q.Get("to"))
+	if err != nil {
+		if errors.Is(err, get_blog_views.ErrInvalidPeriod) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		if errors.Is(err, get_blog_views.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to get blog views: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, views); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type SiteViewsHandler struct {
+	Usecase *get_site_views.Usecase
+}
+
+func NewSiteViewsHandler(usecase *get_site_views.Usecase) *SiteViewsHandler {
+	return &SiteViewsHandler{
+		Usecase: usecase,
+	}
+}
+
+/*
+RequestBody:
+
+	path: /admin/views?from=YYYY-MM-DD&to=YYYY-MM-DD
+
+Response:
+
+	from: string
+	to: string
+	total: int
+	daily: []
+		date: string
+		views: int
+	blogs: []
+		blogId: int
+		title: string
+		views: int
+*/
+func (h *SiteViewsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	q := r.URL.Query()
+	views, err := h.Usecase.Run(ctx, q.Get("from"), q.Get("to"))
+	if err != nil {
+		if errors.Is(err, get_site_views.ErrInvalidPeriod) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to get site views: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, views); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
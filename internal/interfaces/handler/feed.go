@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shoet/blog/internal/feed"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/get_feed"
+)
+
+type FeedFormat string
+
+const (
+	FeedFormatRSS  FeedFormat = "rss"
+	FeedFormatAtom FeedFormat = "atom"
+	FeedFormatJSON FeedFormat = "json"
+)
+
+type FeedHandler struct {
+	Usecase *get_feed.Usecase
+	format  FeedFormat
+}
+
+func NewFeedHandler(usecase *get_feed.Usecase, format FeedFormat) *FeedHandler {
+	return &FeedHandler{
+		Usecase: usecase,
+		format:  format,
+	}
+}
+
+func (h *FeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+
+	var tag *string
+	if name := strings.TrimSpace(chi.URLParam(r, "name")); name != "" {
+		tag = &name
+	}
+
+	f, err := h.Usecase.Run(ctx, tag)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to get feed: %v", err))
+		if errors.Is(err, get_feed.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	f.FeedURL = fmt.Sprintf("https://%s%s", r.Host, r.URL.Path)
+
+	var body []byte
+	var contentType string
+	switch h.format {
+	case FeedFormatAtom:
+		body, err = f.Atom()
+		contentType = feed.ContentTypeAtom
+	case FeedFormatJSON:
+		body, err = f.JSON()
+		contentType = feed.ContentTypeJSON
+	default:
+		body, err = f.RSS()
+		contentType = feed.ContentTypeRSS
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to render feed: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondConditional(w, r, contentType, body, f.LastModified()); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond feed: %v", err))
+	}
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/create_preview_token"
+	"github.com/shoet/blog/internal/usecase/get_preview_tokens"
+	"github.com/shoet/blog/internal/usecase/revoke_preview_token"
+)
+
+type PreviewTokenCreateHandler struct {
+	Usecase          *create_preview_token.Usecase
+	Validator        *validator.Validate
+	DefaultExpiresIn time.Duration
+}
+
+func NewPreviewTokenCreateHandler(
+	usecase *create_preview_token.Usecase,
+	validator *validator.Validate,
+	defaultExpiresIn time.Duration,
+) *PreviewTokenCreateHandler {
+	return &PreviewTokenCreateHandler{
+		Usecase:          usecase,
+		Validator:        validator,
+		DefaultExpiresIn: defaultExpiresIn,
+	}
+}
+
+func (h *PreviewTokenCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	var reqBody struct {
+		ExpiresInSec *int `json:"expiresInSec" validate:"omitempty,min=60,max=2592000"`
+	}
+	defer r.Body.Close()
+	// リクエストボディは省略可能
+	if err := response.JsonToStruct(r, &reqBody); err != nil && !errors.Is(err, io.EOF) {
+		logger.Error(fmt.Sprintf("failed to parse request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	if err := h.Validator.Struct(reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to validate request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	expiresIn := h.DefaultExpiresIn
+	if reqBody.ExpiresInSec != nil {
+		expiresIn = time.Duration(*reqBody.ExpiresInSec) * time.Second
+	}
+	previewToken, err := h.Usecase.Run(ctx, models.BlogId(idInt), expiresIn)
+	if err != nil {
+		if errors.Is(err, create_preview_token.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to create preview token: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, previewToken); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type PreviewTokenListHandler struct {
+	Usecase *get_preview_tokens.Usecase
+}
+
+func NewPreviewTokenListHandler(usecase *get_preview_tokens.Usecase) *PreviewTokenListHandler {
+	return &PreviewTokenListHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *PreviewTokenListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	tokens, err := h.Usecase.Run(ctx, models.BlogId(idInt))
+	if err != nil {
+		if errors.Is(err, get_preview_tokens.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to list preview tokens: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, tokens); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type PreviewTokenRevokeHandler struct {
+	Usecase *revoke_preview_token.Usecase
+}
+
+func NewPreviewTokenRevokeHandler(usecase *revoke_preview_token.Usecase) *PreviewTokenRevokeHandler {
+	return &PreviewTokenRevokeHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *PreviewTokenRevokeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	tokenId := chi.URLParam(r, "tokenId")
+	if err := h.Usecase.Run(ctx, models.BlogId(idInt), models.PreviewTokenId(tokenId)); err != nil {
+		if errors.Is(err, revoke_preview_token.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to revoke preview token: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	res := struct {
+		Message string `json:"message"`
+	}{
+		Message: "ok",
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, res); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
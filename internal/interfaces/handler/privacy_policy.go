@@ -43,6 +43,7 @@ func (h *GetPrivacyPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	response.SetLastModified(w, privacyPolicy.Modified)
 	if err := response.RespondJSON(w, r, http.StatusOK, privacyPolicy); err != nil {
 		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
 	}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/get_reaction_emojis"
+	"github.com/shoet/blog/internal/usecase/toggle_reaction"
+)
+
+type ReactionEmojiListHandler struct {
+	Usecase *get_reaction_emojis.Usecase
+}
+
+func NewReactionEmojiListHandler(usecase *get_reaction_emojis.Usecase) *ReactionEmojiListHandler {
+	return &ReactionEmojiListHandler{
+		Usecase: usecase,
+	}
+}
+
+/*
+RequestBody:
+
+	path: /reactions/emojis
+
+Response:
+
+	emojis: []string
+*/
+func (h *ReactionEmojiListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	resp := struct {
+		Emojis []string `json:"emojis"`
+	}{
+		Emojis: h.Usecase.Run(ctx),
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, resp); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type ReactionToggleHandler struct {
+	Usecase   *toggle_reaction.Usecase
+	jwter     JWTService
+	Validator *validator.Validate
+}
+
+func NewReactionToggleHandler(
+	usecase *toggle_reaction.Usecase, jwter JWTService, validator *validator.Validate,
+) *ReactionToggleHandler {
+	return &ReactionToggleHandler{
+		Usecase:   usecase,
+		jwter:     jwter,
+		Validator: validator,
+	}
+}
+
+type ReactionToggleRequest struct {
+	UserId   *models.UserId `json:"userId"`
+	ClientId *string        `json:"clientId"`
+	Emoji    string         `json:"emoji" validate:"required"`
+}
+
+/*
+RequestBody:
+
+	path: /blogs/{id}/reactions
+	      /blogs/{id}/comments/{commentId}/reactions
+
+	application/json:
+		userId: int | null
+		clientId: string | null
+		emoji: string
+
+Response:
+
+	reacted: bool
+	reactions: []
+		emoji: string
+		count: int
+*/
+func (h *ReactionToggleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	var commentId *models.CommentId
+	if v := chi.URLParam(r, "commentId"); v != "" {
+		commentIdInt, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to convert comment id to int: %v", err))
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		cid := models.CommentId(commentIdInt)
+		commentId = &cid
+	}
+
+	defer r.Body.Close()
+
+	var req ReactionToggleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error(fmt.Sprintf("failed to decode request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	if err := h.Validator.Struct(req); err != nil {
+		logger.Error(fmt.Sprintf("failed to validate request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	if req.UserId == nil && req.ClientId == nil {
+		logger.Error("client_id or user_id is required")
+		response.RespondBadRequest(w, r, nil)
+		return
+	}
+
+	// UserIdによるリアクションは認証が必要
+	if req.UserId != nil {
+		token := r.Header.Get("Authorization")
+		if !strings.HasPrefix(token, "Bearer ") {
+			logger.Error("failed to get authorization token")
+			response.RespondUnauthorized(w, r, nil)
+			return
+		}
+		userId, err := h.jwter.VerifyToken(ctx, strings.TrimPrefix(token, "Bearer "))
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to verify token: %v", err))
+			response.RespondUnauthorized(w, r, err)
+			return
+		}
+		if userId != *req.UserId {
+			logger.Error("user_id is not matched")
+			response.RespondUnauthorized(w, r, nil)
+			return
+		}
+	}
+
+	reactor := &models.Reactor{UserId: req.UserId, ClientId: req.ClientId}
+	result, err := h.Usecase.Run(ctx, models.BlogId(idInt), commentId, req.Emoji, reactor)
+	if err != nil {
+		if errors.Is(err, toggle_reaction.ErrInvalidEmoji) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		if errors.Is(err, toggle_reaction.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to toggle reaction: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, result); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
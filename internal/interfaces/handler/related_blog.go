@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/get_related_blogs"
+)
+
+// defaultRelatedBlogsLimit は、limitが未指定の場合に返す関連ブログの件数
+const defaultRelatedBlogsLimit = 5
+
+type RelatedBlogListHandler struct {
+	Usecase *get_related_blogs.Usecase
+}
+
+func NewRelatedBlogListHandler(usecase *get_related_blogs.Usecase) *RelatedBlogListHandler {
+	return &RelatedBlogListHandler{
+		Usecase: usecase,
+	}
+}
+
+/*
+RequestBody:
+
+	path: /blogs/{id}/related?limit=N
+
+Response:
+
+	blogs: []Blog
+*/
+func (h *RelatedBlogListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	limit := defaultRelatedBlogsLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l < 1 {
+			err := fmt.Errorf("limit is invalid")
+			logger.Error(err.Error())
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		limit = l
+	}
+	blogs, err := h.Usecase.Run(ctx, models.BlogId(idInt), limit)
+	if err != nil {
+		if errors.Is(err, get_related_blogs.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to get related blogs: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	resp := struct {
+		Blogs []*models.Blog `json:"blogs"`
+	}{
+		Blogs: blogs,
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, resp); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
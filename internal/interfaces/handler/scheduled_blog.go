@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/cancel_scheduled_blog"
+	"github.com/shoet/blog/internal/usecase/get_scheduled_blogs"
+	"github.com/shoet/blog/internal/usecase/reschedule_blog"
+)
+
+type ScheduledBlogListHandler struct {
+	Usecase *get_scheduled_blogs.Usecase
+}
+
+func NewScheduledBlogListHandler(usecase *get_scheduled_blogs.Usecase) *ScheduledBlogListHandler {
+	return &ScheduledBlogListHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *ScheduledBlogListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	blogs, err := h.Usecase.Run(ctx)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to list scheduled blogs: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, blogs); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type ScheduledBlogPutHandler struct {
+	Usecase   *reschedule_blog.Usecase
+	Validator *validator.Validate
+}
+
+func NewScheduledBlogPutHandler(
+	usecase *reschedule_blog.Usecase,
+	validator *validator.Validate,
+) *ScheduledBlogPutHandler {
+	return &ScheduledBlogPutHandler{
+		Usecase:   usecase,
+		Validator: validator,
+	}
+}
+
+func (h *ScheduledBlogPutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	var reqBody struct {
+		PublishAt uint `json:"publishAt" validate:"required"`
+	}
+	defer r.Body.Close()
+	if err := response.JsonToStruct(r, &reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to parse request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	if err := h.Validator.Struct(reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to validate request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	blog, err := h.Usecase.Run(ctx, models.BlogId(idInt), reqBody.PublishAt)
+	if err != nil {
+		if errors.Is(err, reschedule_blog.ErrInvalidPublishAt) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		if errors.Is(err, reschedule_blog.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to reschedule blog: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, blog); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type ScheduledBlogDeleteHandler struct {
+	Usecase *cancel_scheduled_blog.Usecase
+}
+
+func NewScheduledBlogDeleteHandler(usecase *cancel_scheduled_blog.Usecase) *ScheduledBlogDeleteHandler {
+	return &ScheduledBlogDeleteHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *ScheduledBlogDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	blog, err := h.Usecase.Run(ctx, models.BlogId(idInt))
+	if err != nil {
+		if errors.Is(err, cancel_scheduled_blog.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to cancel scheduled blog: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, blog); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
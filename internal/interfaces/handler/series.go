@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/create_series"
+	"github.com/shoet/blog/internal/usecase/delete_series"
+	"github.com/shoet/blog/internal/usecase/get_series"
+	"github.com/shoet/blog/internal/usecase/get_series_list"
+	"github.com/shoet/blog/internal/usecase/put_series"
+)
+
+type SeriesRequestBody struct {
+	Title       string          `json:"title" validate:"required"`
+	Description string          `json:"description"`
+	BlogIds     []models.BlogId `json:"blogIds"`
+}
+
+type SeriesListHandler struct {
+	Usecase *get_series_list.Usecase
+}
+
+func NewSeriesListHandler(usecase *get_series_list.Usecase) *SeriesListHandler {
+	return &SeriesListHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *SeriesListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	series, err := h.Usecase.Run(ctx)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to list series: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, series); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type SeriesGetHandler struct {
+	Usecase *get_series.Usecase
+}
+
+func NewSeriesGetHandler(usecase *get_series.Usecase) *SeriesGetHandler {
+	return &SeriesGetHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *SeriesGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	series, err := h.Usecase.Run(ctx, models.SeriesId(idInt))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to get series: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if series == nil {
+		response.RespondNotFound(w, r, nil)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, series); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type SeriesAddHandler struct {
+	Usecase   *create_series.Usecase
+	Validator *validator.Validate
+}
+
+func NewSeriesAddHandler(
+	usecase *create_series.Usecase,
+	validator *validator.Validate,
+) *SeriesAddHandler {
+	return &SeriesAddHandler{
+		Usecase:   usecase,
+		Validator: validator,
+	}
+}
+
+func (h *SeriesAddHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	var reqBody SeriesRequestBody
+	defer r.Body.Close()
+	if err := response.JsonToStruct(r, &reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to parse request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	if err := h.Validator.Struct(reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to validate request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	series := &models.Series{
+		Title:       reqBody.Title,
+		Description: reqBody.Description,
+	}
+	newSeries, err := h.Usecase.Run(ctx, series, reqBody.BlogIds)
+	if err != nil {
+		if errors.Is(err, create_series.ErrInvalidBlogIds) ||
+			errors.Is(err, create_series.ErrBlogAlreadyInSeries) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to create series: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, newSeries); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type SeriesPutHandler struct {
+	Usecase   *put_series.Usecase
+	Validator *validator.Validate
+}
+
+func NewSeriesPutHandler(
+	usecase *put_series.Usecase,
+	validator *validator.Validate,
+) *SeriesPutHandler {
+	return &SeriesPutHandler{
+		Usecase:   usecase,
+		Validator: validator,
+	}
+}
+
+func (h *SeriesPutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	var reqBody SeriesRequestBody
+	defer r.Body.Close()
+	if err := response.JsonToStruct(r, &reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to parse request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	if err := h.Validator.Struct(reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to validate request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	series := &models.Series{
+		Id:          models.SeriesId(idInt),
+		Title:       reqBody.Title,
+		Description: reqBody.Description,
+	}
+	updated, err := h.Usecase.Run(ctx, series, reqBody.BlogIds)
+	if err != nil {
+		if errors.Is(err, put_series.ErrInvalidBlogIds) ||
+			errors.Is(err, put_series.ErrBlogAlreadyInSeries) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		if errors.Is(err, put_series.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to put series: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, updated); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type SeriesDeleteHandler struct {
+	Usecase *delete_series.Usecase
+}
+
+func NewSeriesDeleteHandler(usecase *delete_series.Usecase) *SeriesDeleteHandler {
+	return &SeriesDeleteHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *SeriesDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	if err := h.Usecase.Run(ctx, models.SeriesId(idInt)); err != nil {
+		if errors.Is(err, delete_series.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to delete series: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	resp := struct {
+		Id int `json:"id"`
+	}{
+		Id: idInt,
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, resp); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
@@ -17,6 +17,10 @@ type JWTService interface {
 	VerifyToken(ctx context.Context, token string) (models.UserId, error)
 }
 
+type PreviewTokenService interface {
+	Verify(ctx context.Context, token string) (models.BlogId, error)
+}
+
 type ContentsService interface {
 	GenerateThumbnailPutURL(fileName string) (presignedUrl, objectUrl string, err error)
 	GenerateContentImagePutURL(fileName string) (presignedUrl, objectUrl string, err error)
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/get_sitemap"
+)
+
+const (
+	contentTypeXML  = "application/xml; charset=utf-8"
+	contentTypeText = "text/plain; charset=utf-8"
+)
+
+type SitemapHandler struct {
+	Usecase *get_sitemap.Usecase
+}
+
+func NewSitemapHandler(usecase *get_sitemap.Usecase) *SitemapHandler {
+	return &SitemapHandler{
+		Usecase: usecase,
+	}
+}
+
+// ServeHTTP は、sitemap.xmlを返す
+// URLパラメータにpageがある場合は、sitemap indexから参照される分割したsitemapを返す
+func (h *SitemapHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+
+	docs, err := h.Usecase.Run(ctx)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to get sitemap: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+
+	body := docs.Sitemap
+	if p := chi.URLParam(r, "page"); p != "" {
+		page, err := strconv.Atoi(p)
+		if err != nil || page < 1 || page > len(docs.Pages) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		body = docs.Pages[page-1]
+	}
+	if err := response.RespondConditional(w, r, contentTypeXML, []byte(body), docs.LastModified); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond sitemap: %v", err))
+	}
+}
+
+type RobotsHandler struct {
+	Usecase *get_sitemap.Usecase
+}
+
+func NewRobotsHandler(usecase *get_sitemap.Usecase) *RobotsHandler {
+	return &RobotsHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *RobotsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+
+	docs, err := h.Usecase.Run(ctx)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to get robots.txt: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondConditional(w, r, contentTypeText, []byte(docs.Robots), docs.LastModified); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond robots.txt: %v", err))
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/merge_tag"
+	"github.com/shoet/blog/internal/usecase/put_tag"
+)
+
+type TagPutHandler struct {
+	Usecase   *put_tag.Usecase
+	Validator *validator.Validate
+}
+
+func NewTagPutHandler(
+	usecase *put_tag.Usecase,
+	validator *validator.Validate,
+) *TagPutHandler {
+	return &TagPutHandler{
+		Usecase:   usecase,
+		Validator: validator,
+	}
+}
+
+func (h *TagPutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	var reqBody struct {
+		Name        string  `json:"name" validate:"required,max=255"`
+		Description string  `json:"description"`
+		Color       *string `json:"color" validate:"omitempty,hexcolor"`
+	}
+	defer r.Body.Close()
+	if err := response.JsonToStruct(r, &reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to parse request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	if err := h.Validator.Struct(reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to validate request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	tag := &models.Tag{
+		Id:          models.TagId(idInt),
+		Name:        reqBody.Name,
+		Description: reqBody.Description,
+		Color:       reqBody.Color,
+	}
+	updated, err := h.Usecase.Run(ctx, tag)
+	if err != nil {
+		if errors.Is(err, put_tag.ErrTagNameConflict) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		if errors.Is(err, put_tag.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to put tag: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, updated); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type TagMergeHandler struct {
+	Usecase   *merge_tag.Usecase
+	Validator *validator.Validate
+}
+
+func NewTagMergeHandler(
+	usecase *merge_tag.Usecase,
+	validator *validator.Validate,
+) *TagMergeHandler {
+	return &TagMergeHandler{
+		Usecase:   usecase,
+		Validator: validator,
+	}
+}
+
+func (h *TagMergeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	var reqBody struct {
+		IntoTagId models.TagId `json:"intoTagId" validate:"required"`
+	}
+	defer r.Body.Close()
+	if err := response.JsonToStruct(r, &reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to parse request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	if err := h.Validator.Struct(reqBody); err != nil {
+		logger.Error(fmt.Sprintf("failed to validate request body: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	merged, err := h.Usecase.Run(ctx, models.TagId(idInt), reqBody.IntoTagId)
+	if err != nil {
+		if errors.Is(err, merge_tag.ErrSameTag) {
+			response.RespondBadRequest(w, r, err)
+			return
+		}
+		if errors.Is(err, merge_tag.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to merge tag: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, merged); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/get_trashed_blogs"
+	"github.com/shoet/blog/internal/usecase/purge_blog"
+	"github.com/shoet/blog/internal/usecase/restore_blog"
+)
+
+type TrashedBlogListHandler struct {
+	Usecase *get_trashed_blogs.Usecase
+}
+
+func NewTrashedBlogListHandler(usecase *get_trashed_blogs.Usecase) *TrashedBlogListHandler {
+	return &TrashedBlogListHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *TrashedBlogListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	blogs, err := h.Usecase.Run(ctx)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to list trashed blogs: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, blogs); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type TrashedBlogRestoreHandler struct {
+	Usecase *restore_blog.Usecase
+}
+
+func NewTrashedBlogRestoreHandler(usecase *restore_blog.Usecase) *TrashedBlogRestoreHandler {
+	return &TrashedBlogRestoreHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *TrashedBlogRestoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	blog, err := h.Usecase.Run(ctx, models.BlogId(idInt))
+	if err != nil {
+		if errors.Is(err, restore_blog.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to restore blog: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, blog); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
+
+type TrashedBlogDeleteHandler struct {
+	Usecase *purge_blog.Usecase
+}
+
+func NewTrashedBlogDeleteHandler(usecase *purge_blog.Usecase) *TrashedBlogDeleteHandler {
+	return &TrashedBlogDeleteHandler{
+		Usecase: usecase,
+	}
+}
+
+func (h *TrashedBlogDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	id := chi.URLParam(r, "id")
+	idInt, err := strconv.Atoi(strings.TrimSpace(id))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to convert id to int: %v", err))
+		response.RespondBadRequest(w, r, err)
+		return
+	}
+	blogId, err := h.Usecase.Run(ctx, models.BlogId(idInt))
+	if err != nil {
+		if errors.Is(err, purge_blog.ErrResourceNotFound) {
+			response.RespondNotFound(w, r, err)
+			return
+		}
+		logger.Error(fmt.Sprintf("failed to purge blog: %v", err))
+		response.RespondInternalServerError(w, r, err)
+		return
+	}
+	resp := struct {
+		Id int `json:"id"`
+	}{
+		Id: int(blogId),
+	}
+	if err := response.RespondJSON(w, r, http.StatusOK, resp); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json response: %v", err))
+	}
+}
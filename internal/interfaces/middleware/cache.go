@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shoet/blog/internal/interfaces/response"
+	"github.com/shoet/blog/internal/logging"
+)
+
+// NewConditionalCacheMiddleware は、GETのレスポンスに検証用のETagとCache-Controlを付与し
+// 条件付きリクエスト(If-None-Match/If-Modified-Since)でキャッシュが有効な場合は304を返すミドルウェアを返す
+// ETagはレスポンスボディから生成し、Last-Modifiedはハンドラーが設定した場合のみ判定に使用する
+// 翻訳やリアクションなどバージョンが変わらない変更や言語ごとの違いも反映するため、ハンドラーが設定したETagは使用しない
+// 認証付き・プレビューのリクエストは内容が閲覧者によって変わるため、キャッシュさせずにそのまま返す
+// cacheControlが空の場合は、Cache-Controlを付与せず検証のみ行う
+func NewConditionalCacheMiddleware(cacheControl string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// 共有キャッシュが認証の有無で異なる内容を返さないようにする
+			w.Header().Add("Vary", "Authorization")
+			if r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if isPersonalizedRequest(r) {
+				w.Header().Set("Cache-Control", "private, no-store")
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &responseRecorder{header: w.Header(), status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			// エラーやリダイレクトは検証の対象としない
+			if rec.status != http.StatusOK {
+				writeRecorded(w, r, rec)
+				return
+			}
+			etag := response.ETag(rec.body.Bytes())
+			w.Header().Set("ETag", etag)
+			if cacheControl != "" {
+				w.Header().Set("Cache-Control", cacheControl)
+			}
+			var lastModified time.Time
+			if lm := w.Header().Get("Last-Modified"); lm != "" {
+				if t, err := http.ParseTime(lm); err == nil {
+					lastModified = t
+				}
+			}
+			if response.IsNotModified(r, etag, lastModified) {
+				w.Header().Del("Content-Type")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			writeRecorded(w, r, rec)
+		})
+	}
+}
+
+// isPersonalizedRequest は、認証付きかプレビューのリクエストかを判定する
+func isPersonalizedRequest(r *http.Request) bool {
+	return r.Header.Get("Authorization") != "" || r.URL.Query().Has("preview")
+}
+
+// responseRecorder は、ETagを生成するためにハンドラーのレスポンスボディを保持する
+// ヘッダーは元のResponseWriterのものをそのまま使用する
+type responseRecorder struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *responseRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.wroteHeader {
+		return
+	}
+	rec.status = status
+	rec.wroteHeader = true
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.WriteHeader(http.StatusOK)
+	return rec.body.Write(b)
+}
+
+func writeRecorded(w http.ResponseWriter, r *http.Request, rec *responseRecorder) {
+	w.WriteHeader(rec.status)
+	if _, err := w.Write(rec.body.Bytes()); err != nil {
+		logging.GetLogger(r.Context()).Error(fmt.Sprintf("failed to write response body: %v", err))
+	}
+}
@@ -21,7 +21,9 @@ func NewCORSMiddleWare(cfg *config.Config) func(next http.Handler) http.Handler
 			if originAllowed(origin, whiteList) {
 				w.Header().Set("Access-Control-Allow-Origin", origin)
 			}
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization,If-Match")
+			// 更新時にIf-Matchで指定するため、ETagとバージョンをブラウザから参照できるようにする
+			w.Header().Set("Access-Control-Expose-Headers", "ETag,"+response.VersionHeader)
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 			w.Header().Set("Access-Control-Allow-Methods", "GET,PUT,POST,DELETE,UPDATE,OPTIONS")
 			w.Header().Set("Content-Type", "application/json")
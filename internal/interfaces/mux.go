@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
@@ -15,55 +16,101 @@ import (
 	"github.com/shoet/blog/internal/infrastructure/services/blog_service"
 	"github.com/shoet/blog/internal/infrastructure/services/contents_service"
 	"github.com/shoet/blog/internal/infrastructure/services/jwt_service"
+	"github.com/shoet/blog/internal/infrastructure/services/preview_token_service"
+	"github.com/shoet/blog/internal/infrastructure/services/related_blogs_cache_service"
+	"github.com/shoet/blog/internal/infrastructure/services/sitemap_cache_service"
+	"github.com/shoet/blog/internal/infrastructure/services/view_counter_service"
 	"github.com/shoet/blog/internal/interfaces/cookie"
 	"github.com/shoet/blog/internal/interfaces/handler"
 	"github.com/shoet/blog/internal/interfaces/middleware"
 	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/options"
+	"github.com/shoet/blog/internal/usecase/cancel_scheduled_blog"
+	"github.com/shoet/blog/internal/usecase/count_blog_view"
 	"github.com/shoet/blog/internal/usecase/create_blog"
+	"github.com/shoet/blog/internal/usecase/create_preview_token"
+	"github.com/shoet/blog/internal/usecase/create_series"
 	"github.com/shoet/blog/internal/usecase/create_user_profile"
 	"github.com/shoet/blog/internal/usecase/delete_blog"
+	"github.com/shoet/blog/internal/usecase/delete_blog_translation"
 	"github.com/shoet/blog/internal/usecase/delete_privacy_policy"
+	"github.com/shoet/blog/internal/usecase/delete_series"
+	"github.com/shoet/blog/internal/usecase/diff_blog_revisions"
+	"github.com/shoet/blog/internal/usecase/get_archive_blogs"
+	"github.com/shoet/blog/internal/usecase/get_archives"
+	"github.com/shoet/blog/internal/usecase/get_blog_by_slug"
 	"github.com/shoet/blog/internal/usecase/get_blog_detail"
+	"github.com/shoet/blog/internal/usecase/get_blog_revision"
+	"github.com/shoet/blog/internal/usecase/get_blog_revisions"
+	"github.com/shoet/blog/internal/usecase/get_blog_views"
 	"github.com/shoet/blog/internal/usecase/get_blogs"
 	"github.com/shoet/blog/internal/usecase/get_blogs_offset_paging"
 	"github.com/shoet/blog/internal/usecase/get_comments"
+	"github.com/shoet/blog/internal/usecase/get_feed"
 	"github.com/shoet/blog/internal/usecase/get_github_contributions"
 	"github.com/shoet/blog/internal/usecase/get_github_contributions_latest_week"
 	"github.com/shoet/blog/internal/usecase/get_handlename"
+	"github.com/shoet/blog/internal/usecase/get_preview_tokens"
 	"github.com/shoet/blog/internal/usecase/get_privacy_policy"
+	"github.com/shoet/blog/internal/usecase/get_reaction_emojis"
+	"github.com/shoet/blog/internal/usecase/get_related_blogs"
+	"github.com/shoet/blog/internal/usecase/get_scheduled_blogs"
+	"github.com/shoet/blog/internal/usecase/get_series"
+	"github.com/shoet/blog/internal/usecase/get_series_list"
+	"github.com/shoet/blog/internal/usecase/get_site_views"
+	"github.com/shoet/blog/internal/usecase/get_sitemap"
 	"github.com/shoet/blog/internal/usecase/get_tags"
+	"github.com/shoet/blog/internal/usecase/get_trashed_blogs"
 	"github.com/shoet/blog/internal/usecase/get_user_profile"
 	"github.com/shoet/blog/internal/usecase/login_user"
 	"github.com/shoet/blog/internal/usecase/login_user_session"
+	"github.com/shoet/blog/internal/usecase/merge_tag"
 	"github.com/shoet/blog/internal/usecase/post_comment"
+	"github.com/shoet/blog/internal/usecase/purge_blog"
 	"github.com/shoet/blog/internal/usecase/put_blog"
+	"github.com/shoet/blog/internal/usecase/put_blog_translation"
 	"github.com/shoet/blog/internal/usecase/put_privacy_policy"
+	"github.com/shoet/blog/internal/usecase/put_series"
+	"github.com/shoet/blog/internal/usecase/put_tag"
+	"github.com/shoet/blog/internal/usecase/reschedule_blog"
+	"github.com/shoet/blog/internal/usecase/restore_blog"
+	"github.com/shoet/blog/internal/usecase/restore_blog_revision"
+	"github.com/shoet/blog/internal/usecase/revoke_preview_token"
 	"github.com/shoet/blog/internal/usecase/storage_presigned_content"
 	"github.com/shoet/blog/internal/usecase/storage_presigned_thumbnail"
+	"github.com/shoet/blog/internal/usecase/toggle_reaction"
 	"github.com/shoet/blog/internal/usecase/update_public_status"
 	"github.com/shoet/blog/internal/usecase/update_user_profile"
 	"github.com/shoet/blog/internal/usecase/upload_file"
 )
 
 type MuxDependencies struct {
-	Config                  *config.Config
-	DB                      infrastructure.DB
-	KVS                     *infrastructure.RedisKVS
-	BlogRepository          *repository.BlogRepository
-	BlogRepositoryOffset    *repository.BlogRepositoryOffset
-	CommentRepository       *repository.CommentRepository
-	FileRepository          *repository.FileRepository
-	UserProfileRepository   *repository.UserProfileRepository
-	PrivacyPolicyRepository *repository.PrivacyPolicyRepository
-	BlogService             *blog_service.BlogService
-	AuthService             *auth_service.AuthService
-	ContentsService         *contents_service.ContentsService
-	JWTer                   *jwt_service.JWTService
-	Logger                  *logging.Logger
-	Validator               *validator.Validate
-	Cookie                  *cookie.CookieController
-	GitHubAPIAdapter        *adapter.GitHubV4APIClient
-	Clocker                 clocker.Clocker
+	Config                   *config.Config
+	DB                       infrastructure.DB
+	KVS                      *infrastructure.RedisKVS
+	BlogRepository           *repository.BlogRepository
+	BlogRevisionRepository   *repository.BlogRevisionRepository
+	CommentRepository        *repository.CommentRepository
+	FileRepository           *repository.FileRepository
+	UserProfileRepository    *repository.UserProfileRepository
+	PrivacyPolicyRepository  *repository.PrivacyPolicyRepository
+	SeriesRepository         *repository.SeriesRepository
+	ReactionRepository       *repository.ReactionRepository
+	BlogService              *blog_service.BlogService
+	AuthService              *auth_service.AuthService
+	ContentsService          *contents_service.ContentsService
+	JWTer                    *jwt_service.JWTService
+	PreviewTokenService      *preview_token_service.PreviewTokenService
+	SitemapCacheService      *sitemap_cache_service.SitemapCacheService
+	RelatedBlogsCacheService *related_blogs_cache_service.RelatedBlogsCacheService
+	ViewCounterService       *view_counter_service.ViewCounterService
+	BlogCursorCodec          *options.BlogCursorCodec
+	Logger                   *logging.Logger
+	Validator                *validator.Validate
+	Cookie                   *cookie.CookieController
+	GitHubAPIAdapter         *adapter.GitHubV4APIClient
+	Clocker                  clocker.Clocker
+	SiteLocation             *time.Location
 }
 
 func NewMux(
@@ -78,12 +125,18 @@ func NewMux(
 	log.Printf("set routes")
 	setHealthRoute(router)
 	setBlogsRoute(router, deps, authMiddleWare)
-	setTagsRoute(router, deps)
+	setTagsRoute(router, deps, authMiddleWare)
+	setSeriesRoute(router, deps, authMiddleWare)
+	setReactionsRoute(router, deps)
+	setFeedRoute(router, deps)
+	setSitemapRoute(router, deps)
+	setArchivesRoute(router, deps)
 	setFilesRoute(router, deps, authMiddleWare)
 	setAuthRoute(router, deps)
 	setAdminRoute(router, deps, authMiddleWare)
 	setGitHubRoute(router, deps)
 	setUserProfileRoute(router, deps, authMiddleWare)
+	setUsersRoute(router, deps)
 	setHandlenameRoute(router, deps)
 	setPrivacyPolicyRoute(router, deps, authMiddleWare)
 	return router, nil
@@ -102,58 +155,232 @@ func setBlogsRoute(
 	r chi.Router, deps *MuxDependencies, authMiddleWare *middleware.AuthorizationMiddleware,
 ) {
 	r.Route("/blogs", func(r chi.Router) {
-		blh := handler.NewBlogListHandler(get_blogs.NewUsecase(deps.DB, deps.BlogRepository))
-		r.Get("/", blh.ServeHTTP)
+		blh := handler.NewBlogListHandler(get_blogs.NewUsecase(
+			deps.DB, deps.BlogRepository, deps.ReactionRepository, deps.UserProfileRepository, deps.BlogCursorCodec,
+			deps.SiteLocation))
+		r.With(middleware.NewConditionalCacheMiddleware(deps.Config.CacheControlBlogList)).Get("/", blh.ServeHTTP)
 
 		bah := handler.NewBlogAddHandler(
-			create_blog.NewUsecase(deps.DB, deps.BlogRepository, deps.BlogService),
+			create_blog.NewUsecase(
+				deps.DB, deps.BlogRepository, deps.BlogRevisionRepository, deps.BlogService, deps.SitemapCacheService),
 			deps.Validator)
 		r.With(authMiddleWare.Middleware).Post("/", bah.ServeHTTP)
 
-		bgh := handler.NewBlogGetHandler(
-			get_blog_detail.NewUsecase(deps.DB, deps.BlogRepository, deps.CommentRepository), deps.JWTer)
-		r.Get("/{id}", bgh.ServeHTTP)
+		blogDetailUsecase := get_blog_detail.NewUsecase(
+			deps.DB, deps.BlogRepository, deps.CommentRepository, deps.SeriesRepository, deps.ReactionRepository,
+			deps.UserProfileRepository)
+		blogDetailCache := middleware.NewConditionalCacheMiddleware(deps.Config.CacheControlBlogDetail)
+		bgh := handler.NewBlogGetHandler(blogDetailUsecase, deps.JWTer, deps.PreviewTokenService)
+		r.With(blogDetailCache).Get("/{id}", bgh.ServeHTTP)
+
+		bsh := handler.NewBlogGetBySlugHandler(
+			get_blog_by_slug.NewUsecase(deps.DB, deps.BlogRepository, blogDetailUsecase),
+			deps.JWTer, deps.PreviewTokenService)
+		r.With(blogDetailCache).Get("/slug/{slug}", bsh.ServeHTTP)
 
 		bdh := handler.NewBlogDeleteHandler(
-			delete_blog.NewUsecase(deps.DB, deps.BlogRepository), deps.Validator)
+			delete_blog.NewUsecase(
+				deps.DB, deps.BlogRepository, deps.SitemapCacheService, deps.Clocker),
+			deps.Validator)
 		r.With(authMiddleWare.Middleware).Delete("/{id}", bdh.ServeHTTP)
 
-		buh := handler.NewBlogPutHandler(
-			put_blog.NewUsecase(deps.DB, deps.BlogRepository), deps.Validator)
+		putBlogUsecase := put_blog.NewUsecase(
+			deps.DB, deps.BlogRepository, deps.BlogRevisionRepository,
+			deps.SitemapCacheService, deps.RelatedBlogsCacheService)
+		buh := handler.NewBlogPutHandler(putBlogUsecase, deps.Validator)
 		r.With(authMiddleWare.Middleware).Put("/{id}", buh.ServeHTTP)
 
+		// translations
+		r.Route("/{id}/translations/{locale}", func(r chi.Router) {
+			r.Use(authMiddleWare.Middleware)
+
+			pth := handler.NewBlogTranslationPutHandler(
+				put_blog_translation.NewUsecase(deps.DB, deps.BlogRepository), deps.Validator)
+			r.Put("/", pth.ServeHTTP)
+
+			dth := handler.NewBlogTranslationDeleteHandler(
+				delete_blog_translation.NewUsecase(deps.DB, deps.BlogRepository))
+			r.Delete("/", dth.ServeHTTP)
+		})
+
+		rbh := handler.NewRelatedBlogListHandler(
+			get_related_blogs.NewUsecase(deps.DB, deps.BlogRepository, deps.RelatedBlogsCacheService, deps.Clocker))
+		r.Get("/{id}/related", rbh.ServeHTTP)
+
+		// reactions
+		rth := handler.NewReactionToggleHandler(
+			toggle_reaction.NewUsecase(
+				deps.Config, deps.DB, deps.BlogRepository, deps.CommentRepository, deps.ReactionRepository),
+			deps.JWTer, deps.Validator)
+		r.Post("/{id}/reactions", rth.ServeHTTP)
+
+		bvh := handler.NewBlogViewCountHandler(
+			count_blog_view.NewUsecase(deps.DB, deps.BlogRepository, deps.ViewCounterService, deps.Clocker))
+		r.Post("/{id}/views", bvh.ServeHTTP)
+
+		// revisions
+		r.Route("/{id}/revisions", func(r chi.Router) {
+			r.Use(authMiddleWare.Middleware)
+
+			lrh := handler.NewBlogRevisionListHandler(
+				get_blog_revisions.NewUsecase(deps.DB, deps.BlogRepository, deps.BlogRevisionRepository))
+			r.Get("/", lrh.ServeHTTP)
+
+			drh := handler.NewBlogRevisionDiffHandler(
+				diff_blog_revisions.NewUsecase(deps.DB, deps.BlogRepository, deps.BlogRevisionRepository))
+			r.Get("/diff", drh.ServeHTTP)
+
+			grh := handler.NewBlogRevisionGetHandler(
+				get_blog_revision.NewUsecase(deps.DB, deps.BlogRepository, deps.BlogRevisionRepository))
+			r.Get("/{revision}", grh.ServeHTTP)
+
+			rrh := handler.NewBlogRevisionRestoreHandler(
+				restore_blog_revision.NewUsecase(
+					deps.DB, deps.BlogRepository, deps.BlogRevisionRepository, putBlogUsecase))
+			r.Post("/{revision}/restore", rrh.ServeHTTP)
+		})
+
+		// preview tokens
+		r.Route("/{id}/preview_tokens", func(r chi.Router) {
+			r.Use(authMiddleWare.Middleware)
+
+			cph := handler.NewPreviewTokenCreateHandler(
+				create_preview_token.NewUsecase(deps.DB, deps.BlogRepository, deps.PreviewTokenService),
+				deps.Validator,
+				time.Duration(deps.Config.PreviewTokenExpiresInSec)*time.Second)
+			r.Post("/", cph.ServeHTTP)
+
+			lph := handler.NewPreviewTokenListHandler(
+				get_preview_tokens.NewUsecase(deps.DB, deps.BlogRepository, deps.PreviewTokenService))
+			r.Get("/", lph.ServeHTTP)
+
+			rph := handler.NewPreviewTokenRevokeHandler(
+				revoke_preview_token.NewUsecase(deps.DB, deps.BlogRepository, deps.PreviewTokenService))
+			r.Delete("/{tokenId}", rph.ServeHTTP)
+		})
+
 		// comments
 		r.Route("/{id}/comments", func(r chi.Router) {
 			gch := handler.NewGetCommentsHandler(
-				get_comments.NewUsecase(deps.DB, deps.CommentRepository, deps.UserProfileRepository),
+				get_comments.NewUsecase(
+					deps.DB, deps.CommentRepository, deps.UserProfileRepository, deps.ReactionRepository),
 			)
-			r.Get("/", gch.ServeHTTP)
+			r.With(middleware.NewConditionalCacheMiddleware(deps.Config.CacheControlComments)).Get("/", gch.ServeHTTP)
 
 			pch := handler.NewPostCommentHandler(
 				post_comment.NewUsecase(deps.DB, deps.CommentRepository), deps.JWTer, deps.Validator)
 			r.Post("/", pch.ServeHTTP)
+
+			r.Post("/{commentId}/reactions", rth.ServeHTTP)
 		})
 	})
 
 	r.Route("/v2/blogs", func(r chi.Router) {
 		blh := handler.NewBlogGetOffsetPagingHandler(
-			get_blogs_offset_paging.NewUsecase(deps.DB, deps.BlogRepositoryOffset),
+			get_blogs_offset_paging.NewUsecase(deps.DB, deps.BlogRepository, deps.UserProfileRepository, deps.SiteLocation),
 		)
 		r.Get("/", blh.ServeHTTP)
 	})
 
 	upsh := handler.NewBlogUpdatePublicStatusHandler(
 		deps.Validator,
-		update_public_status.NewUsecase(deps.DB, deps.BlogRepository),
+		update_public_status.NewUsecase(
+			deps.DB, deps.BlogRepository, deps.SitemapCacheService, deps.RelatedBlogsCacheService),
 	)
 	r.With(authMiddleWare.Middleware).Post("/update_public_status", upsh.ServeHTTP)
 }
 
 // tags
-func setTagsRoute(r chi.Router, deps *MuxDependencies) {
+func setTagsRoute(
+	r chi.Router, deps *MuxDependencies, authMiddleWare *middleware.AuthorizationMiddleware,
+) {
 	r.Route("/tags", func(r chi.Router) {
 		th := handler.NewTagListHandler(*get_tags.NewUsecase(deps.DB, deps.BlogRepository))
-		r.Get("/", th.ServeHTTP)
+		r.With(middleware.NewConditionalCacheMiddleware(deps.Config.CacheControlTags)).Get("/", th.ServeHTTP)
+
+		tph := handler.NewTagPutHandler(
+			put_tag.NewUsecase(
+				deps.DB, deps.BlogRepository, deps.SitemapCacheService, deps.RelatedBlogsCacheService),
+			deps.Validator,
+		)
+		r.With(authMiddleWare.Middleware).Put("/{id}", tph.ServeHTTP)
+
+		tmh := handler.NewTagMergeHandler(
+			merge_tag.NewUsecase(
+				deps.DB, deps.BlogRepository, deps.SitemapCacheService, deps.RelatedBlogsCacheService),
+			deps.Validator,
+		)
+		r.With(authMiddleWare.Middleware).Post("/{id}/merge", tmh.ServeHTTP)
+	})
+}
+
+// series
+func setSeriesRoute(
+	r chi.Router, deps *MuxDependencies, authMiddleWare *middleware.AuthorizationMiddleware,
+) {
+	r.Route("/series", func(r chi.Router) {
+		slh := handler.NewSeriesListHandler(get_series_list.NewUsecase(deps.DB, deps.SeriesRepository))
+		r.Get("/", slh.ServeHTTP)
+
+		sgh := handler.NewSeriesGetHandler(get_series.NewUsecase(deps.DB, deps.SeriesRepository))
+		r.Get("/{id}", sgh.ServeHTTP)
+
+		sah := handler.NewSeriesAddHandler(
+			create_series.NewUsecase(deps.DB, deps.BlogRepository, deps.SeriesRepository), deps.Validator)
+		r.With(authMiddleWare.Middleware).Post("/", sah.ServeHTTP)
+
+		sph := handler.NewSeriesPutHandler(
+			put_series.NewUsecase(deps.DB, deps.BlogRepository, deps.SeriesRepository), deps.Validator)
+		r.With(authMiddleWare.Middleware).Put("/{id}", sph.ServeHTTP)
+
+		sdh := handler.NewSeriesDeleteHandler(delete_series.NewUsecase(deps.DB, deps.SeriesRepository))
+		r.With(authMiddleWare.Middleware).Delete("/{id}", sdh.ServeHTTP)
+	})
+}
+
+// reactions
+func setReactionsRoute(r chi.Router, deps *MuxDependencies) {
+	r.Route("/reactions", func(r chi.Router) {
+		reh := handler.NewReactionEmojiListHandler(get_reaction_emojis.NewUsecase(deps.Config))
+		r.Get("/emojis", reh.ServeHTTP)
+	})
+}
+
+// feeds
+func setFeedRoute(r chi.Router, deps *MuxDependencies) {
+	feedUsecase := get_feed.NewUsecase(deps.Config, deps.DB, deps.BlogRepository)
+	formats := map[string]handler.FeedFormat{
+		"rss":  handler.FeedFormatRSS,
+		"atom": handler.FeedFormatAtom,
+		"json": handler.FeedFormatJSON,
+	}
+	for ext, format := range formats {
+		fh := handler.NewFeedHandler(feedUsecase, format)
+		r.Get("/feed."+ext, fh.ServeHTTP)
+		r.Get("/tags/{name}/feed."+ext, fh.ServeHTTP)
+	}
+}
+
+// sitemap
+func setSitemapRoute(r chi.Router, deps *MuxDependencies) {
+	sitemapUsecase := get_sitemap.NewUsecase(deps.Config, deps.DB, deps.BlogRepository, deps.SitemapCacheService)
+	sh := handler.NewSitemapHandler(sitemapUsecase)
+	r.Get("/sitemap.xml", sh.ServeHTTP)
+	r.Get("/sitemaps/{page}.xml", sh.ServeHTTP)
+	rh := handler.NewRobotsHandler(sitemapUsecase)
+	r.Get("/robots.txt", rh.ServeHTTP)
+}
+
+// archives
+func setArchivesRoute(r chi.Router, deps *MuxDependencies) {
+	r.Route("/archives", func(r chi.Router) {
+		ah := handler.NewArchiveListHandler(
+			get_archives.NewUsecase(deps.DB, deps.BlogRepository, deps.SiteLocation))
+		r.Get("/", ah.ServeHTTP)
+
+		abh := handler.NewArchiveBlogListHandler(
+			get_archive_blogs.NewUsecase(deps.DB, deps.BlogRepository, deps.UserProfileRepository, deps.SiteLocation))
+		r.Get("/{year}/{month}", abh.ServeHTTP)
 	})
 }
 
@@ -201,8 +428,39 @@ func setAdminRoute(
 	r chi.Router, deps *MuxDependencies, authMiddleWare *middleware.AuthorizationMiddleware,
 ) {
 	r.Route("/admin", func(r chi.Router) {
-		bla := handler.NewBlogListAdminHandler(get_blogs.NewUsecase(deps.DB, deps.BlogRepository))
+		bla := handler.NewBlogListAdminHandler(get_blogs.NewUsecase(
+			deps.DB, deps.BlogRepository, deps.ReactionRepository, deps.UserProfileRepository, deps.BlogCursorCodec,
+			deps.SiteLocation))
 		r.With(authMiddleWare.Middleware).Get("/blogs", bla.ServeHTTP)
+
+		// scheduled publishing
+		sbl := handler.NewScheduledBlogListHandler(get_scheduled_blogs.NewUsecase(deps.DB, deps.BlogRepository))
+		r.With(authMiddleWare.Middleware).Get("/scheduled_blogs", sbl.ServeHTTP)
+
+		sbp := handler.NewScheduledBlogPutHandler(
+			reschedule_blog.NewUsecase(deps.DB, deps.BlogRepository, deps.Clocker), deps.Validator)
+		r.With(authMiddleWare.Middleware).Put("/scheduled_blogs/{id}", sbp.ServeHTTP)
+
+		sbd := handler.NewScheduledBlogDeleteHandler(cancel_scheduled_blog.NewUsecase(deps.DB, deps.BlogRepository))
+		r.With(authMiddleWare.Middleware).Delete("/scheduled_blogs/{id}", sbd.ServeHTTP)
+
+		// trash
+		tbl := handler.NewTrashedBlogListHandler(get_trashed_blogs.NewUsecase(deps.DB, deps.BlogRepository))
+		r.With(authMiddleWare.Middleware).Get("/trashed_blogs", tbl.ServeHTTP)
+
+		tbr := handler.NewTrashedBlogRestoreHandler(
+			restore_blog.NewUsecase(deps.DB, deps.BlogRepository, deps.SitemapCacheService))
+		r.With(authMiddleWare.Middleware).Post("/trashed_blogs/{id}/restore", tbr.ServeHTTP)
+
+		tbd := handler.NewTrashedBlogDeleteHandler(purge_blog.NewUsecase(deps.DB, deps.BlogRepository, deps.SeriesRepository))
+		r.With(authMiddleWare.Middleware).Delete("/trashed_blogs/{id}", tbd.ServeHTTP)
+
+		// views
+		bvh := handler.NewBlogViewsHandler(get_blog_views.NewUsecase(deps.DB, deps.BlogRepository, deps.Clocker))
+		r.With(authMiddleWare.Middleware).Get("/blogs/{id}/views", bvh.ServeHTTP)
+
+		svh := handler.NewSiteViewsHandler(get_site_views.NewUsecase(deps.DB, deps.BlogRepository, deps.Clocker))
+		r.With(authMiddleWare.Middleware).Get("/views", svh.ServeHTTP)
 	})
 }
 
@@ -242,6 +500,22 @@ func setUserProfileRoute(
 	})
 }
 
+// users
+// 著者ページとして、ユーザーの公開プロフィールと公開済みのブログを提供する
+func setUsersRoute(r chi.Router, deps *MuxDependencies) {
+	r.Route("/users", func(r chi.Router) {
+		agh := handler.NewAuthorGetHandler(
+			get_user_profile.NewUsecase(deps.Config, deps.DB, deps.UserProfileRepository))
+		r.Get("/{id}", agh.ServeHTTP)
+
+		ablh := handler.NewAuthorBlogListHandler(
+			get_blogs.NewUsecase(
+				deps.DB, deps.BlogRepository, deps.ReactionRepository, deps.UserProfileRepository, deps.BlogCursorCodec,
+				deps.SiteLocation))
+		r.Get("/{id}/blogs", ablh.ServeHTTP)
+	})
+}
+
 // privacy policy
 func setPrivacyPolicyRoute(
 	r chi.Router, deps *MuxDependencies, authMiddleWare *middleware.AuthorizationMiddleware,
@@ -250,7 +524,8 @@ func setPrivacyPolicyRoute(
 		getPrivacyPolicyHandler := handler.NewGetPrivacyPolicyHandler(
 			get_privacy_policy.NewUsecase(deps.DB, deps.PrivacyPolicyRepository),
 		)
-		r.Get("/{id}", getPrivacyPolicyHandler.ServeHTTP)
+		r.With(middleware.NewConditionalCacheMiddleware(deps.Config.CacheControlPrivacyPolicy)).
+			Get("/{id}", getPrivacyPolicyHandler.ServeHTTP)
 
 		putPrivacyPolicyHandler := handler.NewPutPrivacyPolicyHandler(
 			put_privacy_policy.NewUsecase(deps.DB, deps.PrivacyPolicyRepository),
@@ -0,0 +1,108 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ETag は、レスポンスボディから強いETagを生成する
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return fmt.Sprintf(`"%s"`, hex.EncodeToString(sum[:16]))
+}
+
+// VersionETag は、リソースのバージョンから強いETagを生成する
+// 更新時のIf-Matchの検証に使用する
+func VersionETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// VersionHeader は、更新時のIf-Matchに指定するバージョンを返すヘッダー
+// GETのETagはレスポンスボディから生成するため、VersionETagはこのヘッダーで別に返す
+const VersionHeader = "X-Resource-Version"
+
+// SetVersion は、VersionETagで生成したバージョンをVersionHeaderとETagに設定する
+// GETではETagは条件付きGETのミドルウェアでレスポンスボディのものに置き換えられる
+func SetVersion(w http.ResponseWriter, version int64) {
+	etag := VersionETag(version)
+	w.Header().Set(VersionHeader, etag)
+	w.Header().Set("ETag", etag)
+}
+
+// IfMatchVersion は、If-MatchヘッダからVersionETagで生成したバージョンを取り出す
+// ヘッダがないか*の場合は条件なしとしてnilを返す
+// 弱いETagや解釈できないETagのみの場合は、一致するリソースがないためokをfalseとする
+// 複数のETagが指定された場合は、解釈できる最初のETagを使用する
+func IfMatchVersion(r *http.Request) (version *int64, ok bool) {
+	im := strings.TrimSpace(r.Header.Get("If-Match"))
+	if im == "" || im == "*" {
+		return nil, true
+	}
+	for _, tag := range strings.Split(im, ",") {
+		tag = strings.TrimSpace(tag)
+		if strings.HasPrefix(tag, "W/") || len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+			continue
+		}
+		v, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+		if err != nil {
+			continue
+		}
+		return &v, true
+	}
+	return nil, false
+}
+
+// IsNotModified は、リクエストの条件付きヘッダからキャッシュが有効かを判定する
+// If-None-Matchが指定されている場合はIf-Modified-Sinceより優先する
+func IsNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		t, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(t)
+	}
+	return false
+}
+
+// SetLastModified は、Last-Modifiedヘッダーを設定する
+// lastModifiedがゼロ値の場合は設定しない
+func SetLastModified(w http.ResponseWriter, lastModified time.Time) {
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// RespondConditional は、ETagとLast-Modifiedを付与してレスポンスを返す
+// 条件付きリクエストでキャッシュが有効な場合は304を返す
+func RespondConditional(
+	w http.ResponseWriter, r *http.Request, contentType string, body []byte, lastModified time.Time,
+) error {
+	etag := ETag(body)
+	w.Header().Set("ETag", etag)
+	SetLastModified(w, lastModified)
+	if IsNotModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("failed to write body in RespondConditional(): %w", err)
+	}
+	return nil
+}
@@ -59,6 +59,15 @@ func RespondUnauthorized(w http.ResponseWriter, r *http.Request, err error) {
 	}
 }
 
+func RespondPreconditionFailed(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+	logger := logging.GetLogger(ctx)
+	resp := Response{Message: ErrMessagePreconditionFailed}
+	if err := RespondJSON(w, r, http.StatusPreconditionFailed, resp); err != nil {
+		logger.Error(fmt.Sprintf("failed to respond json error: %v", err))
+	}
+}
+
 func RespondNoContent(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger := logging.GetLogger(ctx)
@@ -84,5 +93,6 @@ const (
 	ErrMessageNotFound            = "NotFound"
 	ErrMessageInternalServerError = "InternalServerError"
 	ErrMessageUnauthorized        = "Unauthorized"
+	ErrMessagePreconditionFailed  = "PreconditionFailed"
 	MessageNoContent              = "NoContent"
 )
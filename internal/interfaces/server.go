@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/shoet/blog/internal/clocker"
@@ -20,14 +21,27 @@ import (
 	"github.com/shoet/blog/internal/infrastructure/services/blog_service"
 	"github.com/shoet/blog/internal/infrastructure/services/contents_service"
 	"github.com/shoet/blog/internal/infrastructure/services/jwt_service"
+	"github.com/shoet/blog/internal/infrastructure/services/preview_token_service"
+	"github.com/shoet/blog/internal/infrastructure/services/related_blogs_cache_service"
+	"github.com/shoet/blog/internal/infrastructure/services/sitemap_cache_service"
+	"github.com/shoet/blog/internal/infrastructure/services/view_counter_service"
 	"github.com/shoet/blog/internal/interfaces/cookie"
+	"github.com/shoet/blog/internal/interfaces/worker"
 	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/options"
+	"github.com/shoet/blog/internal/usecase/flush_blog_views"
+	"github.com/shoet/blog/internal/usecase/publish_scheduled_blogs"
+	"github.com/shoet/blog/internal/usecase/purge_blog"
+	"github.com/shoet/blog/internal/usecase/purge_trashed_blogs"
 	"golang.org/x/sync/errgroup"
 )
 
 type Server struct {
-	srv *http.Server
-	l   net.Listener
+	srv         *http.Server
+	l           net.Listener
+	publisher   *worker.Periodic
+	viewFlusher *worker.Periodic
+	trashPurger *worker.Periodic
 }
 
 func NewServer(ctx context.Context, cfg *config.Config) (*Server, error) {
@@ -47,7 +61,39 @@ func NewServer(ctx context.Context, cfg *config.Config) (*Server, error) {
 	srv := &http.Server{
 		Handler: mux,
 	}
-	return &Server{srv: srv, l: l}, nil
+	interval := time.Duration(cfg.ScheduledPublishIntervalSec) * time.Second
+	publisher, err := worker.NewScheduledPublisher(
+		publish_scheduled_blogs.NewUsecase(
+			deps.DB, deps.BlogRepository, deps.KVS, deps.SitemapCacheService, deps.RelatedBlogsCacheService,
+			deps.Clocker, interval),
+		deps.Logger,
+		interval,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scheduled publisher in NewServer(): %w", err)
+	}
+	viewFlushInterval := time.Duration(cfg.ViewFlushIntervalSec) * time.Second
+	viewFlusher, err := worker.NewViewFlusher(
+		flush_blog_views.NewUsecase(
+			deps.DB, deps.BlogRepository, deps.ViewCounterService, deps.KVS, viewFlushInterval),
+		deps.Logger,
+		viewFlushInterval,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create view flusher in NewServer(): %w", err)
+	}
+	trashPurgeInterval := time.Duration(cfg.TrashPurgeIntervalSec) * time.Second
+	trashPurger, err := worker.NewTrashPurger(
+		purge_trashed_blogs.NewUsecase(
+			deps.DB, deps.BlogRepository, purge_blog.NewUsecase(deps.DB, deps.BlogRepository, deps.SeriesRepository), deps.KVS, deps.Clocker,
+			time.Duration(cfg.TrashRetentionDays)*24*time.Hour, trashPurgeInterval),
+		deps.Logger,
+		trashPurgeInterval,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trash purger in NewServer(): %w", err)
+	}
+	return &Server{srv: srv, l: l, publisher: publisher, viewFlusher: viewFlusher, trashPurger: trashPurger}, nil
 }
 
 func BuildMuxDependencies(ctx context.Context, cfg *config.Config) (*MuxDependencies, error) {
@@ -75,9 +121,16 @@ func BuildMuxDependencies(ctx context.Context, cfg *config.Config) (*MuxDependen
 	}
 	c := clocker.RealClocker{}
 	jwtService := jwt_service.NewJWTService(kvs, &c, []byte(cfg.JWTSecret), cfg.JWTExpiresInSec)
+	previewTokenService := preview_token_service.NewPreviewTokenService(kvs, &c, cfg.PreviewTokenSecret, cfg.JWTSecret)
+	blogCursorCodec := options.NewBlogCursorCodec(cfg.BlogCursorSecret, cfg.JWTSecret)
+	sitemapCacheService := sitemap_cache_service.NewSitemapCacheService(
+		kvs, time.Duration(cfg.SitemapCacheExpiresInSec)*time.Second)
+	viewCounterService := view_counter_service.NewViewCounterService(kvs)
+	relatedBlogsCacheService := related_blogs_cache_service.NewRelatedBlogsCacheService(
+		kvs, time.Duration(cfg.RelatedCacheExpiresInSec)*time.Second)
 
 	blogRepo := repository.NewBlogRepository(&c)
-	blogOffsetRepo := repository.NewBlogRepositoryOffset(&c)
+	blogRevisionRepo := repository.NewBlogRevisionRepository(&c)
 	blogService := blog_service.NewBlogService()
 
 	userRepo, err := repository.NewUserRepository(&c)
@@ -86,6 +139,8 @@ func BuildMuxDependencies(ctx context.Context, cfg *config.Config) (*MuxDependen
 	}
 
 	commentRepo := repository.NewCommentRepository(&c)
+	seriesRepo := repository.NewSeriesRepository(&c)
+	reactionRepo := repository.NewReactionRepository(&c)
 	userProfileRepo := repository.NewUserProfileRepository(cfg)
 
 	authService, err := auth_service.NewAuthService(db, userRepo, userProfileRepo, jwtService)
@@ -106,24 +161,37 @@ func BuildMuxDependencies(ctx context.Context, cfg *config.Config) (*MuxDependen
 
 	gitHubAPIAdapter := adapter.NewGitHubV4APIClient(cfg.GitHubPersonalAccessToken)
 
+	siteLocation, err := time.LoadLocation(cfg.SiteTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load site timezone: %w", err)
+	}
+
 	return &MuxDependencies{
-		Config:                cfg,
-		DB:                    db,
-		KVS:                   kvs,
-		BlogRepository:        blogRepo,
-		BlogRepositoryOffset:  blogOffsetRepo,
-		CommentRepository:     commentRepo,
-		FileRepository:        fileRepo,
-		UserProfileRepository: userProfileRepo,
-		BlogService:           blogService,
-		AuthService:           authService,
-		ContentsService:       contentsService,
-		JWTer:                 jwtService,
-		Logger:                logger,
-		Validator:             validator,
-		Cookie:                cookie,
-		GitHubAPIAdapter:      gitHubAPIAdapter,
-		Clocker:               &c,
+		Config:                   cfg,
+		DB:                       db,
+		KVS:                      kvs,
+		BlogRepository:           blogRepo,
+		BlogRevisionRepository:   blogRevisionRepo,
+		CommentRepository:        commentRepo,
+		FileRepository:           fileRepo,
+		UserProfileRepository:    userProfileRepo,
+		SeriesRepository:         seriesRepo,
+		ReactionRepository:       reactionRepo,
+		BlogService:              blogService,
+		AuthService:              authService,
+		ContentsService:          contentsService,
+		JWTer:                    jwtService,
+		PreviewTokenService:      previewTokenService,
+		SitemapCacheService:      sitemapCacheService,
+		RelatedBlogsCacheService: relatedBlogsCacheService,
+		ViewCounterService:       viewCounterService,
+		BlogCursorCodec:          blogCursorCodec,
+		Logger:                   logger,
+		Validator:                validator,
+		Cookie:                   cookie,
+		GitHubAPIAdapter:         gitHubAPIAdapter,
+		Clocker:                  &c,
+		SiteLocation:             siteLocation,
 	}, nil
 }
 
@@ -140,6 +208,18 @@ func (s *Server) Run(ctx context.Context) error {
 		return nil
 	})
 
+	eg.Go(func() error {
+		return s.publisher.Run(ctx)
+	})
+
+	eg.Go(func() error {
+		return s.viewFlusher.Run(ctx)
+	})
+
+	eg.Go(func() error {
+		return s.trashPurger.Run(ctx)
+	})
+
 	<-ctx.Done()
 
 	if err := s.srv.Shutdown(context.Background()); err != nil {
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidInterval = fmt.Errorf("interval must be positive")
+
+// Periodic は、一定間隔で処理を繰り返すワーカー
+type Periodic struct {
+	interval time.Duration
+	task     func(ctx context.Context)
+	// runOnStart がtrueの場合は、開始時に間隔を待たずに一度実行する
+	runOnStart bool
+	// runOnStop がtrueの場合は、終了時に最後に一度実行する
+	runOnStop bool
+}
+
+// NewPeriodic は、interval間隔でtaskを実行するワーカーを生成する
+// intervalが0以下の場合はErrInvalidIntervalを返す
+func NewPeriodic(
+	interval time.Duration, runOnStart bool, runOnStop bool, task func(ctx context.Context),
+) (*Periodic, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInterval, interval)
+	}
+	return &Periodic{
+		interval:   interval,
+		task:       task,
+		runOnStart: runOnStart,
+		runOnStop:  runOnStop,
+	}, nil
+}
+
+// Run は、ctxがキャンセルされるまでtaskを繰り返す
+// 終了時に実行する場合は、キャンセルされたctxの値を引き継いだキャンセルされないctxで実行する
+func (p *Periodic) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	if p.runOnStart {
+		p.task(ctx)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			if p.runOnStop {
+				p.task(context.WithoutCancel(ctx))
+			}
+			return nil
+		case <-ticker.C:
+			p.task(ctx)
+		}
+	}
+}
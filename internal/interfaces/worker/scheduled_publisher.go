@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/publish_scheduled_blogs"
+)
+
+// NewScheduledPublisher は、一定間隔で予約公開のブログを公開するワーカーを生成する
+func NewScheduledPublisher(
+	usecase *publish_scheduled_blogs.Usecase,
+	logger *logging.Logger,
+	interval time.Duration,
+) (*Periodic, error) {
+	return NewPeriodic(interval, true, false, func(ctx context.Context) {
+		ids, err := usecase.Run(ctx)
+		for _, id := range ids {
+			logger.Info(fmt.Sprintf("published scheduled blog: %d", id))
+		}
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to publish scheduled blogs: %v", err))
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/purge_trashed_blogs"
+)
+
+// NewTrashPurger は、一定間隔で保持期間を過ぎたゴミ箱のブログを完全に削除するワーカーを生成する
+func NewTrashPurger(
+	usecase *purge_trashed_blogs.Usecase,
+	logger *logging.Logger,
+	interval time.Duration,
+) (*Periodic, error) {
+	return NewPeriodic(interval, true, false, func(ctx context.Context) {
+		ids, err := usecase.Run(ctx)
+		for _, id := range ids {
+			logger.Info(fmt.Sprintf("purged trashed blog: %d", id))
+		}
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to purge trashed blogs: %v", err))
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/usecase/flush_blog_views"
+)
+
+// NewViewFlusher は、一定間隔でKVSで集計中の閲覧数をDBに保存するワーカーを生成する
+// 終了時には、集計中の閲覧数を失わないよう最後に一度保存する
+func NewViewFlusher(
+	usecase *flush_blog_views.Usecase,
+	logger *logging.Logger,
+	interval time.Duration,
+) (*Periodic, error) {
+	return NewPeriodic(interval, false, true, func(ctx context.Context) {
+		views, err := usecase.Run(ctx)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to flush blog views: %v", err))
+			return
+		}
+		if len(views) > 0 {
+			logger.Info(fmt.Sprintf("flushed blog views: %d entries", len(views)))
+		}
+	})
+}
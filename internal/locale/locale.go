@@ -0,0 +1,71 @@
+package locale
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+var ErrInvalidLocale = fmt.Errorf("invalid locale")
+
+// wildcard は、Accept-Languageのワイルドカード(*)を解釈した言語タグ
+var wildcard = language.MustParse("mul")
+
+// Normalize は、BCP 47の言語タグを正規化した文字列を返す
+// 言語を特定できないタグ(und)や不正なタグの場合はErrInvalidLocaleを返す
+func Normalize(s string) (string, error) {
+	tag, err := language.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidLocale, err)
+	}
+	if tag == language.Und {
+		return "", fmt.Errorf("%w: %q is undetermined", ErrInvalidLocale, s)
+	}
+	return tag.String(), nil
+}
+
+// ParseAcceptLanguage は、Accept-Languageヘッダーから優先度の高い順に言語タグを返す
+// ワイルドカード、q=0の言語、解釈できない値は無視する
+func ParseAcceptLanguage(header string) []string {
+	tags, q, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+	locales := make([]string, 0, len(tags))
+	for i, tag := range tags {
+		if tag == language.Und || tag == wildcard || q[i] <= 0 {
+			continue
+		}
+		locales = append(locales, tag.String())
+	}
+	return locales
+}
+
+// Match は、希望する言語の優先順に、利用できる言語から一致するものを返す
+// 完全に一致する言語がない場合は、基本言語が同じ言語で代替する(例: en-USに対するen)
+// いずれの言語も一致しない場合はfalseを返す
+func Match(preferred []string, available []string) (string, bool) {
+	for _, p := range preferred {
+		for _, a := range available {
+			if strings.EqualFold(p, a) {
+				return a, true
+			}
+		}
+		pTag, err := language.Parse(p)
+		if err != nil {
+			continue
+		}
+		pBase, _ := pTag.Base()
+		for _, a := range available {
+			aTag, err := language.Parse(a)
+			if err != nil {
+				continue
+			}
+			if aBase, _ := aTag.Base(); aBase == pBase {
+				return a, true
+			}
+		}
+	}
+	return "", false
+}
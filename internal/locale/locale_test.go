@@ -0,0 +1,82 @@
+package locale_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/locale"
+)
+
+func Test_Normalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{name: "言語のみ", input: "ja", want: "ja"},
+		{name: "地域の大文字小文字を正規化する", input: "en-us", want: "en-US"},
+		{name: "アンダースコア区切り", input: "pt_BR", want: "pt-BR"},
+		{name: "空文字", input: "", wantErr: locale.ErrInvalidLocale},
+		{name: "言語を特定できない", input: "und", wantErr: locale.ErrInvalidLocale},
+		{name: "不正なタグ", input: "not a locale", wantErr: locale.ErrInvalidLocale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := locale.Normalize(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error: %v, want: %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got: %q, want: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{name: "優先度の高い順に並べる", header: "en;q=0.5, ja, fr;q=0.8", want: []string{"ja", "fr", "en"}},
+		{name: "ワイルドカードとq=0を除く", header: "*, de;q=0, en-GB", want: []string{"en-GB"}},
+		{name: "空", header: "", want: []string{}},
+		{name: "不正な値", header: "ja;q=x", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := locale.ParseAcceptLanguage(tt.header)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Match(t *testing.T) {
+	available := []string{"ja", "en", "zh-Hant"}
+	tests := []struct {
+		name      string
+		preferred []string
+		want      string
+		wantOk    bool
+	}{
+		{name: "完全に一致する", preferred: []string{"en"}, want: "en", wantOk: true},
+		{name: "大文字小文字を区別しない", preferred: []string{"ZH-hant"}, want: "zh-Hant", wantOk: true},
+		{name: "基本言語で代替する", preferred: []string{"en-US"}, want: "en", wantOk: true},
+		{name: "優先順に一致を探す", preferred: []string{"fr", "en-GB", "ja"}, want: "en", wantOk: true},
+		{name: "一致しない", preferred: []string{"fr", "de"}, wantOk: false},
+		{name: "希望がない", preferred: nil, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := locale.Match(tt.preferred, available)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("got: (%q, %v), want: (%q, %v)", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package markdown
+
+import (
+	"bytes"
+	"strconv"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// headingIDs は、見出しのアンカーIDを生成する
+// goldmarkの既定の実装は英数字以外を除去するため、日本語の見出しでも読めるIDになるようにする
+type headingIDs struct {
+	values map[string]bool
+}
+
+func newHeadingIDs() *headingIDs {
+	return &headingIDs{values: map[string]bool{}}
+}
+
+func (s *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	var buf bytes.Buffer
+	hyphen := false
+	for _, r := range string(bytes.ToLower(bytes.TrimSpace(value))) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_' {
+			if hyphen && buf.Len() > 0 {
+				buf.WriteByte('-')
+			}
+			hyphen = false
+			buf.WriteRune(r)
+			continue
+		}
+		hyphen = true
+	}
+	if buf.Len() == 0 {
+		if kind == ast.KindHeading {
+			buf.WriteString("heading")
+		} else {
+			buf.WriteString("id")
+		}
+	}
+	id := buf.String()
+	if !s.values[id] {
+		s.values[id] = true
+		return []byte(id)
+	}
+	for i := 1; ; i++ {
+		candidate := id + "-" + strconv.Itoa(i)
+		if !s.values[candidate] {
+			s.values[candidate] = true
+			return []byte(candidate)
+		}
+	}
+}
+
+func (s *headingIDs) Put(value []byte) {
+	s.values[string(value)] = true
+}
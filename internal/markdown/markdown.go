@@ -0,0 +1,133 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// Result は、Markdownのレンダリング結果
+type Result struct {
+	// HTML は、サニタイズ済みのHTML
+	HTML string
+	// Headings は、見出しを階層構造にした目次
+	Headings []*Heading
+	Stats    Stats
+}
+
+// Heading は、目次の1項目
+type Heading struct {
+	Level    int        `json:"level"`
+	Text     string     `json:"text"`
+	Id       string     `json:"id"`
+	Children []*Heading `json:"children,omitempty"`
+}
+
+var md = goldmark.New(
+	goldmark.WithExtensions(
+		extension.GFM,
+		extension.Footnote,
+		extension.CJK,
+		highlighting.NewHighlighting(
+			// スタイルはフロントエンドのCSSで指定するため、クラス名で出力する
+			highlighting.WithFormatOptions(chromahtml.WithClasses(true)),
+		),
+	),
+	goldmark.WithParserOptions(
+		parser.WithAutoHeadingID(),
+	),
+)
+
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	// シンタックスハイライトと脚注はクラス名で装飾する
+	p.AllowAttrs("class").Globally()
+	// 見出しのアンカーと脚注の相互リンク
+	p.AllowAttrs("id").OnElements("h1", "h2", "h3", "h4", "h5", "h6", "li", "sup")
+	p.AllowAttrs("role").OnElements("a", "div", "section")
+	// タスクリストのチェックボックス
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}
+
+// Render は、Markdownをサニタイズ済みのHTMLに変換し、目次と文字数を集計する
+// 生のHTMLは出力せず、コードブロックはサーバー側でハイライトする
+func Render(source string) (*Result, error) {
+	src := []byte(source)
+	pc := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+	doc := md.Parser().Parse(text.NewReader(src), parser.WithContext(pc))
+
+	var buf bytes.Buffer
+	if err := md.Renderer().Render(&buf, src, doc); err != nil {
+		return nil, fmt.Errorf("failed to render markdown: %w", err)
+	}
+	return &Result{
+		HTML:     policy.Sanitize(buf.String()),
+		Headings: collectHeadings(doc, src),
+		Stats:    countStats(doc, src),
+	}, nil
+}
+
+// collectHeadings は、見出しを出現順に走査して階層構造にする
+// 上位の見出しがない場合は、ルートの項目として扱う
+func collectHeadings(doc ast.Node, src []byte) []*Heading {
+	var roots []*Heading
+	var stack []*Heading
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		h, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		heading := &Heading{Level: h.Level, Text: plainText(h, src)}
+		if id, ok := h.AttributeString("id"); ok {
+			if b, ok := id.([]byte); ok {
+				heading.Id = string(b)
+			}
+		}
+		for len(stack) > 0 && stack[len(stack)-1].Level >= heading.Level {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, heading)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, heading)
+		}
+		stack = append(stack, heading)
+		return ast.WalkSkipChildren, nil
+	})
+	return roots
+}
+
+// plainText は、ノード配下のテキストを連結する
+func plainText(n ast.Node, src []byte) string {
+	var buf bytes.Buffer
+	_ = ast.Walk(n, func(c ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := c.(type) {
+		case *ast.Text:
+			buf.Write(t.Segment.Value(src))
+			if t.SoftLineBreak() || t.HardLineBreak() {
+				buf.WriteByte(' ')
+			}
+		case *ast.String:
+			buf.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return buf.String()
+}
@@ -0,0 +1,121 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Render_Headings(t *testing.T) {
+	source := strings.Join([]string{
+		"# はじめに",
+		"## Go の **テスト**",
+		"### 詳細",
+		"## Go の テスト",
+		"# まとめ",
+	}, "\n\n")
+	result, err := Render(source)
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	want := []*Heading{
+		{Level: 1, Text: "はじめに", Id: "はじめに", Children: []*Heading{
+			{Level: 2, Text: "Go の テスト", Id: "go-の-テスト", Children: []*Heading{
+				{Level: 3, Text: "詳細", Id: "詳細"},
+			}},
+			{Level: 2, Text: "Go の テスト", Id: "go-の-テスト-1"},
+		}},
+		{Level: 1, Text: "まとめ", Id: "まとめ"},
+	}
+	if diff := cmp.Diff(want, result.Headings); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+	if !strings.Contains(result.HTML, `<h2 id="go-の-テスト-1">`) {
+		t.Errorf("missing heading anchor: %s", result.HTML)
+	}
+}
+
+func Test_Render_Sanitize(t *testing.T) {
+	source := "<script>alert(1)</script>\n\n[link](javascript:alert(1)) <img src=x onerror=alert(1)>\n"
+	result, err := Render(source)
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	for _, s := range []string{"<script", "javascript:", "onerror"} {
+		if strings.Contains(result.HTML, s) {
+			t.Errorf("html contains %q: %s", s, result.HTML)
+		}
+	}
+}
+
+func Test_Render_GFM(t *testing.T) {
+	source := strings.Join([]string{
+		"| a | b |",
+		"|---|---|",
+		"| 1 | 2 |",
+		"",
+		"- [x] done",
+		"",
+		"~~old~~ 本文[^1]",
+		"",
+		"[^1]: 脚注",
+		"",
+		"```go",
+		"func main() {}",
+		"```",
+	}, "\n")
+	result, err := Render(source)
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	for _, s := range []string{
+		"<table>", `type="checkbox"`, "<del>old</del>",
+		`id="fn:1"`, `href="#fn:1"`, `class="footnotes"`,
+		`<pre class="chroma">`, `<span class="kd">func</span>`,
+	} {
+		if !strings.Contains(result.HTML, s) {
+			t.Errorf("html does not contain %q: %s", s, result.HTML)
+		}
+	}
+}
+
+func Test_Render_Stats(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   Stats
+	}{
+		{
+			name:   "日本語は文字数で読了時間を見積もる",
+			source: strings.Repeat("あ", 1000),
+			want:   Stats{Characters: 1000, Words: 0, ReadingTimeMinutes: 2},
+		},
+		{
+			name:   "英語は単語数で読了時間を見積もる",
+			source: strings.Repeat("word ", 201),
+			want:   Stats{Characters: 804, Words: 201, ReadingTimeMinutes: 2},
+		},
+		{
+			name:   "コードブロックは集計しない",
+			source: "Go言語\n\n```go\nfunc main() {}\n```\n",
+			want:   Stats{Characters: 4, Words: 1, ReadingTimeMinutes: 1},
+		},
+		{
+			name:   "空の本文",
+			source: "",
+			want:   Stats{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Render(tt.source)
+			if err != nil {
+				t.Fatalf("failed to render: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, result.Stats); diff != "" {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package markdown
+
+import (
+	"math"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+const (
+	// 日本語は1分あたりの文字数、英語などは1分あたりの単語数で読了時間を見積もる
+	cjkCharactersPerMinute = 500
+	wordsPerMinute         = 200
+)
+
+// Stats は、本文の文字数と読了時間の目安
+type Stats struct {
+	// Characters は、空白を除いた文字数
+	Characters int `json:"characters"`
+	// Words は、日本語以外の単語数
+	Words int `json:"words"`
+	// ReadingTimeMinutes は、読了までの目安となる分数
+	ReadingTimeMinutes int `json:"readingTimeMinutes"`
+}
+
+// countStats は、コードブロックを除いた本文の文字数と読了時間を集計する
+func countStats(doc ast.Node, src []byte) Stats {
+	var stats Stats
+	var cjk int
+	inWord := false
+	count := func(s string) {
+		for _, r := range s {
+			switch {
+			case unicode.IsSpace(r):
+				inWord = false
+				continue
+			case isCJK(r):
+				cjk++
+				inWord = false
+			case unicode.IsLetter(r) || unicode.IsNumber(r):
+				if !inWord {
+					stats.Words++
+				}
+				inWord = true
+			default:
+				inWord = false
+			}
+			stats.Characters++
+		}
+	}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			count(string(t.Segment.Value(src)))
+			inWord = false
+		case *ast.String:
+			count(string(t.Value))
+			inWord = false
+		}
+		return ast.WalkContinue, nil
+	})
+	minutes := float64(cjk)/cjkCharactersPerMinute + float64(stats.Words)/wordsPerMinute
+	stats.ReadingTimeMinutes = int(math.Ceil(minutes))
+	return stats
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) ||
+		(r >= 0xFF01 && r <= 0xFF60) || (r >= 0x3000 && r <= 0x303F)
+}
@@ -0,0 +1,129 @@
+package options
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+// PageDirectionはカーソル方式のページングでカーソルから進む方向
+type PageDirection string
+
+const (
+	PageDirectionNext PageDirection = "next"
+	PageDirectionPrev PageDirection = "prev"
+)
+
+var ErrInvalidCursor = fmt.Errorf("invalid cursor")
+
+// BlogCursorはカーソル方式のページングの位置
+// 並び順と、カーソルのブログの並び順の基準の値とIDの組を持つ
+type BlogCursor struct {
+	Sort BlogSort
+	// Valueは並び順の基準の値の文字列表現
+	// IDの順の場合は使用しない
+	Value     string
+	Id        models.BlogId
+	Direction PageDirection
+}
+
+// NewBlogCursorは、ブログの位置から指定した方向に進むカーソルを生成する
+func NewBlogCursor(sort BlogSort, blog *models.Blog, direction PageDirection) *BlogCursor {
+	return &BlogCursor{
+		Sort:      sort,
+		Value:     blog.SortValue,
+		Id:        blog.Id,
+		Direction: direction,
+	}
+}
+
+// blogCursorPayloadはカーソルをエンコードする際の形式
+type blogCursorPayload struct {
+	Key       BlogSortKey   `json:"k"`
+	Asc       bool          `json:"a,omitempty"`
+	Value     string        `json:"v,omitempty"`
+	Id        models.BlogId `json:"i"`
+	Direction PageDirection `json:"d"`
+}
+
+// BlogCursorCodecは、カーソルを署名付きの不透明な文字列にエンコード・デコードする
+// 形式は base64url(JSON) + "." + base64url(HMAC-SHA256)
+type BlogCursorCodec struct {
+	secretKey []byte
+}
+
+// NewBlogCursorCodecは、カーソル用の署名鍵でBlogCursorCodecを生成する
+// cursorSecretが空の場合は、ログイン用のJWTと区別するためjwtSecretから派生した鍵を使う
+func NewBlogCursorCodec(cursorSecret string, jwtSecret string) *BlogCursorCodec {
+	secretKey := []byte(cursorSecret)
+	if cursorSecret == "" {
+		mac := hmac.New(sha256.New, []byte(jwtSecret))
+		mac.Write([]byte("blog_cursor"))
+		secretKey = mac.Sum(nil)
+	}
+	return &BlogCursorCodec{secretKey: secretKey}
+}
+
+func (c *BlogCursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secretKey)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Encodeは、カーソルを署名付きの文字列にエンコードする
+func (c *BlogCursorCodec) Encode(cursor *BlogCursor) (string, error) {
+	payload, err := json.Marshal(&blogCursorPayload{
+		Key:       cursor.Sort.Key,
+		Asc:       cursor.Sort.Asc,
+		Value:     cursor.Value,
+		Id:        cursor.Id,
+		Direction: cursor.Direction,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cursor: %w", err)
+	}
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(payload) + "." + encoding.EncodeToString(c.sign(payload)), nil
+}
+
+// Decodeは、文字列をカーソルにデコードする
+// 形式が不正な場合や署名が一致しない場合はErrInvalidCursorを返す
+func (c *BlogCursorCodec) Decode(s string) (*BlogCursor, error) {
+	encoding := base64.RawURLEncoding
+	encodedPayload, encodedSignature, ok := strings.Cut(s, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	payload, err := encoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	signature, err := encoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if !hmac.Equal(signature, c.sign(payload)) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidCursor)
+	}
+	var p blogCursorPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if !p.Key.valid() {
+		return nil, fmt.Errorf("%w: unknown sort key %q", ErrInvalidCursor, p.Key)
+	}
+	if p.Direction != PageDirectionNext && p.Direction != PageDirectionPrev {
+		return nil, fmt.Errorf("%w: unknown direction %q", ErrInvalidCursor, p.Direction)
+	}
+	return &BlogCursor{
+		Sort:      BlogSort{Key: p.Key, Asc: p.Asc},
+		Value:     p.Value,
+		Id:        p.Id,
+		Direction: p.Direction,
+	}, nil
+}
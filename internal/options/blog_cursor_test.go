@@ -0,0 +1,116 @@
+package options_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/options"
+)
+
+func Test_BlogCursorCodec(t *testing.T) {
+	codec := options.NewBlogCursorCodec("secret", "")
+
+	t.Run("エンコードしたカーソルをデコードできる", func(t *testing.T) {
+		want := &options.BlogCursor{
+			Sort:      options.BlogSort{Key: options.BlogSortTitle, Asc: true},
+			Value:     "Go言語",
+			Id:        10,
+			Direction: options.PageDirectionPrev,
+		}
+		s, err := codec.Encode(want)
+		if err != nil {
+			t.Fatalf("failed to encode: %v", err)
+		}
+		got, err := codec.Decode(s)
+		if err != nil {
+			t.Fatalf("failed to decode: %v", err)
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("differs: (-got +want)\n%s", diff)
+		}
+	})
+
+	valid, err := codec.Encode(&options.BlogCursor{
+		Sort: options.BlogSort{Key: options.BlogSortId}, Id: 1, Direction: options.PageDirectionNext,
+	})
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	payload, signature, _ := strings.Cut(valid, ".")
+	otherKey, err := options.NewBlogCursorCodec("other", "").Encode(&options.BlogCursor{
+		Sort: options.BlogSort{Key: options.BlogSortId}, Id: 1, Direction: options.PageDirectionNext,
+	})
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "区切りがない", input: payload},
+		{name: "base64でない", input: "!!!." + signature},
+		{name: "ペイロードが改ざんされている", input: "e30." + signature},
+		{name: "異なる鍵で署名されている", input: otherKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := codec.Decode(tt.input); !errors.Is(err, options.ErrInvalidCursor) {
+				t.Errorf("got: %v, want: %v", err, options.ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+func Test_NewListBlogOptions_CursorSort(t *testing.T) {
+	cursor := &options.BlogCursor{
+		Sort:      options.BlogSort{Key: options.BlogSortModified, Asc: true},
+		Value:     "100",
+		Id:        1,
+		Direction: options.PageDirectionNext,
+	}
+	option, err := options.NewListBlogOptions(&options.BlogFilter{}, &options.BlogSort{Key: options.BlogSortId}, cursor, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(option.Sort, cursor.Sort); diff != "" {
+		t.Errorf("differs: (-got +want)\n%s", diff)
+	}
+}
+
+func Test_NewListBlogOptions_RelevanceCursor(t *testing.T) {
+	codec := options.NewBlogCursorCodec("secret", "")
+	s, err := codec.Encode(&options.BlogCursor{
+		Sort:      options.BlogSort{Key: options.BlogSortRelevance},
+		Value:     "0.0607927",
+		Id:        3,
+		Direction: options.PageDirectionNext,
+	})
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	cursor, err := codec.Decode(s)
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	keyword := "go"
+	option, err := options.NewListBlogOptions(
+		&options.BlogFilter{KeyWord: &keyword}, &options.BlogSort{Key: options.BlogSortId}, cursor, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if option.Sort.Key != options.BlogSortRelevance {
+		t.Errorf("want sort key %q, got %q", options.BlogSortRelevance, option.Sort.Key)
+	}
+	if option.Cursor.Value != "0.0607927" {
+		t.Errorf("want rank in cursor, got %q", option.Cursor.Value)
+	}
+
+	if _, err := options.NewListBlogOptions(
+		&options.BlogFilter{}, &options.BlogSort{Key: options.BlogSortId}, cursor, nil,
+	); !errors.Is(err, options.ErrInvalidSort) {
+		t.Errorf("want ErrInvalidSort without keyword, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/locale"
+)
+
+// TagMatchは複数のタグで絞り込む場合の条件
+type TagMatch string
+
+const (
+	// TagMatchAllはすべてのタグを持つブログに絞り込む
+	TagMatchAll TagMatch = "and"
+	// TagMatchAnyはいずれかのタグを持つブログに絞り込む
+	TagMatchAny TagMatch = "or"
+)
+
+const DefaultTagMatch = TagMatchAll
+
+// filterDateLayoutは作成日で絞り込む場合の日付の形式
+const filterDateLayout = "2006-01-02"
+
+var ErrInvalidTagMatch = fmt.Errorf("tag match is invalid")
+var ErrInvalidCreatedRange = fmt.Errorf("created range is invalid")
+
+// BlogFilterはブログ一覧の絞り込み条件
+// 指定された条件はすべてAND条件で組み合わせる
+type BlogFilter struct {
+	// IsPublicがtrueの場合は公開済みのブログのみとする
+	IsPublic bool
+	Tags     []string
+	TagMatch TagMatch
+	KeyWord  *string
+	// CreatedFromは作成日時の下限(UNIX秒、この値を含む)
+	CreatedFrom *uint
+	// CreatedToは作成日時の上限(UNIX秒、この値を含まない)
+	CreatedTo *uint
+	AuthorId  *models.UserId
+	// Localeは言語で、既定の言語が一致するか、その言語の翻訳があるブログに絞り込む
+	Locale *string
+}
+
+// NewBlogFilterはクエリパラメータの値からBlogFilterを生成する
+// createdFrom, createdToはYYYY-MM-DD形式の日付で、サイトのタイムゾーンlocの日付として扱い、createdToの日付も範囲に含む
+// localeはBCP 47の言語タグで、正規化して保持する
+func NewBlogFilter(
+	isPublic *bool, tags []string, tagMatch *string, keyword *string,
+	createdFrom *string, createdTo *string, authorId *models.UserId, localeTag *string,
+	loc *time.Location,
+) (*BlogFilter, error) {
+	filter := &BlogFilter{
+		TagMatch: DefaultTagMatch,
+		KeyWord:  keyword,
+		AuthorId: authorId,
+	}
+	if err := SetDefault(filter, "IsPublic", isPublic, DefaultIsPublic); err != nil {
+		return nil, fmt.Errorf("failed to set default value IsPublic: %v", err)
+	}
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		filter.Tags = append(filter.Tags, tag)
+	}
+	if tagMatch != nil {
+		switch m := TagMatch(*tagMatch); m {
+		case TagMatchAll, TagMatchAny:
+			filter.TagMatch = m
+		default:
+			return nil, ErrInvalidTagMatch
+		}
+	}
+	if (createdFrom != nil || createdTo != nil) && loc == nil {
+		return nil, fmt.Errorf("location is required to filter by created date")
+	}
+	if createdFrom != nil {
+		from, err := time.ParseInLocation(filterDateLayout, *createdFrom, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCreatedRange, err)
+		}
+		v := uint(from.Unix())
+		filter.CreatedFrom = &v
+	}
+	if createdTo != nil {
+		to, err := time.ParseInLocation(filterDateLayout, *createdTo, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCreatedRange, err)
+		}
+		// 指定された日付の翌日0時を上限とする
+		v := uint(to.AddDate(0, 0, 1).Unix())
+		filter.CreatedTo = &v
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && *filter.CreatedFrom >= *filter.CreatedTo {
+		return nil, ErrInvalidCreatedRange
+	}
+	if localeTag != nil {
+		normalized, err := locale.Normalize(*localeTag)
+		if err != nil {
+			return nil, err
+		}
+		filter.Locale = &normalized
+	}
+	return filter, nil
+}
@@ -0,0 +1,130 @@
+package options_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/locale"
+	"github.com/shoet/blog/internal/options"
+)
+
+func Test_NewBlogFilter(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	epoch := func(v uint) *uint { return &v }
+	authorId := models.UserId(1)
+	isPublic := true
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+
+	type args struct {
+		isPublic    *bool
+		tags        []string
+		tagMatch    *string
+		keyword     *string
+		createdFrom *string
+		createdTo   *string
+		authorId    *models.UserId
+		locale      *string
+		loc         *time.Location
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *options.BlogFilter
+		wantErr error
+	}{
+		{
+			name: "指定なし",
+			args: args{},
+			want: &options.BlogFilter{TagMatch: options.TagMatchAll},
+		},
+		{
+			name: "タグの空白と重複は除く",
+			args: args{
+				tags:     []string{" go ", "", "go", "aws"},
+				tagMatch: ptr("or"),
+			},
+			want: &options.BlogFilter{
+				Tags:     []string{"go", "aws"},
+				TagMatch: options.TagMatchAny,
+			},
+		},
+		{
+			name: "作成日はJSTの日付で、終了日を含む",
+			args: args{
+				isPublic:    &isPublic,
+				keyword:     ptr("keyword"),
+				createdFrom: ptr("2024-01-01"),
+				createdTo:   ptr("2024-01-31"),
+				authorId:    &authorId,
+			},
+			want: &options.BlogFilter{
+				IsPublic:    true,
+				TagMatch:    options.TagMatchAll,
+				KeyWord:     ptr("keyword"),
+				CreatedFrom: epoch(1704034800),
+				CreatedTo:   epoch(1706713200),
+				AuthorId:    &authorId,
+			},
+		},
+		{
+			name: "作成日はサイトのタイムゾーンの日付とする",
+			args: args{
+				createdFrom: ptr("2024-01-01"),
+				createdTo:   ptr("2024-01-31"),
+				loc:         time.UTC,
+			},
+			want: &options.BlogFilter{
+				TagMatch:    options.TagMatchAll,
+				CreatedFrom: epoch(1704067200),
+				CreatedTo:   epoch(1706745600),
+			},
+		},
+		{
+			name:    "タグの条件が不正",
+			args:    args{tagMatch: ptr("xor")},
+			wantErr: options.ErrInvalidTagMatch,
+		},
+		{
+			name:    "日付の形式が不正",
+			args:    args{createdFrom: ptr("2024/01/01")},
+			wantErr: options.ErrInvalidCreatedRange,
+		},
+		{
+			name:    "開始日が終了日より後",
+			args:    args{createdFrom: ptr("2024-02-01"), createdTo: ptr("2024-01-31")},
+			wantErr: options.ErrInvalidCreatedRange,
+		},
+		{
+			name: "言語は正規化する",
+			args: args{locale: ptr("en-us")},
+			want: &options.BlogFilter{TagMatch: options.TagMatchAll, Locale: ptr("en-US")},
+		},
+		{
+			name:    "言語が不正",
+			args:    args{locale: ptr("not a locale")},
+			wantErr: locale.ErrInvalidLocale,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := tt.args.loc
+			if loc == nil {
+				loc = jst
+			}
+			got, err := options.NewBlogFilter(
+				tt.args.isPublic, tt.args.tags, tt.args.tagMatch, tt.args.keyword,
+				tt.args.createdFrom, tt.args.createdTo, tt.args.authorId, tt.args.locale, loc,
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got: %v, want: %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package options
+
+import "fmt"
+
+// BlogSortKeyはブログ一覧の並び順の基準
+type BlogSortKey string
+
+const (
+	BlogSortId       BlogSortKey = "id"
+	BlogSortCreated  BlogSortKey = "created"
+	BlogSortModified BlogSortKey = "modified"
+	BlogSortTitle    BlogSortKey = "title"
+	// BlogSortPopularityは集計済みの閲覧数の合計
+	BlogSortPopularity BlogSortKey = "popularity"
+	// BlogSortRelevanceはキーワードとの関連度で、キーワード検索でのみ指定できる
+	BlogSortRelevance BlogSortKey = "relevance"
+)
+
+func (k BlogSortKey) valid() bool {
+	switch k {
+	case BlogSortId, BlogSortCreated, BlogSortModified, BlogSortTitle, BlogSortPopularity, BlogSortRelevance:
+		return true
+	}
+	return false
+}
+
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+var ErrInvalidSort = fmt.Errorf("sort is invalid")
+
+// BlogSortはブログ一覧の並び順
+// 基準の値が同じ場合はIDを同じ向きで並べる
+type BlogSort struct {
+	Key BlogSortKey
+	Asc bool
+}
+
+// NewBlogSortはクエリパラメータの値からBlogSortを生成する
+// keyが未指定の場合はdefaultKey、orderが未指定の場合は降順とする
+func NewBlogSort(key *string, order *string, defaultKey BlogSortKey) (*BlogSort, error) {
+	sort := &BlogSort{Key: defaultKey}
+	if key != nil {
+		k := BlogSortKey(*key)
+		if !k.valid() {
+			return nil, ErrInvalidSort
+		}
+		sort.Key = k
+	}
+	if order != nil {
+		switch *order {
+		case SortOrderAsc:
+			sort.Asc = true
+		case SortOrderDesc:
+			sort.Asc = false
+		default:
+			return nil, ErrInvalidSort
+		}
+	}
+	return sort, nil
+}
@@ -0,0 +1,78 @@
+package options_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/options"
+)
+
+func Test_NewBlogSort(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name       string
+		key        *string
+		order      *string
+		defaultKey options.BlogSortKey
+		want       *options.BlogSort
+		wantErr    error
+	}{
+		{
+			name:       "指定なし",
+			defaultKey: options.BlogSortId,
+			want:       &options.BlogSort{Key: options.BlogSortId},
+		},
+		{
+			name:       "既定の基準",
+			defaultKey: options.BlogSortRelevance,
+			want:       &options.BlogSort{Key: options.BlogSortRelevance},
+		},
+		{
+			name:       "基準と昇順",
+			key:        ptr("popularity"),
+			order:      ptr("asc"),
+			defaultKey: options.BlogSortId,
+			want:       &options.BlogSort{Key: options.BlogSortPopularity, Asc: true},
+		},
+		{
+			name:       "基準が不正",
+			key:        ptr("author"),
+			defaultKey: options.BlogSortId,
+			wantErr:    options.ErrInvalidSort,
+		},
+		{
+			name:       "順序が不正",
+			order:      ptr("random"),
+			defaultKey: options.BlogSortId,
+			wantErr:    options.ErrInvalidSort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := options.NewBlogSort(tt.key, tt.order, tt.defaultKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got: %v, want: %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_NewListBlogOptions_RelevanceRequiresKeyword(t *testing.T) {
+	filter := &options.BlogFilter{}
+	sort := &options.BlogSort{Key: options.BlogSortRelevance}
+	if _, err := options.NewListBlogOptions(filter, sort, nil, nil); !errors.Is(err, options.ErrInvalidSort) {
+		t.Errorf("got: %v, want: %v", err, options.ErrInvalidSort)
+	}
+
+	keyword := "keyword"
+	filter.KeyWord = &keyword
+	if _, err := options.NewListBlogOptions(filter, sort, nil, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -3,61 +3,77 @@ package options
 import (
 	"fmt"
 	"reflect"
-
-	"github.com/shoet/blog/internal/infrastructure/models"
 )
 
+// ListBlogOptionsはブログ一覧の取得条件
+// 絞り込み・並び順・ページングの条件を持つ
+// Pageが0の場合はカーソル方式、1以上の場合はオフセット方式でページングする
 type ListBlogOptions struct {
-	IsPublic bool
-	Limit    int64
-	// CursorIdはカーソル方式のページネーションで使用するカーソルID
-	CursorId *models.BlogId
-	// PageDirectionはカーソル方式のページネーションで使用するページの方向
-	PageDirection string
+	// Filterは絞り込み条件
+	Filter BlogFilter
+	// Sortは並び順
+	Sort  BlogSort
+	Limit int64
+	// Cursorはカーソル方式のページネーションで使用するカーソル
+	// nilの場合は先頭のページを取得する
+	Cursor *BlogCursor
 	// Pageはオフセット方式のページネーションで使用するページ番号
 	Page int64
 }
 
 const DefaultLimit int64 = 10
 const DefaultIsPublic bool = false
-const DefaultPageDirection string = "next"
 const DefaultPage int64 = 1
 
 var ErrNotPointer = fmt.Errorf("v is not pointer")
 var ErrFieldNotFound = fmt.Errorf("field is not found")
 var ErrDefaultValueUnmatchType = fmt.Errorf("defaultValue is type unmatched")
 
-// NewListBlogOptionsはデフォルト値が設定されたListBlogOptionsを生成する
+// NewListBlogOptionsはカーソル方式のページングでデフォルト値が設定されたListBlogOptionsを生成する
+// カーソルが指定された場合は、並び順はカーソルの並び順とする
 func NewListBlogOptions(
-	isPublic *bool, cursorId *models.BlogId, limit *int64, pageDirection *string,
+	filter *BlogFilter, sort *BlogSort, cursor *BlogCursor, limit *int64,
 ) (*ListBlogOptions, error) {
-	option := new(ListBlogOptions)
-	if err := SetDefault(option, "IsPublic", isPublic, DefaultIsPublic); err != nil {
-		return nil, fmt.Errorf("failed to set default value IsPublic: %v", err)
-	}
-	if err := SetDefault(option, "Limit", limit, DefaultLimit); err != nil {
-		return nil, fmt.Errorf("failed to set default value Limit: %v", err)
+	if cursor != nil {
+		sort = &cursor.Sort
 	}
-	if err := SetDefault(option, "PageDirection", pageDirection, DefaultPageDirection); err != nil {
-		return nil, fmt.Errorf("failed to set default value PageDirection: %v", err)
+	option, err := newListBlogOptions(filter, sort, limit)
+	if err != nil {
+		return nil, err
 	}
-	option.CursorId = cursorId
+	option.Cursor = cursor
 	return option, nil
 }
 
+// NewListBlogOffsetOptionsはオフセット方式のページングでデフォルト値が設定されたListBlogOptionsを生成する
 func NewListBlogOffsetOptions(
-	isPublic *bool, limit *int64, page *int64,
+	filter *BlogFilter, sort *BlogSort, limit *int64, page *int64,
 ) (*ListBlogOptions, error) {
-	option := new(ListBlogOptions)
-	if err := SetDefault(option, "IsPublic", isPublic, DefaultIsPublic); err != nil {
-		return nil, fmt.Errorf("failed to set default value IsPublic: %v", err)
-	}
-	if err := SetDefault(option, "Limit", limit, DefaultLimit); err != nil {
-		return nil, fmt.Errorf("failed to set default value Limit: %v", err)
+	option, err := newListBlogOptions(filter, sort, limit)
+	if err != nil {
+		return nil, err
 	}
 	if err := SetDefault(option, "Page", page, DefaultPage); err != nil {
 		return nil, fmt.Errorf("failed to set default value Page: %v", err)
 	}
+	if option.Page < 1 {
+		option.Page = DefaultPage
+	}
+	return option, nil
+}
+
+func newListBlogOptions(filter *BlogFilter, sort *BlogSort, limit *int64) (*ListBlogOptions, error) {
+	// 関連度はキーワードで検索する場合のみ指定できる
+	if sort.Key == BlogSortRelevance && filter.KeyWord == nil {
+		return nil, fmt.Errorf("%w: relevance requires keyword", ErrInvalidSort)
+	}
+	option := &ListBlogOptions{
+		Filter: *filter,
+		Sort:   *sort,
+	}
+	if err := SetDefault(option, "Limit", limit, DefaultLimit); err != nil {
+		return nil, fmt.Errorf("failed to set default value Limit: %v", err)
+	}
 	return option, nil
 }
 
@@ -0,0 +1,147 @@
+package related
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/shoet/blog/internal/search"
+)
+
+// スコアの各要素の重み
+// タグの一致を最も重視し、本文の類似度、新しさの順に評価する
+const (
+	tagWeight     = 0.6
+	textWeight    = 0.3
+	recencyWeight = 0.1
+)
+
+// recencyHalfLife は、新しさのスコアが半減するまでの期間
+const recencyHalfLife = 180 * 24 * time.Hour
+
+// Document は、関連度の評価対象となるブログ
+type Document struct {
+	Id          int64
+	Title       string
+	Description string
+	Tags        []string
+	Created     time.Time
+}
+
+// Result は、評価対象のブログと関連度のスコア
+type Result struct {
+	Id    int64
+	Score float64
+}
+
+// Rank は、targetと関連するブログをスコアの高い順に最大limit件返す
+// タグもテキストも一致しないブログは、新しさに関わらず対象外とする
+func Rank(target *Document, candidates []*Document, now time.Time, limit int) []*Result {
+	weights := tagWeights(target, candidates)
+	var targetTagWeight float64
+	for _, tag := range uniqueTags(target.Tags) {
+		targetTagWeight += weights[tag]
+	}
+	targetText := target.Title + " " + target.Description
+
+	type scored struct {
+		result  *Result
+		created time.Time
+	}
+	var results []*scored
+	for _, c := range candidates {
+		if c.Id == target.Id {
+			continue
+		}
+		var tagScore float64
+		if targetTagWeight > 0 {
+			for _, tag := range uniqueTags(c.Tags) {
+				if contains(target.Tags, tag) {
+					tagScore += weights[tag]
+				}
+			}
+			tagScore /= targetTagWeight
+		}
+		textScore := search.Similarity(targetText, c.Title+" "+c.Description)
+		if tagScore == 0 && textScore == 0 {
+			continue
+		}
+		score := tagWeight*tagScore + textWeight*textScore + recencyWeight*recency(c.Created, now)
+		results = append(results, &scored{
+			result:  &Result{Id: c.Id, Score: score},
+			created: c.Created,
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].result.Score != results[j].result.Score {
+			return results[i].result.Score > results[j].result.Score
+		}
+		if !results[i].created.Equal(results[j].created) {
+			return results[i].created.After(results[j].created)
+		}
+		return results[i].result.Id > results[j].result.Id
+	})
+	if limit >= 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	ranked := make([]*Result, 0, len(results))
+	for _, r := range results {
+		ranked = append(ranked, r.result)
+	}
+	return ranked
+}
+
+// tagWeights は、タグごとの重みを返す
+// 多くのブログに付与されている汎用的なタグほど重みを小さくする(IDF)
+func tagWeights(target *Document, candidates []*Document) map[string]float64 {
+	frequency := map[string]int{}
+	documents := 0
+	count := func(d *Document) {
+		documents++
+		for _, tag := range uniqueTags(d.Tags) {
+			frequency[tag]++
+		}
+	}
+	count(target)
+	for _, c := range candidates {
+		if c.Id != target.Id {
+			count(c)
+		}
+	}
+	weights := make(map[string]float64, len(frequency))
+	for tag, df := range frequency {
+		weights[tag] = math.Log(1 + float64(documents)/float64(df))
+	}
+	return weights
+}
+
+// recency は、作成日時の新しさを0から1の範囲で返す
+func recency(created time.Time, now time.Time) float64 {
+	age := now.Sub(created)
+	if age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(recencyHalfLife))
+}
+
+func uniqueTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+	return result
+}
+
+func contains(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
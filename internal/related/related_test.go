@@ -0,0 +1,48 @@
+package related_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/related"
+)
+
+func Test_Rank(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	target := &related.Document{
+		Id:      1,
+		Title:   "Go言語でAPIサーバーを作る",
+		Tags:    []string{"go", "api"},
+		Created: now,
+	}
+	candidates := []*related.Document{
+		target,
+		// 汎用的なタグのみ一致
+		{Id: 2, Title: "料理のレシピ", Tags: []string{"go"}, Created: now},
+		// 珍しいタグが一致
+		{Id: 3, Title: "料理のレシピ", Tags: []string{"api"}, Created: now},
+		// タイトルのみ類似
+		{Id: 4, Title: "Go言語でCLIを作る", Created: now},
+		// 関連なし
+		{Id: 5, Title: "旅行記", Tags: []string{"travel"}, Created: now},
+		{Id: 6, Title: "料理のレシピ", Tags: []string{"go"}, Created: now.AddDate(-1, 0, 0)},
+		{Id: 7, Title: "料理のレシピ", Tags: []string{"go"}, Created: now},
+	}
+
+	got := related.Rank(target, candidates, now, 10)
+	ids := make([]int64, 0, len(got))
+	for _, r := range got {
+		ids = append(ids, r.Id)
+	}
+	// 同じスコアの場合は新しい順、作成日時も同じ場合はIDの降順
+	want := []int64{3, 7, 2, 6, 4}
+	if diff := cmp.Diff(want, ids); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	got = related.Rank(target, candidates, now, 2)
+	if len(got) != 2 {
+		t.Errorf("want 2 results, got %d", len(got))
+	}
+}
@@ -0,0 +1,151 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Weight はtsvectorのlexemeに付与する重み
+// ts_rankのデフォルトでは A=1.0, B=0.4, C=0.2, D=0.1 として評価される
+type Weight string
+
+const (
+	WeightA Weight = "A"
+	WeightB Weight = "B"
+	WeightC Weight = "C"
+	WeightD Weight = "D"
+)
+
+// maxPosition はtsvectorで扱える位置情報の最大値
+const maxPosition = 16383
+
+// maxPositionsPerLexeme は1つのlexemeに保持する位置情報の上限
+// 長い本文でtsvectorのサイズ上限(1MB)を超えないように制限する
+const maxPositionsPerLexeme = 8
+
+// Field はインデックス対象のテキストと重みの組
+type Field struct {
+	Text   string
+	Weight Weight
+}
+
+// normalize は全角英数の半角化と小文字化を行う
+func normalize(text string) string {
+	return strings.ToLower(norm.NFKC.String(text))
+}
+
+// segments は文字・数字以外の文字で区切った文字列の塊を返す
+// 日本語は単語境界を持たないため、空白や記号のみを区切りとして扱う
+func segments(text string) [][]rune {
+	var result [][]rune
+	var current []rune
+	for _, r := range normalize(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			current = append(current, r)
+			continue
+		}
+		if len(current) > 0 {
+			result = append(result, current)
+			current = nil
+		}
+	}
+	if len(current) > 0 {
+		result = append(result, current)
+	}
+	return result
+}
+
+// documentTokens はインデックス用のトークンを出現順に返す
+// 1文字の検索にも対応するため、uni-gramとbi-gramの両方を生成する
+func documentTokens(text string) []string {
+	var tokens []string
+	for _, seg := range segments(text) {
+		for i := range seg {
+			tokens = append(tokens, string(seg[i]))
+			if i+1 < len(seg) {
+				tokens = append(tokens, string(seg[i:i+2]))
+			}
+		}
+	}
+	return tokens
+}
+
+// QueryTokens は検索キーワードからトークンを生成する
+// 2文字以上の塊はbi-gram、1文字の塊はuni-gramとして扱い、重複は除外する
+func QueryTokens(keyword string) []string {
+	var tokens []string
+	seen := map[string]struct{}{}
+	add := func(token string) {
+		if _, ok := seen[token]; ok {
+			return
+		}
+		seen[token] = struct{}{}
+		tokens = append(tokens, token)
+	}
+	for _, seg := range segments(keyword) {
+		if len(seg) == 1 {
+			add(string(seg))
+			continue
+		}
+		for i := 0; i+1 < len(seg); i++ {
+			add(string(seg[i : i+2]))
+		}
+	}
+	return tokens
+}
+
+// quoteLexeme はtsvector/tsqueryのリテラルとしてlexemeをクォートする
+func quoteLexeme(lexeme string) string {
+	lexeme = strings.ReplaceAll(lexeme, `\`, `\\`)
+	lexeme = strings.ReplaceAll(lexeme, `'`, `''`)
+	return "'" + lexeme + "'"
+}
+
+// BuildDocument はフィールドからtsvectorのリテラル表現を生成する
+// 位置情報と重みはlexemeごとにまとめ、PostgreSQL側で::tsvectorにキャストして使用する
+func BuildDocument(fields ...Field) string {
+	type entry struct {
+		lexeme    string
+		positions []string
+	}
+	var entries []*entry
+	index := map[string]*entry{}
+	position := 0
+	for _, f := range fields {
+		for _, token := range documentTokens(f.Text) {
+			if position < maxPosition {
+				position++
+			}
+			e, ok := index[token]
+			if !ok {
+				e = &entry{lexeme: token}
+				index[token] = e
+				entries = append(entries, e)
+			}
+			if len(e.positions) >= maxPositionsPerLexeme {
+				continue
+			}
+			e.positions = append(e.positions, fmt.Sprintf("%d%s", position, f.Weight))
+		}
+	}
+	parts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		parts = append(parts, fmt.Sprintf("%s:%s", quoteLexeme(e.lexeme), strings.Join(e.positions, ",")))
+	}
+	return strings.Join(parts, " ")
+}
+
+// BuildQuery は検索キーワードからtsqueryのリテラル表現を生成する
+// すべてのトークンを含む文書のみ一致させるためAND条件で結合する
+// 検索可能なトークンが存在しない場合は空文字を返す
+func BuildQuery(keyword string) string {
+	tokens := QueryTokens(keyword)
+	parts := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		parts = append(parts, quoteLexeme(token))
+	}
+	return strings.Join(parts, " & ")
+}
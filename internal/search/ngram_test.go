@@ -0,0 +1,107 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shoet/blog/internal/search"
+)
+
+func Test_QueryTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyword string
+		want    []string
+	}{
+		{
+			name:    "日本語はbi-gramに分割される",
+			keyword: "日本語",
+			want:    []string{"日本", "本語"},
+		},
+		{
+			name:    "1文字の場合はuni-gram",
+			keyword: "猫",
+			want:    []string{"猫"},
+		},
+		{
+			name:    "全角英数は半角小文字に正規化される",
+			keyword: "ＧＯ言語",
+			want:    []string{"go", "o言", "言語"},
+		},
+		{
+			name:    "空白・記号で区切られ、重複は除外される",
+			keyword: "aaa, 日本",
+			want:    []string{"aa", "日本"},
+		},
+		{
+			name:    "記号のみの場合は空",
+			keyword: "!?",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := search.QueryTokens(tt.keyword)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_BuildQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyword string
+		want    string
+	}{
+		{
+			name:    "AND条件で結合される",
+			keyword: "検索語",
+			want:    "'検索' & '索語'",
+		},
+		{
+			name:    "検索可能なトークンが無い場合は空文字",
+			keyword: "  ",
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := search.BuildQuery(tt.keyword); got != tt.want {
+				t.Errorf("want: %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_BuildDocument(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []search.Field
+		want   string
+	}{
+		{
+			name: "フィールドごとに重みが付与される",
+			fields: []search.Field{
+				{Text: "Go", Weight: search.WeightA},
+				{Text: "入門", Weight: search.WeightD},
+			},
+			want: "'g':1A 'go':2A 'o':3A '入':4D '入門':5D '門':6D",
+		},
+		{
+			name: "同じlexemeの位置情報はまとめられる",
+			fields: []search.Field{
+				{Text: "aa", Weight: search.WeightB},
+			},
+			want: "'a':1B,3B 'aa':2B",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := search.BuildDocument(tt.fields...); got != tt.want {
+				t.Errorf("want: %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
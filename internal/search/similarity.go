@@ -0,0 +1,23 @@
+package search
+
+// Similarity は2つのテキストの類似度を0から1の範囲で返す
+// QueryTokensで生成したトークン集合のJaccard係数で評価する
+func Similarity(a string, b string) float64 {
+	tokensA := QueryTokens(a)
+	tokensB := QueryTokens(b)
+	if len(tokensA) == 0 || len(tokensB) == 0 {
+		return 0
+	}
+	set := make(map[string]struct{}, len(tokensA))
+	for _, token := range tokensA {
+		set[token] = struct{}{}
+	}
+	intersection := 0
+	for _, token := range tokensB {
+		if _, ok := set[token]; ok {
+			intersection++
+		}
+	}
+	union := len(tokensA) + len(tokensB) - intersection
+	return float64(intersection) / float64(union)
+}
@@ -0,0 +1,49 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/shoet/blog/internal/search"
+)
+
+func Test_Similarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want float64
+	}{
+		{
+			name: "同じテキストは1",
+			a:    "Go言語入門",
+			b:    "ｇｏ言語入門",
+			want: 1,
+		},
+		{
+			name: "共通のトークンがない場合は0",
+			a:    "日本語",
+			b:    "english",
+			want: 0,
+		},
+		{
+			name: "一部のトークンが共通",
+			a:    "日本語",
+			b:    "日本酒",
+			want: 1.0 / 3.0,
+		},
+		{
+			name: "空のテキストは0",
+			a:    "",
+			b:    "日本語",
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := search.Similarity(tt.a, tt.b)
+			if got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
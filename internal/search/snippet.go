@@ -0,0 +1,134 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// snippetRadius は一致箇所の前後に含める文字数
+const snippetRadius = 60
+
+const (
+	highlightOpen  = "<mark>"
+	highlightClose = "</mark>"
+	ellipsis       = "…"
+)
+
+// foldRune は大文字小文字・全角半角の違いを吸収した比較用の文字を返す
+// 位置を保つため1文字を1文字に変換する
+func foldRune(r rune) rune {
+	folded := []rune(width.Fold.String(string(r)))
+	if len(folded) == 1 {
+		r = folded[0]
+	}
+	return unicode.ToLower(r)
+}
+
+func foldRunes(rs []rune) []rune {
+	result := make([]rune, len(rs))
+	for i, r := range rs {
+		result[i] = foldRune(r)
+	}
+	return result
+}
+
+// matchRanges は text の中で keyword の各塊が一致する範囲を返す
+func matchRanges(text []rune, keyword string) [][2]int {
+	folded := foldRunes(text)
+	marked := make([]bool, len(text))
+	for _, seg := range segments(keyword) {
+		needle := foldRunes(seg)
+		for i := 0; i+len(needle) <= len(folded); i++ {
+			if equalRunes(folded[i:i+len(needle)], needle) {
+				for j := i; j < i+len(needle); j++ {
+					marked[j] = true
+				}
+			}
+		}
+	}
+	var ranges [][2]int
+	for i := 0; i < len(marked); i++ {
+		if !marked[i] {
+			continue
+		}
+		start := i
+		for i < len(marked) && marked[i] {
+			i++
+		}
+		ranges = append(ranges, [2]int{start, i})
+	}
+	return ranges
+}
+
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// collapseSpaces は改行や連続した空白を1つの空白にまとめる
+func collapseSpaces(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// Highlight は text の中でキーワードに一致する箇所の周辺を切り出し、
+// 一致箇所を<mark>タグで囲んだHTMLを返す
+// 一致箇所が存在しない場合は ok=false を返す
+func Highlight(text string, keyword string) (snippet string, ok bool) {
+	runes := []rune(collapseSpaces(text))
+	ranges := matchRanges(runes, keyword)
+	if len(ranges) == 0 {
+		return "", false
+	}
+	start := max(ranges[0][0]-snippetRadius, 0)
+	end := min(ranges[0][1]+snippetRadius, len(runes))
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString(ellipsis)
+	}
+	cursor := start
+	for _, r := range ranges {
+		if r[0] >= end {
+			break
+		}
+		if r[0] < cursor {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[cursor:r[0]])))
+		b.WriteString(highlightOpen)
+		b.WriteString(html.EscapeString(string(runes[r[0]:min(r[1], end)])))
+		b.WriteString(highlightClose)
+		cursor = min(r[1], end)
+	}
+	b.WriteString(html.EscapeString(string(runes[cursor:end])))
+	if end < len(runes) {
+		b.WriteString(ellipsis)
+	}
+	return b.String(), true
+}
+
+// Snippet は本文・概要の順にキーワードの一致箇所を探してスニペットを生成する
+// どちらにも一致しない場合は本文の先頭を返す
+func Snippet(content string, description string, keyword string) string {
+	if s, ok := Highlight(content, keyword); ok {
+		return s
+	}
+	if s, ok := Highlight(description, keyword); ok {
+		return s
+	}
+	runes := []rune(collapseSpaces(content))
+	if len(runes) > snippetRadius*2 {
+		return html.EscapeString(string(runes[:snippetRadius*2])) + ellipsis
+	}
+	return html.EscapeString(string(runes))
+}
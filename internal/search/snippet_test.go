@@ -0,0 +1,73 @@
+package search_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shoet/blog/internal/search"
+)
+
+func Test_Highlight(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		keyword string
+		want    string
+		wantOk  bool
+	}{
+		{
+			name:    "一致箇所が<mark>で囲まれる",
+			text:    "Goで全文検索を実装する",
+			keyword: "全文検索",
+			want:    "Goで<mark>全文検索</mark>を実装する",
+			wantOk:  true,
+		},
+		{
+			name:    "大文字小文字を区別しない",
+			text:    "PostgreSQL の設定",
+			keyword: "postgresql",
+			want:    "<mark>PostgreSQL</mark> の設定",
+			wantOk:  true,
+		},
+		{
+			name:    "HTMLはエスケープされる",
+			text:    "<script>検索</script>",
+			keyword: "検索",
+			want:    "&lt;script&gt;<mark>検索</mark>&lt;/script&gt;",
+			wantOk:  true,
+		},
+		{
+			name:    "一致しない場合",
+			text:    "本文",
+			keyword: "検索",
+			want:    "",
+			wantOk:  false,
+		},
+		{
+			name:    "一致箇所から離れた部分は省略される",
+			text:    strings.Repeat("あ", 100) + "検索" + strings.Repeat("い", 100),
+			keyword: "検索",
+			want:    "…" + strings.Repeat("あ", 60) + "<mark>検索</mark>" + strings.Repeat("い", 60) + "…",
+			wantOk:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := search.Highlight(tt.text, tt.keyword)
+			if ok != tt.wantOk {
+				t.Fatalf("want ok: %v, got: %v", tt.wantOk, ok)
+			}
+			if got != tt.want {
+				t.Errorf("want: %q, got: %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_Snippet(t *testing.T) {
+	got := search.Snippet("本文です", "概要に検索語を含む", "検索")
+	want := "概要に<mark>検索</mark>語を含む"
+	if got != want {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+}
@@ -0,0 +1,140 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxURLs は、1つのsitemapに含められるURLの上限
+const MaxURLs = 50000
+
+const sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URL は、sitemapに掲載する1件のURL
+type URL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// Documents は、生成済みのsitemap.xmlとrobots.txt
+// URLが上限を超える場合、Sitemapはsitemap indexとなり、各ページはPagesに格納される
+type Documents struct {
+	Sitemap      string    `json:"sitemap"`
+	Pages        []string  `json:"pages,omitempty"`
+	Robots       string    `json:"robots"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// PagePath は、分割したsitemapのパスを返す
+// pageは1始まり
+func PagePath(page int) string {
+	return fmt.Sprintf("/sitemaps/%d.xml", page)
+}
+
+type urlSet struct {
+	XMLName xml.Name   `xml:"urlset"`
+	XMLNS   string     `xml:"xmlns,attr"`
+	URLs    []urlEntry `xml:"url"`
+}
+
+type urlEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name       `xml:"sitemapindex"`
+	XMLNS    string         `xml:"xmlns,attr"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+type sitemapEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Build は、URLの一覧からsitemap.xmlとrobots.txtを生成する
+// siteURLはスキーマを含むサイトのURLで、分割したsitemapとrobots.txtの絶対URLに使用する
+func Build(siteURL string, urls []URL) (*Documents, error) {
+	siteURL = strings.TrimRight(siteURL, "/")
+	docs := &Documents{
+		Robots:       robots(siteURL),
+		LastModified: lastModified(urls),
+	}
+	if len(urls) <= MaxURLs {
+		b, err := buildURLSet(urls)
+		if err != nil {
+			return nil, err
+		}
+		docs.Sitemap = b
+		return docs, nil
+	}
+
+	index := sitemapIndex{XMLNS: sitemapNS}
+	for i := 0; i < len(urls); i += MaxURLs {
+		chunk := urls[i:min(i+MaxURLs, len(urls))]
+		b, err := buildURLSet(chunk)
+		if err != nil {
+			return nil, err
+		}
+		docs.Pages = append(docs.Pages, b)
+		index.Sitemaps = append(index.Sitemaps, sitemapEntry{
+			Loc:     siteURL + PagePath(len(docs.Pages)),
+			LastMod: formatLastMod(lastModified(chunk)),
+		})
+	}
+	b, err := marshal(&index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sitemap index: %w", err)
+	}
+	docs.Sitemap = b
+	return docs, nil
+}
+
+func buildURLSet(urls []URL) (string, error) {
+	set := urlSet{XMLNS: sitemapNS, URLs: make([]urlEntry, 0, len(urls))}
+	for _, u := range urls {
+		set.URLs = append(set.URLs, urlEntry{Loc: u.Loc, LastMod: formatLastMod(u.LastMod)})
+	}
+	b, err := marshal(&set)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal urlset: %w", err)
+	}
+	return b, nil
+}
+
+func marshal(v any) (string, error) {
+	b, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(b), nil
+}
+
+func robots(siteURL string) string {
+	var sb strings.Builder
+	sb.WriteString("User-agent: *\n")
+	sb.WriteString("Allow: /\n")
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("Sitemap: %s/sitemap.xml\n", siteURL))
+	return sb.String()
+}
+
+func lastModified(urls []URL) time.Time {
+	var last time.Time
+	for _, u := range urls {
+		if u.LastMod.After(last) {
+			last = u.LastMod
+		}
+	}
+	return last
+}
+
+func formatLastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,73 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newURLs(n int) []URL {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	urls := make([]URL, 0, n)
+	for i := 0; i < n; i++ {
+		urls = append(urls, URL{
+			Loc:     fmt.Sprintf("https://example.com/blogs/%d", i),
+			LastMod: base.Add(time.Duration(i) * time.Second),
+		})
+	}
+	return urls
+}
+
+func Test_Build(t *testing.T) {
+	urls := newURLs(3)
+	urls = append(urls, URL{Loc: "https://example.com/"})
+	docs, err := Build("https://example.com/", urls)
+	if err != nil {
+		t.Fatalf("failed to build: %v", err)
+	}
+	var got urlSet
+	if err := xml.Unmarshal([]byte(docs.Sitemap), &got); err != nil {
+		t.Fatalf("failed to unmarshal urlset: %v", err)
+	}
+	if len(got.URLs) != 4 || len(docs.Pages) != 0 {
+		t.Fatalf("unexpected urlset: %+v", got)
+	}
+	if got.URLs[2].LastMod != "2024-01-01T00:00:02Z" {
+		t.Errorf("unexpected lastmod: %s", got.URLs[2].LastMod)
+	}
+	if got.URLs[3].LastMod != "" {
+		t.Errorf("want empty lastmod, got %s", got.URLs[3].LastMod)
+	}
+	if !docs.LastModified.Equal(time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC)) {
+		t.Errorf("unexpected last modified: %v", docs.LastModified)
+	}
+	if !strings.Contains(docs.Robots, "Sitemap: https://example.com/sitemap.xml\n") {
+		t.Errorf("unexpected robots.txt: %s", docs.Robots)
+	}
+}
+
+func Test_Build_Index(t *testing.T) {
+	docs, err := Build("https://example.com", newURLs(MaxURLs+1))
+	if err != nil {
+		t.Fatalf("failed to build: %v", err)
+	}
+	var index sitemapIndex
+	if err := xml.Unmarshal([]byte(docs.Sitemap), &index); err != nil {
+		t.Fatalf("failed to unmarshal sitemap index: %v", err)
+	}
+	if len(index.Sitemaps) != 2 || len(docs.Pages) != 2 {
+		t.Fatalf("want 2 sitemaps, got %d", len(index.Sitemaps))
+	}
+	if index.Sitemaps[1].Loc != "https://example.com/sitemaps/2.xml" {
+		t.Errorf("unexpected loc: %s", index.Sitemaps[1].Loc)
+	}
+	var last urlSet
+	if err := xml.Unmarshal([]byte(docs.Pages[1]), &last); err != nil {
+		t.Fatalf("failed to unmarshal urlset: %v", err)
+	}
+	if len(last.URLs) != 1 {
+		t.Errorf("want 1 url in last page, got %d", len(last.URLs))
+	}
+}
@@ -0,0 +1,107 @@
+package slug
+
+import "strings"
+
+// romajiTable は、ひらがなとヘボン式ローマ字の対応表
+var romajiTable = map[string]string{
+	"あ": "a", "い": "i", "う": "u", "え": "e", "お": "o",
+	"か": "ka", "き": "ki", "く": "ku", "け": "ke", "こ": "ko",
+	"さ": "sa", "し": "shi", "す": "su", "せ": "se", "そ": "so",
+	"た": "ta", "ち": "chi", "つ": "tsu", "て": "te", "と": "to",
+	"な": "na", "に": "ni", "ぬ": "nu", "ね": "ne", "の": "no",
+	"は": "ha", "ひ": "hi", "ふ": "fu", "へ": "he", "ほ": "ho",
+	"ま": "ma", "み": "mi", "む": "mu", "め": "me", "も": "mo",
+	"や": "ya", "ゆ": "yu", "よ": "yo",
+	"ら": "ra", "り": "ri", "る": "ru", "れ": "re", "ろ": "ro",
+	"わ": "wa", "ゐ": "i", "ゑ": "e", "を": "o", "ん": "n",
+	"が": "ga", "ぎ": "gi", "ぐ": "gu", "げ": "ge", "ご": "go",
+	"ざ": "za", "じ": "ji", "ず": "zu", "ぜ": "ze", "ぞ": "zo",
+	"だ": "da", "ぢ": "ji", "づ": "zu", "で": "de", "ど": "do",
+	"ば": "ba", "び": "bi", "ぶ": "bu", "べ": "be", "ぼ": "bo",
+	"ぱ": "pa", "ぴ": "pi", "ぷ": "pu", "ぺ": "pe", "ぽ": "po",
+	"ゔ": "vu",
+	"ぁ": "a", "ぃ": "i", "ぅ": "u", "ぇ": "e", "ぉ": "o",
+	"ゃ": "ya", "ゅ": "yu", "ょ": "yo", "ゎ": "wa",
+	"きゃ": "kya", "きゅ": "kyu", "きょ": "kyo",
+	"しゃ": "sha", "しゅ": "shu", "しょ": "sho", "しぇ": "she",
+	"ちゃ": "cha", "ちゅ": "chu", "ちょ": "cho", "ちぇ": "che",
+	"にゃ": "nya", "にゅ": "nyu", "にょ": "nyo",
+	"ひゃ": "hya", "ひゅ": "hyu", "ひょ": "hyo",
+	"みゃ": "mya", "みゅ": "myu", "みょ": "myo",
+	"りゃ": "rya", "りゅ": "ryu", "りょ": "ryo",
+	"ぎゃ": "gya", "ぎゅ": "gyu", "ぎょ": "gyo",
+	"じゃ": "ja", "じゅ": "ju", "じょ": "jo", "じぇ": "je",
+	"びゃ": "bya", "びゅ": "byu", "びょ": "byo",
+	"ぴゃ": "pya", "ぴゅ": "pyu", "ぴょ": "pyo",
+	"ふぁ": "fa", "ふぃ": "fi", "ふぇ": "fe", "ふぉ": "fo",
+	"てぃ": "ti", "でぃ": "di", "とぅ": "tu", "どぅ": "du",
+	"うぃ": "wi", "うぇ": "we", "うぉ": "wo",
+	"ゔぁ": "va", "ゔぃ": "vi", "ゔぇ": "ve", "ゔぉ": "vo",
+}
+
+// toHiragana は、カタカナをひらがなに変換する
+func toHiragana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - 0x60
+	}
+	return r
+}
+
+// romanize は、文字列中のかなをローマ字に変換する
+// かな以外の文字はそのまま残し、かなとの境界には区切りの空白を入れる
+func romanize(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = toHiragana(r)
+	}
+	var sb strings.Builder
+	sokuon := false
+	inKana := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == 'っ' {
+			sokuon = true
+			continue
+		}
+		if r == 'ー' && inKana {
+			// 長音は直前の母音を重ねずに省略する
+			continue
+		}
+		romaji := ""
+		if i+1 < len(runes) {
+			if v, ok := romajiTable[string(runes[i:i+2])]; ok {
+				romaji = v
+				i++
+			}
+		}
+		if romaji == "" {
+			if v, ok := romajiTable[string(r)]; ok {
+				romaji = v
+			}
+		}
+		if romaji == "" {
+			if inKana {
+				sb.WriteRune(' ')
+			}
+			inKana = false
+			sokuon = false
+			sb.WriteRune(r)
+			continue
+		}
+		if !inKana {
+			sb.WriteRune(' ')
+		}
+		inKana = true
+		if sokuon {
+			// 促音は次の子音を重ねる (ch は tch とする)
+			if strings.HasPrefix(romaji, "ch") {
+				sb.WriteByte('t')
+			} else if romaji[0] != 'a' && romaji[0] != 'i' && romaji[0] != 'u' && romaji[0] != 'e' && romaji[0] != 'o' {
+				sb.WriteByte(romaji[0])
+			}
+			sokuon = false
+		}
+		sb.WriteString(romaji)
+	}
+	return sb.String()
+}
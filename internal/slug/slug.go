@@ -0,0 +1,104 @@
+package slug
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength は、スラッグの最大文字数
+const MaxLength = 200
+
+// minGeneratedLength は、タイトルから生成するスラッグの最小文字数
+// これより短いスラッグは意味をなさないため、IDによるスラッグとする
+const minGeneratedLength = 3
+
+// idPrefix は、IDによるスラッグの接頭辞
+// DBのトリガー(set_blog_slug_default)でも同じ形式でスラッグを設定する
+const idPrefix = "blog-"
+
+var validPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+var digitsPattern = regexp.MustCompile(`^[0-9]+$`)
+var idPattern = regexp.MustCompile(`^` + idPrefix + `[0-9]+$`)
+
+// IsValid は、スラッグとして使用できる文字列かを判定する
+// 数字のみのスラッグと"blog-数字"のスラッグはIDによるスラッグと衝突するため使用できない
+func IsValid(s string) bool {
+	if len(s) == 0 || len(s) > MaxLength {
+		return false
+	}
+	return validPattern.MatchString(s) && !digitsPattern.MatchString(s) && !idPattern.MatchString(s)
+}
+
+// FromId は、IDによるスラッグを返す
+func FromId(id int64) string {
+	return fmt.Sprintf("%s%d", idPrefix, id)
+}
+
+// IsIdBased は、スラッグがIDによるスラッグかを判定する
+func IsIdBased(s string, id int64) bool {
+	return s == FromId(id)
+}
+
+// FromTitle は、タイトルからスラッグを生成する
+// かなはローマ字に変換し、漢字などの変換できない文字は区切りとして扱う
+// 変換できない文字がタイトルの文字の3分の1以上を占める場合や、生成したスラッグが短すぎる場合は
+// タイトルの意味を表せないため空文字を返す
+// 空文字の場合、呼び出し側はIDによるスラッグとする
+func FromTitle(title string) string {
+	normalized := norm.NFKC.String(title)
+	if total, dropped := countDropped(normalized); dropped*3 >= total {
+		return ""
+	}
+	romanized := romanize(normalized)
+	var sb strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(romanized) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if hyphen && sb.Len() > 0 {
+				sb.WriteRune('-')
+			}
+			hyphen = false
+			sb.WriteRune(r)
+			continue
+		}
+		hyphen = true
+	}
+	s := sb.String()
+	if len(s) > MaxLength {
+		s = strings.TrimRight(s[:MaxLength], "-")
+	}
+	if len(s) < minGeneratedLength || !IsValid(s) {
+		return ""
+	}
+	return s
+}
+
+// countDropped は、文字列中の文字・数字の数と、そのうちスラッグに変換できない文字の数を返す
+// 英数字とかなは変換でき、漢字などそれ以外の文字は変換できない
+func countDropped(s string) (total int, dropped int) {
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != 'ー' {
+			continue
+		}
+		total++
+		if r < unicode.MaxASCII || unicode.In(r, unicode.Hiragana, unicode.Katakana) || r == 'ー' {
+			continue
+		}
+		dropped++
+	}
+	return total, dropped
+}
+
+// WithSuffix は、スラッグの末尾に連番を付与する
+// 最大文字数を超える場合は元のスラッグを切り詰める
+func WithSuffix(s string, n int) string {
+	suffix := fmt.Sprintf("-%d", n)
+	if len(s)+len(suffix) > MaxLength {
+		s = strings.TrimRight(s[:MaxLength-len(suffix)], "-")
+	}
+	return s + suffix
+}
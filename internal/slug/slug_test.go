@@ -0,0 +1,89 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_FromTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{name: "英数字のタイトル", title: "Hello, World! Go 1.24", want: "hello-world-go-1-24"},
+		{name: "全角英数字は半角にする", title: "ＧｏでＡＰＩ", want: "go-de-api"},
+		{name: "ひらがなをローマ字にする", title: "はじめての きゃんぷ", want: "hajimeteno-kyanpu"},
+		{name: "カタカナをローマ字にする", title: "チュートリアル", want: "chutoriaru"},
+		{name: "促音", title: "マッチ と ロケット", want: "matchi-to-roketto"},
+		{name: "わずかな漢字は区切りとして扱う", title: "Go 1.24のリリースノートまとめ 新機能", want: "go-1-24-noririsunotomatome"},
+		{name: "漢字の多いタイトルは空文字", title: "Go言語のテスト入門", want: ""},
+		{name: "ほとんど漢字のタイトルは空文字", title: "東京の天気", want: ""},
+		{name: "漢字のみのタイトルは空文字", title: "全文検索", want: ""},
+		{name: "数字のみのタイトルは空文字", title: "2024", want: ""},
+		{name: "短すぎるスラッグは空文字", title: "Go", want: ""},
+		{name: "IDによるスラッグと同じ形式は空文字", title: "Blog 12", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromTitle(tt.title); got != tt.want {
+				t.Errorf("FromTitle(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_IsValid(t *testing.T) {
+	tests := []struct {
+		slug string
+		want bool
+	}{
+		{slug: "hello-world", want: true},
+		{slug: "go-1-24", want: true},
+		{slug: "", want: false},
+		{slug: "123", want: false},
+		{slug: "blog-123", want: false},
+		{slug: "blog-go", want: true},
+		{slug: "Hello", want: false},
+		{slug: "hello--world", want: false},
+		{slug: "-hello", want: false},
+		{slug: "日本語", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			if got := IsValid(tt.slug); got != tt.want {
+				t.Errorf("IsValid(%q) = %v, want %v", tt.slug, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_WithSuffix(t *testing.T) {
+	if got := WithSuffix("hello", 2); got != "hello-2" {
+		t.Errorf("WithSuffix() = %q, want %q", got, "hello-2")
+	}
+	long := FromTitle(strings.Repeat("a", MaxLength))
+	got := WithSuffix(long, 10)
+	if len(got) != MaxLength || !IsValid(got) {
+		t.Errorf("WithSuffix() = %q, len %d", got, len(got))
+	}
+}
+
+func Test_IsIdBased(t *testing.T) {
+	tests := []struct {
+		slug string
+		want bool
+	}{
+		{slug: "blog-12", want: true},
+		{slug: "12", want: false},
+		{slug: "blog-13", want: false},
+		{slug: "hello", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			if got := IsIdBased(tt.slug, 12); got != tt.want {
+				t.Errorf("IsIdBased(%q) = %v, want %v", tt.slug, got, tt.want)
+			}
+		})
+	}
+}
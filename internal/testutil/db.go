@@ -65,7 +65,7 @@ func NewDBMySQLForTest(t *testing.T, ctx context.Context) (*sqlx.DB, error) {
 	return xdb, nil
 }
 
-func NewDBPostgreSQLForTest(t *testing.T, ctx context.Context) (*sqlx.DB, error) {
+func NewDBPostgreSQLForTest(t testing.TB, ctx context.Context) (*sqlx.DB, error) {
 	t.Helper()
 	dbDsn := fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s",
@@ -83,7 +83,7 @@ func NewDBPostgreSQLForTest(t *testing.T, ctx context.Context) (*sqlx.DB, error)
 	return sqlx.NewDb(db, "pgx"), nil
 }
 
-func RepositoryTestPrepare(t *testing.T, ctx context.Context, db *sqlx.DB) {
+func RepositoryTestPrepare(t testing.TB, ctx context.Context, db *sqlx.DB) {
 	t.Helper()
 
 	curDir, err := os.Getwd()
@@ -0,0 +1,41 @@
+package cancel_scheduled_blog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+)
+
+var ErrResourceNotFound = fmt.Errorf("unpublished blog not found")
+
+type BlogRepository interface {
+	UpdatePublishAt(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, publishAt *uint) (*models.Blog, error)
+}
+
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+}
+
+func NewUsecase(db infrastructure.DB, blogRepository BlogRepository) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+	}
+}
+
+// Run は、ブログの予約公開を取り消す
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId) (*models.Blog, error) {
+	blog, err := u.BlogRepository.UpdatePublishAt(ctx, u.DB, blogId, nil)
+	if err != nil {
+		if errors.Is(err, repository.ErrResourceNotFound) {
+			return nil, ErrResourceNotFound
+		}
+		return nil, fmt.Errorf("failed to update publish_at: %w", err)
+	}
+	return blog, nil
+}
@@ -0,0 +1,65 @@
+package count_blog_view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+var ErrResourceNotFound = fmt.Errorf("resource not found")
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+}
+
+type ViewCounter interface {
+	Count(ctx context.Context, blogId models.BlogId, visitor string, now time.Time) (bool, error)
+}
+
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+	ViewCounter    ViewCounter
+	Clocker        clocker.Clocker
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	viewCounter ViewCounter,
+	clocker clocker.Clocker,
+) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+		ViewCounter:    viewCounter,
+		Clocker:        clocker,
+	}
+}
+
+// Run は、公開済みのブログの閲覧を数える
+// 同じ訪問者による同じ日の閲覧は数えずにfalseを返す
+func (u *Usecase) Run(
+	ctx context.Context, blogId models.BlogId, ip string, userAgent string,
+) (bool, error) {
+	blog, err := u.BlogRepository.Get(ctx, u.DB, blogId)
+	if err != nil {
+		return false, fmt.Errorf("failed to get blog: %w", err)
+	}
+	if blog == nil || !blog.IsPublic {
+		return false, ErrResourceNotFound
+	}
+	ips := strings.Split(ip, ",")
+	originalIP := strings.Trim(ips[0], " ")
+	visitor := fmt.Sprintf("%s.%s", originalIP, userAgent)
+	counted, err := u.ViewCounter.Count(ctx, blogId, visitor, u.Clocker.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to count view: %w", err)
+	}
+	return counted, nil
+}
@@ -6,7 +6,16 @@ import (
 
 	"github.com/shoet/blog/internal/infrastructure"
 	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/locale"
+	"github.com/shoet/blog/internal/logging"
 	"github.com/shoet/blog/internal/session"
+	"github.com/shoet/blog/internal/slug"
+)
+
+var (
+	ErrInvalidSlug   = fmt.Errorf("invalid slug")
+	ErrSlugConflict  = fmt.Errorf("slug is already used")
+	ErrInvalidLocale = fmt.Errorf("invalid locale")
 )
 
 type BlogRepository interface {
@@ -15,6 +24,17 @@ type BlogRepository interface {
 	AddBlogTag(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, tagId models.TagId) (int64, error)
 	SelectTags(ctx context.Context, tx infrastructure.TX, tag string) ([]*models.Tag, error)
 	AddTag(ctx context.Context, tx infrastructure.TX, tag string) (models.TagId, error)
+	UpsertSearchIndex(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error
+	UpsertRendering(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error
+	GetSlugOwner(ctx context.Context, tx infrastructure.TX, slug string) (*models.BlogId, error)
+}
+
+type BlogRevisionRepository interface {
+	Add(ctx context.Context, tx infrastructure.TX, blog *models.Blog) (models.BlogRevisionId, error)
+}
+
+type SitemapCache interface {
+	Invalidate(ctx context.Context) error
 }
 
 type BlogService interface {
@@ -22,20 +42,26 @@ type BlogService interface {
 }
 
 type Usecase struct {
-	DB             infrastructure.DB
-	BlogRepository BlogRepository
-	BlogService    BlogService
+	DB                     infrastructure.DB
+	BlogRepository         BlogRepository
+	BlogRevisionRepository BlogRevisionRepository
+	BlogService            BlogService
+	SitemapCache           SitemapCache
 }
 
 func NewUsecase(
 	db infrastructure.DB,
 	blogRepository BlogRepository,
+	blogRevisionRepository BlogRevisionRepository,
 	blogService BlogService,
+	sitemapCache SitemapCache,
 ) *Usecase {
 	return &Usecase{
-		DB:             db,
-		BlogRepository: blogRepository,
-		BlogService:    blogService,
+		DB:                     db,
+		BlogRepository:         blogRepository,
+		BlogRevisionRepository: blogRevisionRepository,
+		BlogService:            blogService,
+		SitemapCache:           sitemapCache,
 	}
 }
 
@@ -47,6 +73,20 @@ func (u *Usecase) Run(ctx context.Context, blog *models.Blog) (*models.Blog, err
 	if err := u.BlogService.Validate(ctx, sessionUserId, blog); err != nil {
 		return nil, fmt.Errorf("failed to BlogService.Validate: %w", err)
 	}
+	// 公開済みのブログは予約公開の対象外
+	if blog.IsPublic {
+		blog.PublishAt = nil
+	}
+	if blog.Slug != "" && !slug.IsValid(blog.Slug) {
+		return nil, ErrInvalidSlug
+	}
+	if blog.DefaultLocale != "" {
+		defaultLocale, err := locale.Normalize(blog.DefaultLocale)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidLocale, err)
+		}
+		blog.DefaultLocale = defaultLocale
+	}
 
 	transactor := infrastructure.NewTransactionProvider(u.DB)
 
@@ -69,6 +109,11 @@ func (u *Usecase) Run(ctx context.Context, blog *models.Blog) (*models.Blog, err
 			}
 		}
 
+		// resolve slug
+		if err := u.resolveSlug(ctx, tx, blog); err != nil {
+			return nil, err
+		}
+
 		// add blog
 		id, err := u.BlogRepository.Add(ctx, tx, blog)
 		if err != nil {
@@ -87,6 +132,21 @@ func (u *Usecase) Run(ctx context.Context, blog *models.Blog) (*models.Blog, err
 		if err != nil {
 			return nil, fmt.Errorf("failed to get blog: %w", err)
 		}
+
+		// update search index
+		if err := u.BlogRepository.UpsertSearchIndex(ctx, tx, newBlog); err != nil {
+			return nil, fmt.Errorf("failed to upsert search index: %w", err)
+		}
+
+		// render content
+		if err := u.BlogRepository.UpsertRendering(ctx, tx, newBlog); err != nil {
+			return nil, fmt.Errorf("failed to upsert rendering: %w", err)
+		}
+
+		// add first revision
+		if _, err := u.BlogRevisionRepository.Add(ctx, tx, newBlog); err != nil {
+			return nil, fmt.Errorf("failed to add blog revision: %w", err)
+		}
 		return newBlog, nil
 	})
 
@@ -94,6 +154,12 @@ func (u *Usecase) Run(ctx context.Context, blog *models.Blog) (*models.Blog, err
 		return nil, fmt.Errorf("failed to get blog: %w", err)
 	}
 
+	// 公開中のブログが変わるため、sitemapのキャッシュを破棄する
+	// ブログの作成は完了しているため、破棄に失敗してもエラーは記録のみとする
+	if err := u.SitemapCache.Invalidate(ctx); err != nil {
+		logging.GetLogger(ctx).Error(fmt.Sprintf("failed to invalidate sitemap cache: %v", err))
+	}
+
 	blog, ok := result.(*models.Blog)
 	if !ok {
 		return nil, fmt.Errorf("failed to type assertion: %w", err)
@@ -101,3 +167,42 @@ func (u *Usecase) Run(ctx context.Context, blog *models.Blog) (*models.Blog, err
 
 	return blog, nil
 }
+
+// maxSlugSuffix は、タイトルから生成したスラッグが重複した場合に試行する連番の上限
+const maxSlugSuffix = 100
+
+// resolveSlug は、ブログのスラッグを確定する
+// 指定されたスラッグは重複していればエラーとし、未指定の場合はタイトルから生成する
+// タイトルから生成できない場合は空のままとし、DB側でIDによるスラッグ("blog-ID")を設定する
+func (u *Usecase) resolveSlug(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error {
+	if blog.Slug != "" {
+		owner, err := u.BlogRepository.GetSlugOwner(ctx, tx, blog.Slug)
+		if err != nil {
+			return fmt.Errorf("failed to get slug owner: %w", err)
+		}
+		if owner != nil {
+			return ErrSlugConflict
+		}
+		return nil
+	}
+
+	base := slug.FromTitle(blog.Title)
+	if base == "" {
+		return nil
+	}
+	for i := 1; i <= maxSlugSuffix; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = slug.WithSuffix(base, i)
+		}
+		owner, err := u.BlogRepository.GetSlugOwner(ctx, tx, candidate)
+		if err != nil {
+			return fmt.Errorf("failed to get slug owner: %w", err)
+		}
+		if owner == nil {
+			blog.Slug = candidate
+			return nil
+		}
+	}
+	return nil
+}
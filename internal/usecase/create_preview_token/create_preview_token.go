@@ -0,0 +1,62 @@
+package create_preview_token
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/session"
+)
+
+var ErrResourceNotFound = fmt.Errorf("blog not found")
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+}
+
+type PreviewTokenService interface {
+	Generate(ctx context.Context, blogId models.BlogId, expiresIn time.Duration) (*models.PreviewToken, error)
+}
+
+type Usecase struct {
+	DB                  infrastructure.DB
+	BlogRepository      BlogRepository
+	PreviewTokenService PreviewTokenService
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	previewTokenService PreviewTokenService,
+) *Usecase {
+	return &Usecase{
+		DB:                  db,
+		BlogRepository:      blogRepository,
+		PreviewTokenService: previewTokenService,
+	}
+}
+
+// Run は、ブログの著者のみがプレビュー用トークンを発行できる
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId, expiresIn time.Duration) (*models.PreviewToken, error) {
+	sessionUserId, err := session.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+	blog, err := u.BlogRepository.Get(ctx, u.DB, blogId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog: %w", err)
+	}
+	if blog == nil {
+		return nil, ErrResourceNotFound
+	}
+	if blog.AuthorId != sessionUserId {
+		return nil, fmt.Errorf("can't create preview token for other user's blog")
+	}
+	previewToken, err := u.PreviewTokenService.Generate(ctx, blogId, expiresIn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate preview token: %w", err)
+	}
+	return previewToken, nil
+}
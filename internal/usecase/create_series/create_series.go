@@ -0,0 +1,111 @@
+package create_series
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/session"
+)
+
+var (
+	ErrInvalidBlogIds      = fmt.Errorf("invalid blog ids")
+	ErrBlogAlreadyInSeries = fmt.Errorf("blog already belongs to other series")
+)
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+}
+
+type SeriesRepository interface {
+	Add(ctx context.Context, tx infrastructure.TX, series *models.Series) (models.SeriesId, error)
+	Get(ctx context.Context, tx infrastructure.TX, id models.SeriesId) (*models.Series, error)
+	GetByBlogId(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) (*models.Series, error)
+	ReplaceBlogs(ctx context.Context, tx infrastructure.TX, id models.SeriesId, blogIds []models.BlogId) error
+}
+
+type Usecase struct {
+	DB               infrastructure.DB
+	BlogRepository   BlogRepository
+	SeriesRepository SeriesRepository
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	seriesRepository SeriesRepository,
+) *Usecase {
+	return &Usecase{
+		DB:               db,
+		BlogRepository:   blogRepository,
+		SeriesRepository: seriesRepository,
+	}
+}
+
+// Run は、シリーズを作成し、指定された順序でブログを含める
+// ブログは自身のもので、他のシリーズに所属していない必要がある
+func (u *Usecase) Run(
+	ctx context.Context, series *models.Series, blogIds []models.BlogId,
+) (*models.Series, error) {
+	sessionUserId, err := session.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+	series.AuthorId = sessionUserId
+
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		if err := u.validateBlogIds(ctx, tx, sessionUserId, blogIds); err != nil {
+			return nil, err
+		}
+		id, err := u.SeriesRepository.Add(ctx, tx, series)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add series: %w", err)
+		}
+		if err := u.SeriesRepository.ReplaceBlogs(ctx, tx, id, blogIds); err != nil {
+			return nil, fmt.Errorf("failed to replace series blogs: %w", err)
+		}
+		newSeries, err := u.SeriesRepository.Get(ctx, tx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get series: %w", err)
+		}
+		return newSeries, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create series: %w", err)
+	}
+
+	newSeries, ok := result.(*models.Series)
+	if !ok {
+		return nil, fmt.Errorf("failed to type assertion")
+	}
+	return newSeries, nil
+}
+
+func (u *Usecase) validateBlogIds(
+	ctx context.Context, tx infrastructure.TX, userId models.UserId, blogIds []models.BlogId,
+) error {
+	seen := make(map[models.BlogId]bool, len(blogIds))
+	for _, blogId := range blogIds {
+		if seen[blogId] {
+			return ErrInvalidBlogIds
+		}
+		seen[blogId] = true
+		blog, err := u.BlogRepository.Get(ctx, tx, blogId)
+		if err != nil {
+			return fmt.Errorf("failed to get blog: %w", err)
+		}
+		if blog == nil || blog.AuthorId != userId {
+			return ErrInvalidBlogIds
+		}
+		current, err := u.SeriesRepository.GetByBlogId(ctx, tx, blogId)
+		if err != nil {
+			return fmt.Errorf("failed to get series by blog id: %w", err)
+		}
+		if current != nil {
+			return ErrBlogAlreadyInSeries
+		}
+	}
+	return nil
+}
@@ -2,39 +2,58 @@ package delete_blog
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/shoet/blog/internal/clocker"
 	"github.com/shoet/blog/internal/infrastructure"
 	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/logging"
 	"github.com/shoet/blog/internal/session"
-	"golang.org/x/exp/slices"
+)
+
+var (
+	ErrResourceNotFound = fmt.Errorf("blog not found")
+	// ErrPreconditionFailedは、取得後に他のリクエストでブログが更新されている場合のエラー
+	ErrPreconditionFailed = fmt.Errorf("blog has been modified")
 )
 
 type BlogRepository interface {
 	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
-	Delete(ctx context.Context, tx infrastructure.TX, id models.BlogId) error
-	SelectBlogsTagsByOtherUsingBlog(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) ([]*models.BlogsTags, error)
-	SelectBlogsTags(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) ([]*models.BlogsTags, error)
-	DeleteTag(ctx context.Context, tx infrastructure.TX, tagId models.TagId) error
-	DeleteBlogsTags(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, tagId models.TagId) error
+	Trash(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, deletedAt uint, version *int64) error
+}
+
+type SitemapCache interface {
+	Invalidate(ctx context.Context) error
 }
 
 type Usecase struct {
 	DB             infrastructure.DB
 	BlogRepository BlogRepository
+	SitemapCache   SitemapCache
+	Clocker        clocker.Clocker
 }
 
 func NewUsecase(
 	db infrastructure.DB,
 	blogRepository BlogRepository,
+	sitemapCache SitemapCache,
+	clocker clocker.Clocker,
 ) *Usecase {
 	return &Usecase{
 		DB:             db,
 		BlogRepository: blogRepository,
+		SitemapCache:   sitemapCache,
+		Clocker:        clocker,
 	}
 }
 
-func (u *Usecase) Run(ctx context.Context, blogId models.BlogId) (models.BlogId, error) {
+// Run は、ブログをゴミ箱に移動する
+// コメントやタグ、シリーズへの所属は残し、ゴミ箱から元に戻せるようにする
+// 完全に削除する場合はpurge_blogを使用する
+// versionを指定した場合は、ブログのバージョンが一致しない場合にErrPreconditionFailedを返す
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId, version *int64) (models.BlogId, error) {
 	sessionUserId, err := session.GetUserId(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to session.GetUserId: %w", err)
@@ -47,46 +66,22 @@ func (u *Usecase) Run(ctx context.Context, blogId models.BlogId) (models.BlogId,
 		if err != nil {
 			return 0, fmt.Errorf("failed to BlogRepository.Get: %w", err)
 		}
+		if blog == nil {
+			return 0, ErrResourceNotFound
+		}
 
 		if blog.AuthorId != sessionUserId {
 			return 0, fmt.Errorf("can't delete other user's blog")
 		}
 
-		// delete blogs_tags -----------------
-		// select using other blog tags
-		var usingTags models.BlogsTagsArray
-		usingTags, err = u.BlogRepository.SelectBlogsTagsByOtherUsingBlog(ctx, tx, blog.Id)
-		if err != nil {
-			return 0, fmt.Errorf("failed to select using tags: %w", err)
-		}
-
-		//  select will delete tags
-		blogsTags, err := u.BlogRepository.SelectBlogsTags(ctx, tx, blog.Id)
-		if err != nil {
-			return 0, fmt.Errorf("failed to select blogs_tags: %w", err)
-		}
-		var willDeleteTags []models.TagId
-		for _, t := range blogsTags {
-			if !slices.Contains(usingTags.TagIds(), t.TagId) {
-				willDeleteTags = append(willDeleteTags, t.TagId)
-			}
-		}
-
-		for _, tagId := range willDeleteTags {
-			// delete tags
-			if err := u.BlogRepository.DeleteTag(ctx, tx, tagId); err != nil {
-				return 0, fmt.Errorf("failed to delete tags: %w", err)
+		if err := u.BlogRepository.Trash(ctx, tx, blog.Id, uint(u.Clocker.Now().Unix()), version); err != nil {
+			if errors.Is(err, repository.ErrVersionMismatch) {
+				return 0, ErrPreconditionFailed
 			}
-			// delete blogs_tags
-			if err := u.BlogRepository.DeleteBlogsTags(ctx, tx, blog.Id, tagId); err != nil {
-				return 0, fmt.Errorf("failed to delete blogs_tags: %w", err)
+			if errors.Is(err, repository.ErrResourceNotFound) {
+				return 0, ErrResourceNotFound
 			}
-		}
-
-		// delete blogs ----------------------
-		err = u.BlogRepository.Delete(ctx, tx, blog.Id)
-		if err != nil {
-			return 0, fmt.Errorf("failed to delete blog: %w", err)
+			return 0, fmt.Errorf("failed to trash blog: %w", err)
 		}
 
 		return blog.Id, nil
@@ -96,6 +91,12 @@ func (u *Usecase) Run(ctx context.Context, blogId models.BlogId) (models.BlogId,
 		return 0, fmt.Errorf("failed to delete blog: %w", err)
 	}
 
+	// 公開中のブログが変わるため、sitemapのキャッシュを破棄する
+	// ブログの削除は完了しているため、破棄に失敗してもエラーは記録のみとする
+	if err := u.SitemapCache.Invalidate(ctx); err != nil {
+		logging.GetLogger(ctx).Error(fmt.Sprintf("failed to invalidate sitemap cache: %v", err))
+	}
+
 	blogId, ok := result.(models.BlogId)
 	if !ok {
 		return 0, fmt.Errorf("failed to type assertion: %w", err)
@@ -0,0 +1,77 @@
+package delete_blog_translation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/locale"
+	"github.com/shoet/blog/internal/session"
+)
+
+var (
+	ErrResourceNotFound = fmt.Errorf("resource not found")
+	ErrInvalidLocale    = fmt.Errorf("invalid locale")
+)
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	GetTranslation(
+		ctx context.Context, tx infrastructure.TX, blogId models.BlogId, locale string,
+	) (*models.BlogTranslation, error)
+	DeleteTranslation(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, locale string) error
+}
+
+// delete_blog_translation.Usecaseは、ブログの言語ごとの翻訳を削除するユースケースです。
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+}
+
+func NewUsecase(db infrastructure.DB, blogRepository BlogRepository) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+	}
+}
+
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId, localeTag string) error {
+	sessionUserId, err := session.GetUserId(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+	normalized, err := locale.Normalize(localeTag)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLocale, err)
+	}
+
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	_, err = transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		blog, err := u.BlogRepository.Get(ctx, tx, blogId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blog: %w", err)
+		}
+		if blog == nil {
+			return nil, ErrResourceNotFound
+		}
+		if sessionUserId != blog.AuthorId {
+			return nil, fmt.Errorf("can't delete other user's blog translation")
+		}
+		translation, err := u.BlogRepository.GetTranslation(ctx, tx, blogId, normalized)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get translation: %w", err)
+		}
+		if translation == nil {
+			return nil, ErrResourceNotFound
+		}
+		if err := u.BlogRepository.DeleteTranslation(ctx, tx, blogId, normalized); err != nil {
+			return nil, fmt.Errorf("failed to delete translation: %w", err)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete translation: %w", err)
+	}
+	return nil
+}
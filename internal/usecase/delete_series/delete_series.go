@@ -0,0 +1,59 @@
+package delete_series
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/session"
+)
+
+var ErrResourceNotFound = fmt.Errorf("series not found")
+
+type SeriesRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.SeriesId) (*models.Series, error)
+	Delete(ctx context.Context, tx infrastructure.TX, id models.SeriesId) error
+}
+
+type Usecase struct {
+	DB               infrastructure.DB
+	SeriesRepository SeriesRepository
+}
+
+func NewUsecase(db infrastructure.DB, seriesRepository SeriesRepository) *Usecase {
+	return &Usecase{
+		DB:               db,
+		SeriesRepository: seriesRepository,
+	}
+}
+
+// Run は、シリーズを削除する
+// シリーズに含まれていたブログは削除しない
+func (u *Usecase) Run(ctx context.Context, id models.SeriesId) error {
+	sessionUserId, err := session.GetUserId(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	_, err = transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		series, err := u.SeriesRepository.Get(ctx, tx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get series: %w", err)
+		}
+		if series == nil {
+			return nil, ErrResourceNotFound
+		}
+		if series.AuthorId != sessionUserId {
+			return nil, fmt.Errorf("can't delete other user's series")
+		}
+		if err := u.SeriesRepository.Delete(ctx, tx, id); err != nil {
+			return nil, fmt.Errorf("failed to delete series: %w", err)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete series: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package diff_blog_revisions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/session"
+)
+
+var ErrResourceNotFound = fmt.Errorf("blog revision not found")
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+}
+
+type BlogRevisionRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, revision int64) (*models.BlogRevision, error)
+}
+
+type Usecase struct {
+	DB                     infrastructure.DB
+	BlogRepository         BlogRepository
+	BlogRevisionRepository BlogRevisionRepository
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	blogRevisionRepository BlogRevisionRepository,
+) *Usecase {
+	return &Usecase{
+		DB:                     db,
+		BlogRepository:         blogRepository,
+		BlogRevisionRepository: blogRevisionRepository,
+	}
+}
+
+// Run は、2つのリビジョン間の差分をunified diff形式で返す
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId, from int64, to int64) (string, error) {
+	sessionUserId, err := session.GetUserId(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+	blog, err := u.BlogRepository.Get(ctx, u.DB, blogId)
+	if err != nil {
+		return "", fmt.Errorf("failed to get blog: %w", err)
+	}
+	if blog == nil {
+		return "", ErrResourceNotFound
+	}
+	if blog.AuthorId != sessionUserId {
+		return "", fmt.Errorf("can't diff other user's blog revisions")
+	}
+
+	fromRevision, err := u.getRevision(ctx, blogId, from)
+	if err != nil {
+		return "", err
+	}
+	toRevision, err := u.getRevision(ctx, blogId, to)
+	if err != nil {
+		return "", err
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(toDocument(fromRevision)),
+		B:        difflib.SplitLines(toDocument(toRevision)),
+		FromFile: fmt.Sprintf("revision/%d", from),
+		ToFile:   fmt.Sprintf("revision/%d", to),
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get unified diff: %w", err)
+	}
+	return diff, nil
+}
+
+func (u *Usecase) getRevision(ctx context.Context, blogId models.BlogId, revision int64) (*models.BlogRevision, error) {
+	blogRevision, err := u.BlogRevisionRepository.Get(ctx, u.DB, blogId, revision)
+	if err != nil {
+		if errors.Is(err, repository.ErrResourceNotFound) {
+			return nil, ErrResourceNotFound
+		}
+		return nil, fmt.Errorf("failed to get blog revision: %w", err)
+	}
+	return blogRevision, nil
+}
+
+// toDocument は、差分を取るためにリビジョンをヘッダー付きのテキストに変換する
+func toDocument(r *models.BlogRevision) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "title: %s\n", r.Title)
+	fmt.Fprintf(&sb, "description: %s\n", r.Description)
+	fmt.Fprintf(&sb, "thumbnailImageFileName: %s\n", r.ThumbnailImageFileName)
+	fmt.Fprintf(&sb, "isPublic: %t\n", r.IsPublic)
+	fmt.Fprintf(&sb, "tags: %s\n", strings.Join(r.Tags, ", "))
+	sb.WriteString("---\n")
+	sb.WriteString(r.Content)
+	if !strings.HasSuffix(r.Content, "\n") {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
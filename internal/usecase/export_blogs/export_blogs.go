@@ -0,0 +1,54 @@
+package export_blogs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type BlogRepository interface {
+	ListAllIds(ctx context.Context, tx infrastructure.TX) ([]models.BlogId, error)
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+}
+
+// export_blogs.Usecaseは、すべてのブログを本文とタグを含めて取得するユースケースです。
+// ブログをファイルに書き出し、git管理や環境間の移行に使用します。
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+	}
+}
+
+// Runは、すべてのブログをIDの昇順で返す
+func (u *Usecase) Run(ctx context.Context) ([]*models.Blog, error) {
+	ids, err := u.BlogRepository.ListAllIds(ctx, u.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blog ids: %w", err)
+	}
+	blogs := make([]*models.Blog, 0, len(ids))
+	for _, id := range ids {
+		blog, err := u.BlogRepository.Get(ctx, u.DB, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blog: %w", err)
+		}
+		if blog == nil {
+			continue
+		}
+		// 書き出すたびに差分が出ないよう、タグは名前順とする
+		slices.Sort(blog.Tags)
+		blogs = append(blogs, blog)
+	}
+	return blogs, nil
+}
@@ -0,0 +1,89 @@
+package flush_blog_views
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type BlogRepository interface {
+	AddViews(ctx context.Context, tx infrastructure.TX, views []*models.BlogViewDaily) error
+}
+
+type ViewCounter interface {
+	Pop(ctx context.Context) ([]*models.BlogViewDaily, error)
+	Restore(ctx context.Context, views []*models.BlogViewDaily) error
+}
+
+type Locker interface {
+	TryLock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error)
+	Unlock(ctx context.Context, key string, token string) error
+}
+
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+	ViewCounter    ViewCounter
+	Locker         Locker
+	LockTTL        time.Duration
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	viewCounter ViewCounter,
+	locker Locker,
+	lockTTL time.Duration,
+) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+		ViewCounter:    viewCounter,
+		Locker:         locker,
+		LockTTL:        lockTTL,
+	}
+}
+
+// Run は、KVSで集計中の閲覧数をblog_view_dailyに加算する
+// 複数インスタンスで同時に実行されないよう、ロックを取得できた場合のみ処理する
+func (u *Usecase) Run(ctx context.Context) ([]*models.BlogViewDaily, error) {
+	token := uuid.NewString()
+	ok, err := u.Locker.TryLock(ctx, config.KVS_VIEW_FLUSH_LOCK, token, u.LockTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock: %w", err)
+	}
+	if !ok {
+		// 他のインスタンスが処理中
+		return []*models.BlogViewDaily{}, nil
+	}
+	defer u.Locker.Unlock(context.WithoutCancel(ctx), config.KVS_VIEW_FLUSH_LOCK, token)
+
+	views, err := u.ViewCounter.Pop(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pop views: %w", err)
+	}
+	if len(views) == 0 {
+		return views, nil
+	}
+
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	_, err = transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		if err := u.BlogRepository.AddViews(ctx, tx, views); err != nil {
+			return nil, fmt.Errorf("failed to add views: %w", err)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		// 閲覧数が失われないよう、次回の実行で再度加算する
+		if rerr := u.ViewCounter.Restore(context.WithoutCancel(ctx), views); rerr != nil {
+			return nil, fmt.Errorf("failed to restore views: %v: %w", rerr, err)
+		}
+		return nil, fmt.Errorf("failed to flush views: %w", err)
+	}
+	return views, nil
+}
@@ -0,0 +1,111 @@
+package get_archive_blogs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/options"
+)
+
+type BlogRepository interface {
+	Query(
+		ctx context.Context, tx infrastructure.TX, option *options.ListBlogOptions,
+	) (models.Blogs, error)
+
+	Count(
+		ctx context.Context, tx infrastructure.TX, filter options.BlogFilter,
+	) (int64, error)
+}
+
+type UserProfileRepository interface {
+	ListByUserIds(
+		ctx context.Context, tx infrastructure.TX, userIds []models.UserId,
+	) (map[models.UserId]*models.UserProfile, error)
+}
+
+// get_archive_blogs.Usecaseは、指定した年月に作成された公開済みのブログ一覧を取得するユースケースです。
+// 年月はサイトのタイムゾーンで判定し、ページングはオフセット方式で実装しています。
+type Usecase struct {
+	DB                    infrastructure.DB
+	BlogRepository        BlogRepository
+	UserProfileRepository UserProfileRepository
+	SiteLocation          *time.Location
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	userProfileRepository UserProfileRepository,
+	siteLocation *time.Location,
+) *Usecase {
+	return &Usecase{
+		DB:                    db,
+		BlogRepository:        blogRepository,
+		UserProfileRepository: userProfileRepository,
+		SiteLocation:          siteLocation,
+	}
+}
+
+type Input struct {
+	Year  int
+	Month int
+	Limit *int64
+	Page  *int64
+	// WithAuthorがtrueの場合は、著者のプロフィールを設定する
+	WithAuthor bool
+}
+
+type transactionResult struct {
+	blogs      models.Blogs
+	blogsCount int64
+}
+
+// Runは、年月のブログを作成日時の新しい順に取得し、年月のブログの総数とあわせて返す
+func (u *Usecase) Run(ctx context.Context, input *Input) ([]*models.Blog, int64, error) {
+	period, err := models.NewArchivePeriod(input.Year, input.Month, u.SiteLocation)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create archive period: %w", err)
+	}
+	filter := &options.BlogFilter{
+		IsPublic:    true,
+		CreatedFrom: &period.From,
+		CreatedTo:   &period.To,
+	}
+	sort := &options.BlogSort{Key: options.BlogSortCreated}
+	option, err := options.NewListBlogOffsetOptions(filter, sort, input.Limit, input.Page)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create list option: %w", err)
+	}
+
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		blogs, err := u.BlogRepository.Query(ctx, tx, option)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query blogs: %w", err)
+		}
+		if input.WithAuthor {
+			profiles, err := u.UserProfileRepository.ListByUserIds(ctx, tx, blogs.AuthorIds())
+			if err != nil {
+				return nil, fmt.Errorf("failed to list user profiles: %w", err)
+			}
+			blogs.SetAuthors(profiles)
+		}
+		blogsCount, err := u.BlogRepository.Count(ctx, tx, option.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count blogs: %w", err)
+		}
+		return transactionResult{blogs: blogs, blogsCount: blogsCount}, nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get archive blogs: %w", err)
+	}
+
+	txResult, ok := result.(transactionResult)
+	if !ok {
+		return nil, 0, fmt.Errorf("failed to cast result")
+	}
+	return txResult.blogs.ToSlice(), txResult.blogsCount, nil
+}
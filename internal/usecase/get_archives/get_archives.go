@@ -0,0 +1,54 @@
+package get_archives
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type BlogRepository interface {
+	ListArchives(ctx context.Context, tx infrastructure.TX, timezone string) ([]*models.BlogArchive, error)
+}
+
+// get_archives.Usecaseは、公開済みのブログの件数を年月ごとに取得するユースケースです。
+// 年月はサイトのタイムゾーンで判定します。
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+	SiteLocation   *time.Location
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	siteLocation *time.Location,
+) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+		SiteLocation:   siteLocation,
+	}
+}
+
+func (u *Usecase) Run(ctx context.Context) ([]*models.BlogArchive, error) {
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		archives, err := u.BlogRepository.ListArchives(ctx, tx, u.SiteLocation.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list archives: %w", err)
+		}
+		return archives, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archives: %w", err)
+	}
+
+	archives, ok := result.([]*models.BlogArchive)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert result to []*models.BlogArchive")
+	}
+	return archives, nil
+}
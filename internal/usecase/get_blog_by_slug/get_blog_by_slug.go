@@ -0,0 +1,74 @@
+package get_blog_by_slug
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type BlogRepository interface {
+	GetIdBySlug(ctx context.Context, tx infrastructure.TX, slug string) (*models.BlogId, error)
+	GetRedirectSlug(ctx context.Context, tx infrastructure.TX, slug string) (*string, error)
+}
+
+// BlogDetailGetter は、IDからブログの詳細を取得する
+type BlogDetailGetter interface {
+	Run(
+		ctx context.Context, blogId models.BlogId, withRendered bool, withAuthor bool, locales []string,
+	) (*models.Blog, error)
+}
+
+type Usecase struct {
+	DB               infrastructure.DB
+	BlogRepository   BlogRepository
+	BlogDetailGetter BlogDetailGetter
+}
+
+func NewUsecase(
+	db infrastructure.DB, blogRepository BlogRepository, blogDetailGetter BlogDetailGetter,
+) *Usecase {
+	return &Usecase{
+		DB:               db,
+		BlogRepository:   blogRepository,
+		BlogDetailGetter: blogDetailGetter,
+	}
+}
+
+// Result は、スラッグに一致したブログか、旧スラッグの場合はリダイレクト先のスラッグを持つ
+type Result struct {
+	Blog         *models.Blog
+	RedirectSlug *string
+}
+
+// Run は、スラッグからブログを取得する
+// 一致するブログも旧スラッグもない場合はnilを返す
+// withRenderedがtrueの場合は本文のレンダリング結果、withAuthorがtrueの場合は著者のプロフィールも設定する
+// localesは希望する言語の優先順
+func (u *Usecase) Run(
+	ctx context.Context, slug string, withRendered bool, withAuthor bool, locales []string,
+) (*Result, error) {
+	blogId, err := u.BlogRepository.GetIdBySlug(ctx, u.DB, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog id by slug: %w", err)
+	}
+	if blogId != nil {
+		blog, err := u.BlogDetailGetter.Run(ctx, *blogId, withRendered, withAuthor, locales)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blog detail: %w", err)
+		}
+		if blog == nil {
+			return nil, nil
+		}
+		return &Result{Blog: blog}, nil
+	}
+	redirectSlug, err := u.BlogRepository.GetRedirectSlug(ctx, u.DB, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redirect slug: %w", err)
+	}
+	if redirectSlug == nil {
+		return nil, nil
+	}
+	return &Result{RedirectSlug: redirectSlug}, nil
+}
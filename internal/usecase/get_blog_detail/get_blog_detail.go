@@ -6,10 +6,18 @@ import (
 
 	"github.com/shoet/blog/internal/infrastructure"
 	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/locale"
 )
 
 type BlogRepository interface {
 	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	GetRendering(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) (*models.BlogRendering, error)
+	ListTranslationLocales(
+		ctx context.Context, tx infrastructure.TX, blogIds []models.BlogId,
+	) (map[models.BlogId][]string, error)
+	GetTranslation(
+		ctx context.Context, tx infrastructure.TX, blogId models.BlogId, locale string,
+	) (*models.BlogTranslation, error)
 }
 
 type CommentRepository interface {
@@ -18,21 +26,54 @@ type CommentRepository interface {
 	) ([]*models.Comment, error)
 }
 
+type SeriesRepository interface {
+	GetByBlogId(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) (*models.Series, error)
+}
+
+type ReactionRepository interface {
+	CountByTargets(
+		ctx context.Context, tx infrastructure.TX, target models.ReactionTarget, targetIds []int64,
+	) (map[int64][]*models.ReactionCount, error)
+}
+
+type UserProfileRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, userId models.UserId) (*models.UserProfile, error)
+}
+
 type Usecase struct {
-	DB                infrastructure.DB
-	BlogRepository    BlogRepository
-	CommentRepository CommentRepository
+	DB                    infrastructure.DB
+	BlogRepository        BlogRepository
+	CommentRepository     CommentRepository
+	SeriesRepository      SeriesRepository
+	ReactionRepository    ReactionRepository
+	UserProfileRepository UserProfileRepository
 }
 
-func NewUsecase(db infrastructure.DB, blogRepository BlogRepository, commentRepository CommentRepository) *Usecase {
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	commentRepository CommentRepository,
+	seriesRepository SeriesRepository,
+	reactionRepository ReactionRepository,
+	userProfileRepository UserProfileRepository,
+) *Usecase {
 	return &Usecase{
-		DB:                db,
-		BlogRepository:    blogRepository,
-		CommentRepository: commentRepository,
+		DB:                    db,
+		BlogRepository:        blogRepository,
+		CommentRepository:     commentRepository,
+		SeriesRepository:      seriesRepository,
+		ReactionRepository:    reactionRepository,
+		UserProfileRepository: userProfileRepository,
 	}
 }
 
-func (u *Usecase) Run(ctx context.Context, blogId models.BlogId) (*models.Blog, error) {
+// Run は、ブログを取得する
+// withRenderedがtrueの場合は本文のレンダリング結果、withAuthorがtrueの場合は著者のプロフィールも設定する
+// localesは希望する言語の優先順で、閲覧できる言語のうち最も優先度の高い言語で返す
+// 一致する言語がない場合は既定の言語で返す
+func (u *Usecase) Run(
+	ctx context.Context, blogId models.BlogId, withRendered bool, withAuthor bool, locales []string,
+) (*models.Blog, error) {
 	blog, err := u.BlogRepository.Get(ctx, u.DB, blogId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get blog: %v", err)
@@ -40,6 +81,72 @@ func (u *Usecase) Run(ctx context.Context, blogId models.BlogId) (*models.Blog,
 	if blog == nil {
 		return nil, nil
 	}
+	translated, err := u.applyTranslation(ctx, blog, locales)
+	if err != nil {
+		return nil, err
+	}
+	if withRendered {
+		var rendered *models.BlogRendering
+		// 翻訳のレンダリング結果は保存しないため、その場でレンダリングする
+		if !translated {
+			rendered, err = u.BlogRepository.GetRendering(ctx, u.DB, blog.Id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get rendering: %v", err)
+			}
+		}
+		// レンダリング結果の保存前に作成されたブログは、その場でレンダリングする
+		if rendered == nil {
+			rendered, err = models.NewBlogRendering(blog.Id, blog.Content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render content: %v", err)
+			}
+		}
+		blog.Rendered = rendered
+	}
+	series, err := u.SeriesRepository.GetByBlogId(ctx, u.DB, blog.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series: %v", err)
+	}
+	if series != nil {
+		blog.Series = series.Navigation(blog.Id)
+	}
+	reactions, err := u.ReactionRepository.CountByTargets(
+		ctx, u.DB, models.ReactionTargetBlog, []int64{int64(blog.Id)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %v", err)
+	}
+	blog.Reactions = reactions[int64(blog.Id)]
+	if withAuthor {
+		author, err := u.UserProfileRepository.Get(ctx, u.DB, blog.AuthorId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user profile: %v", err)
+		}
+		blog.Author = author
+	}
 	return blog, nil
 
 }
+
+// applyTranslation は、閲覧できる言語を設定し、希望する言語の翻訳があればタイトル・概要・本文を置き換える
+// 翻訳で置き換えた場合はtrueを返す
+func (u *Usecase) applyTranslation(ctx context.Context, blog *models.Blog, locales []string) (bool, error) {
+	translationLocales, err := u.BlogRepository.ListTranslationLocales(ctx, u.DB, []models.BlogId{blog.Id})
+	if err != nil {
+		return false, fmt.Errorf("failed to list translation locales: %v", err)
+	}
+	blog.Locale = blog.DefaultLocale
+	blog.Locales = append([]string{blog.DefaultLocale}, translationLocales[blog.Id]...)
+	matched, ok := locale.Match(locales, blog.Locales)
+	if !ok || matched == blog.DefaultLocale {
+		return false, nil
+	}
+	translation, err := u.BlogRepository.GetTranslation(ctx, u.DB, blog.Id, matched)
+	if err != nil {
+		return false, fmt.Errorf("failed to get translation: %v", err)
+	}
+	if translation == nil {
+		return false, nil
+	}
+	blog.ApplyTranslation(translation)
+	return true, nil
+}
@@ -0,0 +1,65 @@
+package get_blog_revision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/session"
+)
+
+var ErrResourceNotFound = fmt.Errorf("blog revision not found")
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+}
+
+type BlogRevisionRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, revision int64) (*models.BlogRevision, error)
+}
+
+type Usecase struct {
+	DB                     infrastructure.DB
+	BlogRepository         BlogRepository
+	BlogRevisionRepository BlogRevisionRepository
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	blogRevisionRepository BlogRevisionRepository,
+) *Usecase {
+	return &Usecase{
+		DB:                     db,
+		BlogRepository:         blogRepository,
+		BlogRevisionRepository: blogRevisionRepository,
+	}
+}
+
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId, revision int64) (*models.BlogRevision, error) {
+	sessionUserId, err := session.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+	blog, err := u.BlogRepository.Get(ctx, u.DB, blogId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog: %w", err)
+	}
+	if blog == nil {
+		return nil, ErrResourceNotFound
+	}
+	if blog.AuthorId != sessionUserId {
+		return nil, fmt.Errorf("can't get other user's blog revision")
+	}
+	blogRevision, err := u.BlogRevisionRepository.Get(ctx, u.DB, blogId, revision)
+	if err != nil {
+		if errors.Is(err, repository.ErrResourceNotFound) {
+			return nil, ErrResourceNotFound
+		}
+		return nil, fmt.Errorf("failed to get blog revision: %w", err)
+	}
+	return blogRevision, nil
+}
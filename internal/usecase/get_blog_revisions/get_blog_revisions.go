@@ -0,0 +1,60 @@
+package get_blog_revisions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/session"
+)
+
+var ErrResourceNotFound = fmt.Errorf("blog not found")
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+}
+
+type BlogRevisionRepository interface {
+	List(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) ([]*models.BlogRevision, error)
+}
+
+type Usecase struct {
+	DB                     infrastructure.DB
+	BlogRepository         BlogRepository
+	BlogRevisionRepository BlogRevisionRepository
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	blogRevisionRepository BlogRevisionRepository,
+) *Usecase {
+	return &Usecase{
+		DB:                     db,
+		BlogRepository:         blogRepository,
+		BlogRevisionRepository: blogRevisionRepository,
+	}
+}
+
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId) ([]*models.BlogRevision, error) {
+	sessionUserId, err := session.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+	blog, err := u.BlogRepository.Get(ctx, u.DB, blogId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog: %w", err)
+	}
+	if blog == nil {
+		return nil, ErrResourceNotFound
+	}
+	if blog.AuthorId != sessionUserId {
+		return nil, fmt.Errorf("can't get other user's blog revisions")
+	}
+	revisions, err := u.BlogRevisionRepository.List(ctx, u.DB, blogId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blog revisions: %w", err)
+	}
+	return revisions, nil
+}
@@ -0,0 +1,66 @@
+package get_blog_views
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+var (
+	ErrResourceNotFound = fmt.Errorf("resource not found")
+	ErrInvalidPeriod    = fmt.Errorf("invalid period")
+)
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	ListDailyViews(
+		ctx context.Context, tx infrastructure.TX, blogId *models.BlogId, from string, to string,
+	) ([]*models.ViewCount, error)
+}
+
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+	Clocker        clocker.Clocker
+}
+
+func NewUsecase(db infrastructure.DB, blogRepository BlogRepository, clocker clocker.Clocker) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+		Clocker:        clocker,
+	}
+}
+
+// Run は、ブログの期間内の日別の閲覧数を取得する
+// from, toは YYYY-MM-DD 形式で、空の場合は直近の期間とする
+func (u *Usecase) Run(
+	ctx context.Context, blogId models.BlogId, from string, to string,
+) (*models.BlogViews, error) {
+	period, err := models.NewViewPeriod(from, to, u.Clocker.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPeriod, err)
+	}
+	blog, err := u.BlogRepository.Get(ctx, u.DB, blogId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog: %w", err)
+	}
+	if blog == nil {
+		return nil, ErrResourceNotFound
+	}
+	counts, err := u.BlogRepository.ListDailyViews(ctx, u.DB, &blogId, period.From, period.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daily views: %w", err)
+	}
+	daily := period.Fill(counts)
+	return &models.BlogViews{
+		BlogId: blogId,
+		From:   period.From,
+		To:     period.To,
+		Total:  models.TotalViews(daily),
+		Daily:  daily,
+	}, nil
+}
@@ -3,6 +3,7 @@ package get_blogs
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/shoet/blog/internal/infrastructure"
 	"github.com/shoet/blog/internal/infrastructure/models"
@@ -10,110 +11,193 @@ import (
 )
 
 type BlogRepository interface {
-	List(
+	Query(
 		ctx context.Context, tx infrastructure.TX, option *options.ListBlogOptions,
-	) ([]*models.Blog, error)
-
-	ListByTag(
-		ctx context.Context, tx infrastructure.TX, tag string, option *options.ListBlogOptions,
 	) (models.Blogs, error)
+}
 
-	ListByKeyword(
-		ctx context.Context, tx infrastructure.TX, keyword string, option *options.ListBlogOptions,
-	) (models.Blogs, error)
+type ReactionRepository interface {
+	CountByTargets(
+		ctx context.Context, tx infrastructure.TX, target models.ReactionTarget, targetIds []int64,
+	) (map[int64][]*models.ReactionCount, error)
+}
+
+type UserProfileRepository interface {
+	ListByUserIds(
+		ctx context.Context, tx infrastructure.TX, userIds []models.UserId,
+	) (map[models.UserId]*models.UserProfile, error)
+}
+
+type CursorCodec interface {
+	Encode(cursor *options.BlogCursor) (string, error)
+	Decode(s string) (*options.BlogCursor, error)
 }
 
 // get_blogs.Usecaseはブログ一覧を取得するユースケースです。
 // ページングはカーソル方式で実装しています。
 type Usecase struct {
-	DB             infrastructure.DB
-	BlogRepository BlogRepository
+	DB                    infrastructure.DB
+	BlogRepository        BlogRepository
+	ReactionRepository    ReactionRepository
+	UserProfileRepository UserProfileRepository
+	CursorCodec           CursorCodec
+	// SiteLocationは、作成日で絞り込む場合の日付のタイムゾーン
+	SiteLocation *time.Location
 }
 
 func NewUsecase(
 	DB infrastructure.DB,
 	blogRepository BlogRepository,
+	reactionRepository ReactionRepository,
+	userProfileRepository UserProfileRepository,
+	cursorCodec CursorCodec,
+	siteLocation *time.Location,
 ) *Usecase {
 	return &Usecase{
-		DB:             DB,
-		BlogRepository: blogRepository,
+		DB:                    DB,
+		BlogRepository:        blogRepository,
+		ReactionRepository:    reactionRepository,
+		UserProfileRepository: userProfileRepository,
+		CursorCodec:           cursorCodec,
+		SiteLocation:          siteLocation,
 	}
 }
 
 type GetBlogsInput struct {
-	Tag           *string
-	KeyWord       *string
-	IsPublicOnly  *bool
-	CursorId      *models.BlogId
-	PageDirection *string
-	Limit         *int64
+	Tags         []string
+	TagMatch     *string
+	KeyWord      *string
+	CreatedFrom  *string
+	CreatedTo    *string
+	AuthorId     *models.UserId
+	Sort         *string
+	Order        *string
+	IsPublicOnly *bool
+	// Cursorは前回のレスポンスで返したnextCursorまたはprevCursor
+	// 指定された場合は、並び順はカーソルの並び順とする
+	Cursor *string
+	Limit  *int64
+	// WithAuthorがtrueの場合は、著者のプロフィールを設定する
+	WithAuthor bool
+	// Localeが指定された場合は、その言語で閲覧できるブログに絞り込み、翻訳のタイトル・概要とする
+	Locale *string
 }
 
+// Runは、ブログ一覧と前後のページを取得するためのカーソルを返す
+// 前後のページが存在しない場合、カーソルはnilとする
 func (u *Usecase) Run(
 	ctx context.Context, input *GetBlogsInput,
-) (blogs []*models.Blog, prevEOF bool, nextEOF bool, err error) {
+) (blogs []*models.Blog, prevCursor *string, nextCursor *string, err error) {
 
 	transactor := infrastructure.NewTransactionProvider(u.DB)
 
-	option, err := options.NewListBlogOptions(input.IsPublicOnly, input.CursorId, input.Limit, input.PageDirection)
+	filter, err := options.NewBlogFilter(
+		input.IsPublicOnly, input.Tags, input.TagMatch, input.KeyWord,
+		input.CreatedFrom, input.CreatedTo, input.AuthorId, input.Locale, u.SiteLocation,
+	)
 	if err != nil {
-		return nil, false, false, fmt.Errorf("failed to create list option: %v", err)
+		return nil, nil, nil, fmt.Errorf("failed to create blog filter: %w", err)
+	}
+	// キーワード検索の場合は関連度の高い順を既定とする
+	// 関連度の値はカーソルに含めるため、ページを移動しても並び順は変わらない
+	defaultSortKey := options.BlogSortId
+	if filter.KeyWord != nil {
+		defaultSortKey = options.BlogSortRelevance
+	}
+	sort, err := options.NewBlogSort(input.Sort, input.Order, defaultSortKey)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create blog sort: %w", err)
+	}
+	var cursor *options.BlogCursor
+	if input.Cursor != nil {
+		cursor, err = u.CursorCodec.Decode(*input.Cursor)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to decode cursor: %w", err)
+		}
+	}
+	option, err := options.NewListBlogOptions(filter, sort, cursor, input.Limit)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create list option: %w", err)
 	}
 	// 次のページが存在するか判定するためにLimit+1で取得する
 	option.Limit++
 
 	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
-		var blogs models.Blogs
+		blogs, err := u.BlogRepository.Query(ctx, tx, option)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query blogs: %v", err)
+		}
 
-		if input.Tag != nil {
-			// タグ検索
-			b, err := u.BlogRepository.ListByTag(ctx, tx, *input.Tag, option)
-			if err != nil {
-				return nil, fmt.Errorf("failed to list blogs by tag: %v", err)
-			}
-			blogs = b
-		} else if input.KeyWord != nil {
-			// キーワード検索
-			b, err := u.BlogRepository.ListByKeyword(ctx, tx, *input.KeyWord, option)
-			if err != nil {
-				return nil, fmt.Errorf("failed to list blogs by keyword: %v", err)
-			}
-			blogs = b
-		} else {
-			// 通常の検索
-			b, err := u.BlogRepository.List(ctx, tx, option)
+		// リアクション数はブログごとに取得せず、まとめて取得する
+		ids := make([]int64, 0, len(blogs))
+		for _, blog := range blogs {
+			ids = append(ids, int64(blog.Id))
+		}
+		reactions, err := u.ReactionRepository.CountByTargets(ctx, tx, models.ReactionTargetBlog, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count reactions: %v", err)
+		}
+		for _, blog := range blogs {
+			blog.Reactions = reactions[int64(blog.Id)]
+		}
+
+		if input.WithAuthor {
+			// 著者のプロフィールもブログごとに取得せず、まとめて取得する
+			profiles, err := u.UserProfileRepository.ListByUserIds(ctx, tx, blogs.AuthorIds())
 			if err != nil {
-				return nil, fmt.Errorf("failed to list blogs: %v", err)
+				return nil, fmt.Errorf("failed to list user profiles: %v", err)
 			}
-			blogs = b
+			blogs.SetAuthors(profiles)
 		}
 
 		return blogs.ToSlice(), nil
 	})
 
 	if err != nil {
-		return nil, false, false, fmt.Errorf("failed to get blogs: %v", err)
+		return nil, nil, nil, fmt.Errorf("failed to get blogs: %v", err)
 	}
 
 	blogs, ok := result.([]*models.Blog)
 	if !ok {
-		return nil, false, false, fmt.Errorf("failed to cast []*models.Blog")
+		return nil, nil, nil, fmt.Errorf("failed to cast []*models.Blog")
 	}
 
-	var isEOF = false
-	if len(blogs) <= int(option.Limit-1) {
-		// Limit+1で取得しているため、Limit以下の場合はEOF
-		isEOF = true
-	} else {
-		// Limit+1で取得しているため、Limitを超える場合は最後の要素を削除
-		if option.PageDirection == "prev" {
+	// Limit+1で取得しているため、Limitを超える場合はカーソルから進む方向にさらにブログが存在する
+	hasMore := len(blogs) > int(option.Limit-1)
+	prev := cursor != nil && cursor.Direction == options.PageDirectionPrev
+	if hasMore {
+		if prev {
 			blogs = blogs[1:]
 		} else {
 			blogs = blogs[:len(blogs)-1]
 		}
 	}
-	return blogs,
-		option.PageDirection == "prev" && isEOF,
-		option.PageDirection == "next" && isEOF,
-		nil
+	if len(blogs) == 0 {
+		return blogs, nil, nil, nil
+	}
+
+	// カーソルで次のページへ進んだ場合は前のページ、前のページへ戻った場合は次のページが存在する
+	hasPrev := (prev && hasMore) || (!prev && cursor != nil)
+	hasNext := (!prev && hasMore) || prev
+	if hasPrev {
+		prevCursor, err = u.encodeCursor(options.NewBlogCursor(option.Sort, blogs[0], options.PageDirectionPrev))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if hasNext {
+		nextCursor, err = u.encodeCursor(options.NewBlogCursor(option.Sort, blogs[len(blogs)-1], options.PageDirectionNext))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return blogs, prevCursor, nextCursor, nil
+}
+
+func (u *Usecase) encodeCursor(cursor *options.BlogCursor) (*string, error) {
+	s, err := u.CursorCodec.Encode(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return &s, nil
 }
@@ -3,61 +3,69 @@ package get_blogs_offset_paging
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/shoet/blog/internal/infrastructure"
 	"github.com/shoet/blog/internal/infrastructure/models"
 	"github.com/shoet/blog/internal/options"
 )
 
-type BlogRepositoryOffset interface {
-	List(
+type BlogRepository interface {
+	Query(
 		ctx context.Context, tx infrastructure.TX, option *options.ListBlogOptions,
 	) (models.Blogs, error)
 
-	ListByTag(
-		ctx context.Context, tx infrastructure.TX, tag string, option *options.ListBlogOptions,
-	) (models.Blogs, error)
-
-	ListByKeyword(
-		ctx context.Context, tx infrastructure.TX, keyword string, option *options.ListBlogOptions,
-	) (models.Blogs, error)
-
-	CountBlogs(
-		ctx context.Context, tx infrastructure.TX, option *options.ListBlogOptions,
-	) (int64, error)
-
-	CountBlogsByTag(
-		ctx context.Context, tx infrastructure.TX, tag string, option *options.ListBlogOptions,
+	Count(
+		ctx context.Context, tx infrastructure.TX, filter options.BlogFilter,
 	) (int64, error)
+}
 
-	CountBlogsByKeyword(
-		ctx context.Context, tx infrastructure.TX, keyword string, option *options.ListBlogOptions,
-	) (int64, error)
+type UserProfileRepository interface {
+	ListByUserIds(
+		ctx context.Context, tx infrastructure.TX, userIds []models.UserId,
+	) (map[models.UserId]*models.UserProfile, error)
 }
 
 // get_blogs_offset_paging.Usecaseはブログ一覧を取得するユースケースです。
 // ページングはオフセット方式で実装しています。
 type Usecase struct {
-	DB                   infrastructure.DB
-	BlogRepositoryOffset BlogRepositoryOffset
+	DB                    infrastructure.DB
+	BlogRepository        BlogRepository
+	UserProfileRepository UserProfileRepository
+	// SiteLocationは、作成日で絞り込む場合の日付のタイムゾーン
+	SiteLocation *time.Location
 }
 
 func NewUsecase(
 	DB infrastructure.DB,
-	blogRepositoryOffset BlogRepositoryOffset,
+	blogRepository BlogRepository,
+	userProfileRepository UserProfileRepository,
+	siteLocation *time.Location,
 ) *Usecase {
 	return &Usecase{
-		DB:                   DB,
-		BlogRepositoryOffset: blogRepositoryOffset,
+		DB:                    DB,
+		BlogRepository:        blogRepository,
+		UserProfileRepository: userProfileRepository,
+		SiteLocation:          siteLocation,
 	}
 }
 
 type Input struct {
-	Tag          *string
+	Tags         []string
+	TagMatch     *string
 	KeyWord      *string
+	CreatedFrom  *string
+	CreatedTo    *string
+	AuthorId     *models.UserId
+	Sort         *string
+	Order        *string
 	IsPublicOnly *bool
 	Limit        *int64
 	Page         *int64
+	// WithAuthorがtrueの場合は、著者のプロフィールを設定する
+	WithAuthor bool
+	// Localeが指定された場合は、その言語で閲覧できるブログに絞り込み、翻訳のタイトル・概要とする
+	Locale *string
 }
 
 type TransactionResult struct {
@@ -68,50 +76,42 @@ type TransactionResult struct {
 func (u *Usecase) Run(ctx context.Context, input *Input) ([]*models.Blog, int64, error) {
 	transactor := infrastructure.NewTransactionProvider(u.DB)
 
-	option, err := options.NewListBlogOffsetOptions(input.IsPublicOnly, input.Limit, input.Page)
+	filter, err := options.NewBlogFilter(
+		input.IsPublicOnly, input.Tags, input.TagMatch, input.KeyWord,
+		input.CreatedFrom, input.CreatedTo, input.AuthorId, input.Locale, u.SiteLocation,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create blog filter: %w", err)
+	}
+	// キーワード検索の場合は関連度の高い順を既定とする
+	defaultSortKey := options.BlogSortId
+	if filter.KeyWord != nil {
+		defaultSortKey = options.BlogSortRelevance
+	}
+	sort, err := options.NewBlogSort(input.Sort, input.Order, defaultSortKey)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create blog sort: %w", err)
+	}
+	option, err := options.NewListBlogOffsetOptions(filter, sort, input.Limit, input.Page)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create list option: %v", err)
+		return nil, 0, fmt.Errorf("failed to create list option: %w", err)
 	}
 	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
-		var blogs models.Blogs
-		var blogsCount int64
-
-		if input.Tag != nil {
-			// タグ検索
-			b, err := u.BlogRepositoryOffset.ListByTag(ctx, tx, *input.Tag, option)
-			if err != nil {
-				return nil, fmt.Errorf("failed to list blogs by tag: %v", err)
-			}
-			count, err := u.BlogRepositoryOffset.CountBlogsByTag(ctx, tx, *input.Tag, option)
-			if err != nil {
-				return nil, fmt.Errorf("failed to count blogs by tag: %v", err)
-			}
-			blogs = b
-			blogsCount = count
-		} else if input.KeyWord != nil {
-			// キーワード検索
-			b, err := u.BlogRepositoryOffset.ListByKeyword(ctx, tx, *input.KeyWord, option)
-			if err != nil {
-				return nil, fmt.Errorf("failed to list blogs by keyword: %v", err)
-			}
-			count, err := u.BlogRepositoryOffset.CountBlogsByKeyword(ctx, tx, *input.KeyWord, option)
-			if err != nil {
-				return nil, fmt.Errorf("failed to count blogs by keyword: %v", err)
-			}
-			blogs = b
-			blogsCount = count
-		} else {
-			// 通常の検索
-			b, err := u.BlogRepositoryOffset.List(ctx, tx, option)
-			if err != nil {
-				return nil, fmt.Errorf("failed to list blogs: %v", err)
-			}
-			count, err := u.BlogRepositoryOffset.CountBlogs(ctx, tx, option)
+		blogs, err := u.BlogRepository.Query(ctx, tx, option)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query blogs: %v", err)
+		}
+		if input.WithAuthor {
+			profiles, err := u.UserProfileRepository.ListByUserIds(ctx, tx, blogs.AuthorIds())
 			if err != nil {
-				return nil, fmt.Errorf("failed to count blogs: %v", err)
+				return nil, fmt.Errorf("failed to list user profiles: %v", err)
 			}
-			blogs = b
-			blogsCount = count
+			blogs.SetAuthors(profiles)
+		}
+		// 件数にはページングを除いた同じ絞り込み条件を適用する
+		blogsCount, err := u.BlogRepository.Count(ctx, tx, option.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count blogs: %v", err)
 		}
 		txResult := TransactionResult{
 			blogs:      blogs.ToSlice(),
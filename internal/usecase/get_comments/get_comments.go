@@ -21,21 +21,30 @@ type UserProfileRepository interface {
 	Get(ctx context.Context, tx infrastructure.TX, userId models.UserId) (*models.UserProfile, error)
 }
 
+type ReactionRepository interface {
+	CountByTargets(
+		ctx context.Context, tx infrastructure.TX, target models.ReactionTarget, targetIds []int64,
+	) (map[int64][]*models.ReactionCount, error)
+}
+
 type Usecase struct {
 	DB                    infrastructure.DB
 	commentRepository     CommmentRepository
 	userProfileRepository UserProfileRepository
+	reactionRepository    ReactionRepository
 }
 
 func NewUsecase(
 	db infrastructure.DB,
 	commentRepository CommmentRepository,
 	userProfileRepository UserProfileRepository,
+	reactionRepository ReactionRepository,
 ) *Usecase {
 	return &Usecase{
 		DB:                    db,
 		commentRepository:     commentRepository,
 		userProfileRepository: userProfileRepository,
+		reactionRepository:    reactionRepository,
 	}
 }
 
@@ -54,5 +63,16 @@ func (u *Usecase) Run(ctx context.Context, blogId models.BlogId) ([]*models.Comm
 			comment.Nickname = &profile.Nickname
 		}
 	}
+	ids := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, int64(comment.CommentId))
+	}
+	reactions, err := u.reactionRepository.CountByTargets(ctx, u.DB, models.ReactionTargetComment, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	for _, comment := range comments {
+		comment.Reactions = reactions[int64(comment.CommentId)]
+	}
 	return comments, nil
 }
@@ -0,0 +1,163 @@
+package get_feed
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/feed"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/options"
+)
+
+var ErrResourceNotFound = fmt.Errorf("tag not found")
+
+// FeedLimit は、フィードに含める記事の件数
+const FeedLimit int64 = 20
+
+type BlogRepository interface {
+	Query(
+		ctx context.Context, tx infrastructure.TX, option *options.ListBlogOptions,
+	) (models.Blogs, error)
+	SelectTags(ctx context.Context, tx infrastructure.TX, tag string) ([]*models.Tag, error)
+	ListTranslationLocales(
+		ctx context.Context, tx infrastructure.TX, blogIds []models.BlogId,
+	) (map[models.BlogId][]string, error)
+	ListTranslationModified(
+		ctx context.Context, tx infrastructure.TX, blogIds []models.BlogId,
+	) (map[models.BlogId]uint, error)
+}
+
+type Usecase struct {
+	config         *config.Config
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+}
+
+func NewUsecase(
+	config *config.Config,
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+) *Usecase {
+	return &Usecase{
+		config:         config,
+		DB:             db,
+		BlogRepository: blogRepository,
+	}
+}
+
+// Run は、公開済みのブログからフィードを生成する
+// tagが指定された場合はタグに紐づくブログのみを対象とする
+func (u *Usecase) Run(ctx context.Context, tag *string) (*feed.Feed, error) {
+	isPublic := true
+	var tags []string
+	if tag != nil {
+		exists, err := u.BlogRepository.SelectTags(ctx, u.DB, *tag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select tags: %w", err)
+		}
+		if len(exists) == 0 {
+			return nil, ErrResourceNotFound
+		}
+		tags = []string{*tag}
+	}
+	filter, err := options.NewBlogFilter(&isPublic, tags, nil, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blog filter: %w", err)
+	}
+	sort, err := options.NewBlogSort(nil, nil, options.BlogSortId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blog sort: %w", err)
+	}
+	limit := FeedLimit
+	option, err := options.NewListBlogOptions(filter, sort, nil, &limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create list option: %w", err)
+	}
+	blogs, err := u.BlogRepository.Query(ctx, u.DB, option)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blogs: %w", err)
+	}
+	ids := make([]models.BlogId, 0, len(blogs))
+	for _, blog := range blogs {
+		ids = append(ids, blog.Id)
+	}
+	translationLocales, err := u.BlogRepository.ListTranslationLocales(ctx, u.DB, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation locales: %w", err)
+	}
+	translationModified, err := u.BlogRepository.ListTranslationModified(ctx, u.DB, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translation modified: %w", err)
+	}
+
+	siteURL := fmt.Sprintf("https://%s", u.config.SiteDomain)
+	f := &feed.Feed{
+		Title:       u.config.FeedTitle,
+		Description: u.config.FeedDescription,
+		Link:        siteURL,
+		Updated:     time.Unix(0, 0).UTC(),
+		Items:       make([]*feed.Item, 0, len(blogs)),
+	}
+	if tag != nil {
+		f.Title = fmt.Sprintf("%s - %s", u.config.FeedTitle, *tag)
+		f.Link = fmt.Sprintf("%s/tags/%s", siteURL, url.PathEscape(*tag))
+	}
+	for _, blog := range blogs {
+		// リンクはスラッグによるパーマリンクとし、スラッグを変更しても同じ記事と判定できるよう
+		// 記事のIDはブログのIDによるURLとする
+		link := fmt.Sprintf("%s/blogs/%s", siteURL, url.PathEscape(blog.Slug))
+		imageURL, err := u.thumbnailURL(blog.ThumbnailImageFileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get thumbnail url: %w", err)
+		}
+		// 翻訳の更新もフィードの購読者に伝わるよう、ブログと翻訳のうち最も新しい更新日時を使用する
+		modified := max(blog.Modified, translationModified[blog.Id])
+		f.Items = append(f.Items, &feed.Item{
+			Id:         fmt.Sprintf("%s/blogs/%d", siteURL, blog.Id),
+			Title:      blog.Title,
+			Link:       link,
+			Summary:    blog.Description,
+			ImageURL:   imageURL,
+			Tags:       blog.Tags,
+			Published:  time.Unix(int64(blog.Created), 0).UTC(),
+			Updated:    time.Unix(int64(modified), 0).UTC(),
+			Alternates: alternates(link, blog.DefaultLocale, translationLocales[blog.Id]),
+		})
+	}
+	return f, nil
+}
+
+// alternates は、翻訳があるブログの既定の言語と翻訳の言語ごとのリンクを返す
+// 翻訳がない場合はnilを返す
+func alternates(link string, defaultLocale string, translationLocales []string) []feed.Alternate {
+	if len(translationLocales) == 0 {
+		return nil
+	}
+	locales := append([]string{defaultLocale}, translationLocales...)
+	result := make([]feed.Alternate, 0, len(locales))
+	for _, locale := range locales {
+		result = append(result, feed.Alternate{
+			Locale: locale,
+			Link:   fmt.Sprintf("%s?lang=%s", link, url.QueryEscape(locale)),
+		})
+	}
+	return result
+}
+
+// thumbnailURL は、サムネイル画像のCDN上のURLを返す
+// 既にURLとして保存されている場合はそのまま返す
+func (u *Usecase) thumbnailURL(fileName string) (string, error) {
+	if fileName == "" {
+		return "", nil
+	}
+	if strings.HasPrefix(fileName, "https://") || strings.HasPrefix(fileName, "http://") {
+		return fileName, nil
+	}
+	file := &models.File{Type: models.FileTypeThumbnailImage, FileName: fileName}
+	return file.GetFileURL(u.config)
+}
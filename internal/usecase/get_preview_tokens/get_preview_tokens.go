@@ -0,0 +1,60 @@
+package get_preview_tokens
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/session"
+)
+
+var ErrResourceNotFound = fmt.Errorf("blog not found")
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+}
+
+type PreviewTokenService interface {
+	List(ctx context.Context, blogId models.BlogId) ([]*models.PreviewToken, error)
+}
+
+type Usecase struct {
+	DB                  infrastructure.DB
+	BlogRepository      BlogRepository
+	PreviewTokenService PreviewTokenService
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	previewTokenService PreviewTokenService,
+) *Usecase {
+	return &Usecase{
+		DB:                  db,
+		BlogRepository:      blogRepository,
+		PreviewTokenService: previewTokenService,
+	}
+}
+
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId) ([]*models.PreviewToken, error) {
+	sessionUserId, err := session.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+	blog, err := u.BlogRepository.Get(ctx, u.DB, blogId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog: %w", err)
+	}
+	if blog == nil {
+		return nil, ErrResourceNotFound
+	}
+	if blog.AuthorId != sessionUserId {
+		return nil, fmt.Errorf("can't get preview tokens for other user's blog")
+	}
+	tokens, err := u.PreviewTokenService.List(ctx, blogId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list preview tokens: %w", err)
+	}
+	return tokens, nil
+}
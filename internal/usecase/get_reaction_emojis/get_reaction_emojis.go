@@ -0,0 +1,23 @@
+package get_reaction_emojis
+
+import (
+	"context"
+
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type Usecase struct {
+	emojis []string
+}
+
+func NewUsecase(config *config.Config) *Usecase {
+	return &Usecase{
+		emojis: models.ParseReactionEmojis(config.ReactionEmojis),
+	}
+}
+
+// Run は、リアクションに使用できる絵文字の一覧を返す
+func (u *Usecase) Run(ctx context.Context) []string {
+	return u.emojis
+}
@@ -0,0 +1,110 @@
+package get_related_blogs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/related"
+)
+
+var ErrResourceNotFound = fmt.Errorf("resource not found")
+
+// MaxLimit は、取得できる関連ブログの上限
+// キャッシュには上限の件数まで保存し、指定された件数に切り詰めて返す
+const MaxLimit = 20
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	ListRelatedCandidates(ctx context.Context, tx infrastructure.TX) ([]*models.Blog, error)
+}
+
+type RelatedBlogsCache interface {
+	Load(ctx context.Context, blogId models.BlogId) ([]*models.Blog, error)
+	Save(ctx context.Context, blogId models.BlogId, blogs []*models.Blog) error
+}
+
+type Usecase struct {
+	DB                infrastructure.DB
+	BlogRepository    BlogRepository
+	RelatedBlogsCache RelatedBlogsCache
+	Clocker           clocker.Clocker
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	relatedBlogsCache RelatedBlogsCache,
+	clocker clocker.Clocker,
+) *Usecase {
+	return &Usecase{
+		DB:                db,
+		BlogRepository:    blogRepository,
+		RelatedBlogsCache: relatedBlogsCache,
+		Clocker:           clocker,
+	}
+}
+
+// Run は、公開済みのブログに関連する公開済みのブログを関連度の高い順に最大limit件取得する
+// 算出結果はブログごとにキャッシュし、キャッシュがあればそれを返す
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId, limit int) ([]*models.Blog, error) {
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	cached, err := u.RelatedBlogsCache.Load(ctx, blogId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load related blogs cache: %w", err)
+	}
+	if cached != nil {
+		return truncate(cached, limit), nil
+	}
+
+	blog, err := u.BlogRepository.Get(ctx, u.DB, blogId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog: %w", err)
+	}
+	if blog == nil || !blog.IsPublic {
+		return nil, ErrResourceNotFound
+	}
+	candidates, err := u.BlogRepository.ListRelatedCandidates(ctx, u.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list related candidates: %w", err)
+	}
+
+	documents := make([]*related.Document, 0, len(candidates))
+	blogs := make(map[models.BlogId]*models.Blog, len(candidates))
+	for _, c := range candidates {
+		documents = append(documents, toDocument(c))
+		blogs[c.Id] = c
+	}
+	ranked := related.Rank(toDocument(blog), documents, u.Clocker.Now(), MaxLimit)
+	result := make([]*models.Blog, 0, len(ranked))
+	for _, r := range ranked {
+		result = append(result, blogs[models.BlogId(r.Id)])
+	}
+
+	if err := u.RelatedBlogsCache.Save(ctx, blogId, result); err != nil {
+		return nil, fmt.Errorf("failed to save related blogs cache: %w", err)
+	}
+	return truncate(result, limit), nil
+}
+
+func toDocument(blog *models.Blog) *related.Document {
+	return &related.Document{
+		Id:          int64(blog.Id),
+		Title:       blog.Title,
+		Description: blog.Description,
+		Tags:        blog.Tags,
+		Created:     time.Unix(int64(blog.Created), 0),
+	}
+}
+
+func truncate(blogs []*models.Blog, limit int) []*models.Blog {
+	if len(blogs) > limit {
+		return blogs[:limit]
+	}
+	return blogs
+}
@@ -0,0 +1,33 @@
+package get_scheduled_blogs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type BlogRepository interface {
+	ListScheduledBlogs(ctx context.Context, tx infrastructure.TX) ([]*models.Blog, error)
+}
+
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+}
+
+func NewUsecase(db infrastructure.DB, blogRepository BlogRepository) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+	}
+}
+
+func (u *Usecase) Run(ctx context.Context) ([]*models.Blog, error) {
+	blogs, err := u.BlogRepository.ListScheduledBlogs(ctx, u.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled blogs: %w", err)
+	}
+	return blogs, nil
+}
@@ -0,0 +1,39 @@
+package get_series
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type SeriesRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.SeriesId) (*models.Series, error)
+}
+
+type Usecase struct {
+	DB               infrastructure.DB
+	SeriesRepository SeriesRepository
+}
+
+func NewUsecase(db infrastructure.DB, seriesRepository SeriesRepository) *Usecase {
+	return &Usecase{
+		DB:               db,
+		SeriesRepository: seriesRepository,
+	}
+}
+
+// Run は、シリーズと含まれる公開済みのブログを並び順に取得する
+// 存在しない場合はnilを返す
+func (u *Usecase) Run(ctx context.Context, id models.SeriesId) (*models.Series, error) {
+	series, err := u.SeriesRepository.Get(ctx, u.DB, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+	if series == nil {
+		return nil, nil
+	}
+	series.Entries = series.PublicEntries()
+	return series, nil
+}
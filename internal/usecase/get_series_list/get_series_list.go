@@ -0,0 +1,33 @@
+package get_series_list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type SeriesRepository interface {
+	List(ctx context.Context, tx infrastructure.TX) ([]*models.Series, error)
+}
+
+type Usecase struct {
+	DB               infrastructure.DB
+	SeriesRepository SeriesRepository
+}
+
+func NewUsecase(db infrastructure.DB, seriesRepository SeriesRepository) *Usecase {
+	return &Usecase{
+		DB:               db,
+		SeriesRepository: seriesRepository,
+	}
+}
+
+func (u *Usecase) Run(ctx context.Context) ([]*models.Series, error) {
+	series, err := u.SeriesRepository.List(ctx, u.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list series: %w", err)
+	}
+	return series, nil
+}
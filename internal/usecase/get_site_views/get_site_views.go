@@ -0,0 +1,63 @@
+package get_site_views
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+var ErrInvalidPeriod = fmt.Errorf("invalid period")
+
+// TopBlogsLimit は、閲覧数の多いブログとして返す件数
+const TopBlogsLimit = 10
+
+type BlogRepository interface {
+	ListDailyViews(
+		ctx context.Context, tx infrastructure.TX, blogId *models.BlogId, from string, to string,
+	) ([]*models.ViewCount, error)
+	ListTopViewedBlogs(
+		ctx context.Context, tx infrastructure.TX, from string, to string, limit uint,
+	) ([]*models.BlogViewCount, error)
+}
+
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+	Clocker        clocker.Clocker
+}
+
+func NewUsecase(db infrastructure.DB, blogRepository BlogRepository, clocker clocker.Clocker) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+		Clocker:        clocker,
+	}
+}
+
+// Run は、サイト全体の期間内の日別の閲覧数と、閲覧数の多いブログを取得する
+// from, toは YYYY-MM-DD 形式で、空の場合は直近の期間とする
+func (u *Usecase) Run(ctx context.Context, from string, to string) (*models.SiteViews, error) {
+	period, err := models.NewViewPeriod(from, to, u.Clocker.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPeriod, err)
+	}
+	counts, err := u.BlogRepository.ListDailyViews(ctx, u.DB, nil, period.From, period.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daily views: %w", err)
+	}
+	blogs, err := u.BlogRepository.ListTopViewedBlogs(ctx, u.DB, period.From, period.To, TopBlogsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list top viewed blogs: %w", err)
+	}
+	daily := period.Fill(counts)
+	return &models.SiteViews{
+		From:  period.From,
+		To:    period.To,
+		Total: models.TotalViews(daily),
+		Daily: daily,
+		Blogs: blogs,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package get_sitemap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/sitemap"
+)
+
+type BlogRepository interface {
+	ListPublicBlogsForSitemap(ctx context.Context, tx infrastructure.TX) ([]*models.Blog, error)
+	ListPublicTagsForSitemap(ctx context.Context, tx infrastructure.TX) ([]*models.TagLastModified, error)
+}
+
+type SitemapCache interface {
+	Load(ctx context.Context) (*sitemap.Documents, error)
+	Save(ctx context.Context, docs *sitemap.Documents) error
+}
+
+type Usecase struct {
+	config         *config.Config
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+	SitemapCache   SitemapCache
+}
+
+func NewUsecase(
+	config *config.Config,
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	sitemapCache SitemapCache,
+) *Usecase {
+	return &Usecase{
+		config:         config,
+		DB:             db,
+		BlogRepository: blogRepository,
+		SitemapCache:   sitemapCache,
+	}
+}
+
+// Run は、公開済みのブログとタグからsitemapとrobots.txtを生成する
+// 生成結果はキャッシュし、キャッシュがあればそれを返す
+func (u *Usecase) Run(ctx context.Context) (*sitemap.Documents, error) {
+	cached, err := u.SitemapCache.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sitemap cache: %w", err)
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	blogs, err := u.BlogRepository.ListPublicBlogsForSitemap(ctx, u.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blogs: %w", err)
+	}
+	tags, err := u.BlogRepository.ListPublicTagsForSitemap(ctx, u.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	siteURL := fmt.Sprintf("https://%s", u.config.SiteDomain)
+	urls := make([]sitemap.URL, 0, len(blogs)+len(tags)+1)
+	// トップページは最後に更新されたブログの日時とする
+	top := sitemap.URL{Loc: siteURL + "/"}
+	for _, blog := range blogs {
+		lastMod := time.Unix(int64(blog.Modified), 0)
+		if lastMod.After(top.LastMod) {
+			top.LastMod = lastMod
+		}
+		urls = append(urls, sitemap.URL{
+			Loc:     fmt.Sprintf("%s/blogs/%d", siteURL, blog.Id),
+			LastMod: lastMod,
+		})
+	}
+	for _, tag := range tags {
+		urls = append(urls, sitemap.URL{
+			Loc:     fmt.Sprintf("%s/tags/%s", siteURL, url.PathEscape(tag.Name)),
+			LastMod: time.Unix(int64(tag.Modified), 0),
+		})
+	}
+	urls = append([]sitemap.URL{top}, urls...)
+
+	docs, err := sitemap.Build(siteURL, urls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sitemap: %w", err)
+	}
+	if err := u.SitemapCache.Save(ctx, docs); err != nil {
+		return nil, fmt.Errorf("failed to save sitemap cache: %w", err)
+	}
+	return docs, nil
+}
@@ -0,0 +1,39 @@
+package get_trashed_blogs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/session"
+)
+
+type BlogRepository interface {
+	ListTrashedBlogs(ctx context.Context, tx infrastructure.TX, authorId models.UserId) ([]*models.Blog, error)
+}
+
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+}
+
+func NewUsecase(db infrastructure.DB, blogRepository BlogRepository) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+	}
+}
+
+// Run は、ログインユーザーのゴミ箱にあるブログを取得する
+func (u *Usecase) Run(ctx context.Context) ([]*models.Blog, error) {
+	userId, err := session.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+	blogs, err := u.BlogRepository.ListTrashedBlogs(ctx, u.DB, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trashed blogs: %w", err)
+	}
+	return blogs, nil
+}
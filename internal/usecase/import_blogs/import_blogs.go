@@ -0,0 +1,192 @@
+package import_blogs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/session"
+	"github.com/shoet/blog/internal/slug"
+)
+
+var ErrAuthorRequired = fmt.Errorf("author id is required to create blog")
+
+// Actionは、取り込んだブログに対して行った操作
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionUnchanged Action = "unchanged"
+)
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	GetIdBySlug(ctx context.Context, tx infrastructure.TX, slug string) (*models.BlogId, error)
+	SetCreated(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, created uint) error
+}
+
+// BlogCreatorは、ブログを作成する
+// タグ・スラッグ・検索インデックス・リビジョンの扱いをAPIからの作成と揃えるため、create_blogを使用する
+type BlogCreator interface {
+	Run(ctx context.Context, blog *models.Blog) (*models.Blog, error)
+}
+
+// BlogPutterは、ブログを更新する
+// タグ・スラッグ・検索インデックス・リビジョンの扱いをAPIからの更新と揃えるため、put_blogを使用する
+type BlogPutter interface {
+	Run(ctx context.Context, blog *models.Blog, version *int64) (*models.Blog, error)
+}
+
+// import_blogs.Usecaseは、ファイルから読み込んだブログを取り込むユースケースです。
+// IDかスラッグが一致するブログがあれば更新し、なければ作成します。
+// 内容が変わらないブログは更新しないため、同じファイルを繰り返し取り込んでも結果は変わりません。
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+	BlogCreator    BlogCreator
+	BlogPutter     BlogPutter
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	blogCreator BlogCreator,
+	blogPutter BlogPutter,
+) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+		BlogCreator:    blogCreator,
+		BlogPutter:     blogPutter,
+	}
+}
+
+// Resultは、1件のブログの取り込み結果
+type Result struct {
+	Action Action
+	Blog   *models.Blog
+}
+
+// Runは、ブログを順に取り込み、ブログごとの結果を返す
+// 作成するブログの著者はブログに指定された著者、指定されていない場合はauthorIdとし、更新するブログの著者は変更しない
+// dryRunがtrueの場合は、変更せずに行う操作のみを返す
+// ブログごとに作成・更新するため、途中でエラーになった場合はそれまでの取り込み結果とエラーを返す
+func (u *Usecase) Run(
+	ctx context.Context, blogs []*models.Blog, authorId models.UserId, dryRun bool,
+) ([]*Result, error) {
+	results := make([]*Result, 0, len(blogs))
+	for _, blog := range blogs {
+		result, err := u.importBlog(ctx, blog, authorId, dryRun)
+		if err != nil {
+			return results, fmt.Errorf("failed to import blog %q: %w", blog.Slug, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (u *Usecase) importBlog(
+	ctx context.Context, blog *models.Blog, authorId models.UserId, dryRun bool,
+) (*Result, error) {
+	current, err := u.findBlog(ctx, blog)
+	if err != nil {
+		return nil, err
+	}
+	// IDをスラッグとしているブログは、スラッグを指定せずに作成・更新する
+	if slug.IsIdBased(blog.Slug, int64(blog.Id)) {
+		blog.Slug = ""
+	}
+
+	if current == nil {
+		if blog.AuthorId == 0 {
+			blog.AuthorId = authorId
+		}
+		if blog.AuthorId == 0 {
+			return nil, ErrAuthorRequired
+		}
+		if dryRun {
+			return &Result{Action: ActionCreate, Blog: blog}, nil
+		}
+		created := blog.Created
+		newBlog, err := u.BlogCreator.Run(session.SetUserId(ctx, blog.AuthorId), blog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create blog: %w", err)
+		}
+		// 作成日時は取り込み元のブログから引き継ぐ
+		if created != 0 {
+			if err := u.BlogRepository.SetCreated(ctx, u.DB, newBlog.Id, created); err != nil {
+				return nil, fmt.Errorf("failed to set created: %w", err)
+			}
+			newBlog.Created = created
+		}
+		return &Result{Action: ActionCreate, Blog: newBlog}, nil
+	}
+
+	blog.Id = current.Id
+	blog.AuthorId = current.AuthorId
+	blog.PublishAt = current.PublishAt
+	if blog.Slug == current.Slug {
+		blog.Slug = ""
+	}
+	if !changed(current, blog) {
+		return &Result{Action: ActionUnchanged, Blog: current}, nil
+	}
+	if dryRun {
+		return &Result{Action: ActionUpdate, Blog: blog}, nil
+	}
+	newBlog, err := u.BlogPutter.Run(session.SetUserId(ctx, current.AuthorId), blog, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put blog: %w", err)
+	}
+	return &Result{Action: ActionUpdate, Blog: newBlog}, nil
+}
+
+// findBlogは、IDかスラッグが一致する取り込み先のブログを取得する
+// IDが一致するブログを優先し、どちらも一致しない場合はnilを返す
+func (u *Usecase) findBlog(ctx context.Context, blog *models.Blog) (*models.Blog, error) {
+	if blog.Id != 0 {
+		current, err := u.BlogRepository.Get(ctx, u.DB, blog.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blog: %w", err)
+		}
+		if current != nil {
+			return current, nil
+		}
+	}
+	if blog.Slug == "" {
+		return nil, nil
+	}
+	id, err := u.BlogRepository.GetIdBySlug(ctx, u.DB, blog.Slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog id by slug: %w", err)
+	}
+	if id == nil {
+		return nil, nil
+	}
+	current, err := u.BlogRepository.Get(ctx, u.DB, *id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog: %w", err)
+	}
+	return current, nil
+}
+
+// changedは、取り込むブログの内容が現在のブログから変わっているかを判定する
+// スラッグが空の場合は変更しないものとし、タグは順序を区別しない
+func changed(current *models.Blog, blog *models.Blog) bool {
+	if blog.Slug != "" && blog.Slug != current.Slug {
+		return true
+	}
+	currentTags := slices.Clone(current.Tags)
+	slices.Sort(currentTags)
+	tags := slices.Clone(blog.Tags)
+	slices.Sort(tags)
+	return current.Title != blog.Title ||
+		current.Description != blog.Description ||
+		current.Content != blog.Content ||
+		current.ThumbnailImageFileName != blog.ThumbnailImageFileName ||
+		current.IsPublic != blog.IsPublic ||
+		!slices.Equal(currentTags, tags)
+}
@@ -0,0 +1,158 @@
+package import_external_blogs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/external"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/usecase/import_blogs"
+)
+
+type FileRepository interface {
+	ExistsFile(ctx context.Context, file *models.File) (bool, error)
+	PutFile(ctx context.Context, file *models.File, body io.Reader, contentType string) error
+}
+
+// BlogImporterは、ブログを取り込む
+// 作成・更新の判定と繰り返し取り込んだ場合の扱いをファイルからの取り込みと揃えるため、import_blogsを使用する
+type BlogImporter interface {
+	Run(ctx context.Context, blogs []*models.Blog, authorId models.UserId, dryRun bool) ([]*import_blogs.Result, error)
+}
+
+// import_external_blogs.Usecaseは、WordPress・Hugo・Zennから読み込んだ記事を取り込むユースケースです。
+// 取り込み元の著者をこのブログのユーザーに対応付け、本文中のローカルの画像はアップロードしてURLを置き換えます。
+type Usecase struct {
+	Config         *config.Config
+	FileRepository FileRepository
+	BlogImporter   BlogImporter
+}
+
+func NewUsecase(
+	config *config.Config,
+	fileRepository FileRepository,
+	blogImporter BlogImporter,
+) *Usecase {
+	return &Usecase{
+		Config:         config,
+		FileRepository: fileRepository,
+		BlogImporter:   blogImporter,
+	}
+}
+
+type Input struct {
+	Source *external.Result
+	// Authorsは、取り込み元の著者名とこのブログのユーザーの対応
+	Authors map[string]models.UserId
+	// DefaultAuthorIdは、Authorsに含まれない著者や著者のない記事の著者
+	DefaultAuthorId models.UserId
+	DryRun          bool
+}
+
+// Reportは、取り込みの結果
+type Report struct {
+	Results []*import_blogs.Result
+	// Skipsは、読み込み時と取り込み時に取り込まなかった記事や本文の一部
+	Skips []*external.Skip
+	// UploadedImagesは、アップロードした画像の件数
+	UploadedImages int
+}
+
+// Runは、読み込んだ記事を著者を対応付けて取り込む
+// 著者を対応付けられない記事は取り込まずにスキップとして報告する
+// dryRunがtrueの場合は、画像をアップロードせずに置き換え後のURLのみを設定する
+// 途中でエラーになった場合は、それまでの結果とエラーを返す
+func (u *Usecase) Run(ctx context.Context, input *Input) (*Report, error) {
+	report := &Report{Skips: append([]*external.Skip{}, input.Source.Skips...)}
+	uploaded := map[string]string{}
+	blogs := make([]*models.Blog, 0, len(input.Source.Posts))
+	for _, post := range input.Source.Posts {
+		authorId, ok := input.Authors[post.Author]
+		if !ok {
+			authorId = input.DefaultAuthorId
+		}
+		if authorId == 0 {
+			report.Skips = append(report.Skips, &external.Skip{
+				Source: post.Source,
+				Reason: fmt.Sprintf("author %q is not mapped to user", post.Author),
+			})
+			continue
+		}
+
+		urls := map[string]string{}
+		for src, path := range post.Images {
+			url, ok := uploaded[path]
+			if !ok {
+				var err error
+				url, err = u.uploadImage(ctx, path, input.DryRun, report)
+				if err != nil {
+					return report, fmt.Errorf("failed to upload image %s: %w", path, err)
+				}
+				uploaded[path] = url
+			}
+			urls[src] = url
+		}
+		blog := *post.Blog
+		blog.AuthorId = authorId
+		blog.Content = external.RewriteImages(blog.Content, func(src string) (string, bool) {
+			url, ok := urls[src]
+			return url, ok
+		})
+		blogs = append(blogs, &blog)
+	}
+
+	results, err := u.BlogImporter.Run(ctx, blogs, input.DefaultAuthorId, input.DryRun)
+	report.Results = results
+	if err != nil {
+		return report, fmt.Errorf("failed to import blogs: %w", err)
+	}
+	return report, nil
+}
+
+// uploadImageは、本文中の画像をアップロードし、置き換え後のURLを返す
+// ファイル名は内容のハッシュとし、同じ画像がアップロード済みの場合はアップロードしない
+func (u *Usecase) uploadImage(
+	ctx context.Context, path string, dryRun bool, report *Report,
+) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	hash := sha256.Sum256(b)
+	ext := strings.ToLower(filepath.Ext(path))
+	file, err := models.NewFile(models.FileTypeBlogContentImage, hex.EncodeToString(hash[:16])+ext)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	url, err := file.GetFileURL(u.Config)
+	if err != nil {
+		return "", fmt.Errorf("failed to get file url: %w", err)
+	}
+	if dryRun {
+		return url, nil
+	}
+	exists, err := u.FileRepository.ExistsFile(ctx, file)
+	if err != nil {
+		return "", fmt.Errorf("failed to check file: %w", err)
+	}
+	if !exists {
+		contentType := mime.TypeByExtension(ext)
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		if err := u.FileRepository.PutFile(ctx, file, bytes.NewReader(b), contentType); err != nil {
+			return "", fmt.Errorf("failed to put file: %w", err)
+		}
+		report.UploadedImages++
+	}
+	return url, nil
+}
@@ -0,0 +1,129 @@
+package merge_tag
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/logging"
+)
+
+var (
+	ErrResourceNotFound = fmt.Errorf("resource not found")
+	ErrSameTag          = fmt.Errorf("can't merge tag into itself")
+)
+
+type BlogRepository interface {
+	GetTag(ctx context.Context, tx infrastructure.TX, tagId models.TagId) (*models.Tag, error)
+	MergeTag(ctx context.Context, tx infrastructure.TX, from models.TagId, into models.TagId) error
+	ListBlogIdsByTagId(ctx context.Context, tx infrastructure.TX, tagId models.TagId) ([]models.BlogId, error)
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	UpsertSearchIndex(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error
+}
+
+type SitemapCache interface {
+	Invalidate(ctx context.Context) error
+}
+
+type RelatedBlogsCache interface {
+	Invalidate(ctx context.Context, blogIds ...models.BlogId) error
+}
+
+// merge_tag.Usecaseは、タグを別のタグに統合するユースケースです。
+// 統合元のタグが付けられたブログは統合先のタグに付け替え、統合元のタグは削除します。
+type Usecase struct {
+	DB                infrastructure.DB
+	BlogRepository    BlogRepository
+	SitemapCache      SitemapCache
+	RelatedBlogsCache RelatedBlogsCache
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	sitemapCache SitemapCache,
+	relatedBlogsCache RelatedBlogsCache,
+) *Usecase {
+	return &Usecase{
+		DB:                db,
+		BlogRepository:    blogRepository,
+		SitemapCache:      sitemapCache,
+		RelatedBlogsCache: relatedBlogsCache,
+	}
+}
+
+// Runは、fromのタグをintoのタグに統合し、統合後のタグを返す
+func (u *Usecase) Run(ctx context.Context, from models.TagId, into models.TagId) (*models.Tag, error) {
+	if from == into {
+		return nil, ErrSameTag
+	}
+
+	// タグが付け替えられたブログ
+	var mergedBlogIds []models.BlogId
+
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		mergedBlogIds = nil
+
+		for _, id := range []models.TagId{from, into} {
+			tag, err := u.BlogRepository.GetTag(ctx, tx, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tag: %w", err)
+			}
+			if tag == nil {
+				return nil, ErrResourceNotFound
+			}
+		}
+
+		ids, err := u.BlogRepository.ListBlogIdsByTagId(ctx, tx, from)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blog ids by tag id: %w", err)
+		}
+
+		if err := u.BlogRepository.MergeTag(ctx, tx, from, into); err != nil {
+			return nil, fmt.Errorf("failed to merge tag: %w", err)
+		}
+
+		// 検索インデックスにはタグ名が含まれるため、付け替えたブログの分を作り直す
+		for _, id := range ids {
+			blog, err := u.BlogRepository.Get(ctx, tx, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get blog: %w", err)
+			}
+			if blog == nil {
+				continue
+			}
+			if err := u.BlogRepository.UpsertSearchIndex(ctx, tx, blog); err != nil {
+				return nil, fmt.Errorf("failed to upsert search index: %w", err)
+			}
+		}
+		mergedBlogIds = ids
+
+		merged, err := u.BlogRepository.GetTag(ctx, tx, into)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tag: %w", err)
+		}
+		return merged, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge tag: %w", err)
+	}
+
+	// sitemapと関連ブログにはタグが影響するため、キャッシュを破棄する
+	// タグの統合は完了しているため、破棄に失敗してもエラーは記録のみとする
+	if err := u.SitemapCache.Invalidate(ctx); err != nil {
+		logging.GetLogger(ctx).Error(fmt.Sprintf("failed to invalidate sitemap cache: %v", err))
+	}
+	if len(mergedBlogIds) > 0 {
+		if err := u.RelatedBlogsCache.Invalidate(ctx, mergedBlogIds...); err != nil {
+			logging.GetLogger(ctx).Error(fmt.Sprintf("failed to invalidate related blogs cache: %v", err))
+		}
+	}
+
+	merged, ok := result.(*models.Tag)
+	if !ok {
+		return nil, fmt.Errorf("failed to type assertion")
+	}
+	return merged, nil
+}
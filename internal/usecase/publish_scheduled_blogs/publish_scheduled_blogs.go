@@ -0,0 +1,140 @@
+package publish_scheduled_blogs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type BlogRepository interface {
+	PublishScheduledBlogs(ctx context.Context, tx infrastructure.TX, now time.Time) ([]models.BlogId, error)
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	ListBlogIdsByTags(ctx context.Context, tx infrastructure.TX, tags []string) ([]models.BlogId, error)
+}
+
+type Locker interface {
+	TryLock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error)
+	Unlock(ctx context.Context, key string, token string) error
+}
+
+type SitemapCache interface {
+	Invalidate(ctx context.Context) error
+}
+
+type RelatedBlogsCache interface {
+	Invalidate(ctx context.Context, blogIds ...models.BlogId) error
+}
+
+type result struct {
+	publishedBlogIds []models.BlogId
+	relatedBlogIds   []models.BlogId
+}
+
+type Usecase struct {
+	DB                infrastructure.DB
+	BlogRepository    BlogRepository
+	Locker            Locker
+	SitemapCache      SitemapCache
+	RelatedBlogsCache RelatedBlogsCache
+	Clocker           clocker.Clocker
+	LockTTL           time.Duration
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	locker Locker,
+	sitemapCache SitemapCache,
+	relatedBlogsCache RelatedBlogsCache,
+	clocker clocker.Clocker,
+	lockTTL time.Duration,
+) *Usecase {
+	return &Usecase{
+		DB:                db,
+		BlogRepository:    blogRepository,
+		Locker:            locker,
+		SitemapCache:      sitemapCache,
+		RelatedBlogsCache: relatedBlogsCache,
+		Clocker:           clocker,
+		LockTTL:           lockTTL,
+	}
+}
+
+// Run は、公開予定日時を過ぎたブログを公開する
+// 複数インスタンスで同時に実行されないよう、ロックを取得できた場合のみ処理する
+func (u *Usecase) Run(ctx context.Context) ([]models.BlogId, error) {
+	token := uuid.NewString()
+	ok, err := u.Locker.TryLock(ctx, config.KVS_SCHEDULED_PUBLISH_LOCK, token, u.LockTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock: %w", err)
+	}
+	if !ok {
+		// 他のインスタンスが処理中
+		return []models.BlogId{}, nil
+	}
+	defer u.Locker.Unlock(context.WithoutCancel(ctx), config.KVS_SCHEDULED_PUBLISH_LOCK, token)
+
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	r, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		ids, err := u.BlogRepository.PublishScheduledBlogs(ctx, tx, u.Clocker.Now())
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish scheduled blogs: %w", err)
+		}
+		relatedBlogIds, err := u.listRelatedBlogIds(ctx, tx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list related blog ids: %w", err)
+		}
+		return &result{publishedBlogIds: ids, relatedBlogIds: relatedBlogIds}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish scheduled blogs: %w", err)
+	}
+
+	res, ok := r.(*result)
+	if !ok {
+		return nil, fmt.Errorf("failed to type assertion: %w", err)
+	}
+	ids := res.publishedBlogIds
+	// 公開したブログをsitemapと関連ブログに反映するため、手動で公開した場合と同様にキャッシュを破棄する
+	if len(ids) > 0 {
+		if err := u.SitemapCache.Invalidate(ctx); err != nil {
+			return ids, fmt.Errorf("failed to invalidate sitemap cache: %w", err)
+		}
+		if err := u.RelatedBlogsCache.Invalidate(ctx, res.relatedBlogIds...); err != nil {
+			return ids, fmt.Errorf("failed to invalidate related blogs cache: %w", err)
+		}
+	}
+	return ids, nil
+}
+
+// listRelatedBlogIds は、公開したブログと、それらとタグを共有するブログのIDを返す
+// 公開したブログが関連ブログの候補に加わるため、これらの関連ブログを再計算させる
+func (u *Usecase) listRelatedBlogIds(
+	ctx context.Context, tx infrastructure.TX, publishedBlogIds []models.BlogId,
+) ([]models.BlogId, error) {
+	if len(publishedBlogIds) == 0 {
+		return []models.BlogId{}, nil
+	}
+	tags := []string{}
+	for _, id := range publishedBlogIds {
+		blog, err := u.BlogRepository.Get(ctx, tx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blog: %w", err)
+		}
+		if blog != nil {
+			tags = append(tags, blog.Tags...)
+		}
+	}
+	ids, err := u.BlogRepository.ListBlogIdsByTags(ctx, tx, tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blog ids by tags: %w", err)
+	}
+	return append(slices.Clone(publishedBlogIds), ids...), nil
+}
@@ -0,0 +1,122 @@
+package purge_blog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/session"
+	"golang.org/x/exp/slices"
+)
+
+var ErrResourceNotFound = fmt.Errorf("trashed blog not found")
+
+type BlogRepository interface {
+	GetTrashed(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) (*models.Blog, error)
+	Delete(ctx context.Context, tx infrastructure.TX, id models.BlogId) error
+	SelectBlogsTagsByOtherUsingBlog(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) ([]*models.BlogsTags, error)
+	SelectBlogsTags(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) ([]*models.BlogsTags, error)
+	DeleteTag(ctx context.Context, tx infrastructure.TX, tagId models.TagId) error
+	DeleteBlogsTags(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, tagId models.TagId) error
+}
+
+type SeriesRepository interface {
+	RemoveBlog(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) error
+}
+
+// purge_blog.Usecaseは、ゴミ箱にあるブログを完全に削除するユースケースです。
+// ブログのコメントも削除され、元に戻すことはできません。
+type Usecase struct {
+	DB               infrastructure.DB
+	BlogRepository   BlogRepository
+	SeriesRepository SeriesRepository
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	seriesRepository SeriesRepository,
+) *Usecase {
+	return &Usecase{
+		DB:               db,
+		BlogRepository:   blogRepository,
+		SeriesRepository: seriesRepository,
+	}
+}
+
+// Run は、ログインユーザーのゴミ箱にあるブログを完全に削除する
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId) (models.BlogId, error) {
+	sessionUserId, err := session.GetUserId(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	_, err = transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		blog, err := u.BlogRepository.GetTrashed(ctx, tx, blogId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trashed blog: %w", err)
+		}
+		if blog == nil {
+			return nil, ErrResourceNotFound
+		}
+		if blog.AuthorId != sessionUserId {
+			return nil, fmt.Errorf("can't purge other user's blog")
+		}
+		if err := u.Purge(ctx, tx, blog.Id); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge blog: %w", err)
+	}
+	return blogId, nil
+}
+
+// Purge は、ブログと他のブログで使用されていないタグを削除する
+// シリーズに所属している場合はシリーズから外し、後続のブログの並び順を詰める
+// 呼び出し元のトランザクションで実行し、ゴミ箱にあるかどうかは確認しない
+func (u *Usecase) Purge(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) error {
+	// delete blogs_tags -----------------
+	// select using other blog tags
+	var usingTags models.BlogsTagsArray
+	usingTags, err := u.BlogRepository.SelectBlogsTagsByOtherUsingBlog(ctx, tx, blogId)
+	if err != nil {
+		return fmt.Errorf("failed to select using tags: %w", err)
+	}
+
+	//  select will delete tags
+	blogsTags, err := u.BlogRepository.SelectBlogsTags(ctx, tx, blogId)
+	if err != nil {
+		return fmt.Errorf("failed to select blogs_tags: %w", err)
+	}
+	var willDeleteTags []models.TagId
+	for _, t := range blogsTags {
+		if !slices.Contains(usingTags.TagIds(), t.TagId) {
+			willDeleteTags = append(willDeleteTags, t.TagId)
+		}
+	}
+
+	for _, tagId := range willDeleteTags {
+		// delete tags
+		if err := u.BlogRepository.DeleteTag(ctx, tx, tagId); err != nil {
+			return fmt.Errorf("failed to delete tags: %w", err)
+		}
+		// delete blogs_tags
+		if err := u.BlogRepository.DeleteBlogsTags(ctx, tx, blogId, tagId); err != nil {
+			return fmt.Errorf("failed to delete blogs_tags: %w", err)
+		}
+	}
+
+	if err := u.SeriesRepository.RemoveBlog(ctx, tx, blogId); err != nil {
+		return fmt.Errorf("failed to remove blog from series: %w", err)
+	}
+
+	// delete blogs ----------------------
+	if err := u.BlogRepository.Delete(ctx, tx, blogId); err != nil {
+		return fmt.Errorf("failed to delete blog: %w", err)
+	}
+	return nil
+}
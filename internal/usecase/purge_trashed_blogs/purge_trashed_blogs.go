@@ -0,0 +1,98 @@
+package purge_trashed_blogs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type BlogRepository interface {
+	ListTrashedIdsBefore(ctx context.Context, tx infrastructure.TX, before uint) ([]models.BlogId, error)
+}
+
+// BlogPurgerは、ブログを完全に削除する
+// 使用されなくなったタグの扱いを手動での削除と揃えるため、purge_blogを使用する
+type BlogPurger interface {
+	Purge(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) error
+}
+
+type Locker interface {
+	TryLock(ctx context.Context, key string, token string, ttl time.Duration) (bool, error)
+	Unlock(ctx context.Context, key string, token string) error
+}
+
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+	BlogPurger     BlogPurger
+	Locker         Locker
+	Clocker        clocker.Clocker
+	// Retentionは、ゴミ箱に移動してから完全に削除するまでの期間
+	Retention time.Duration
+	LockTTL   time.Duration
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	blogPurger BlogPurger,
+	locker Locker,
+	clocker clocker.Clocker,
+	retention time.Duration,
+	lockTTL time.Duration,
+) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+		BlogPurger:     blogPurger,
+		Locker:         locker,
+		Clocker:        clocker,
+		Retention:      retention,
+		LockTTL:        lockTTL,
+	}
+}
+
+// Run は、ゴミ箱に移動してから保持期間を過ぎたブログを完全に削除し、削除したブログのIDを返す
+// 複数インスタンスで同時に実行されないよう、ロックを取得できた場合のみ処理する
+func (u *Usecase) Run(ctx context.Context) ([]models.BlogId, error) {
+	token := uuid.NewString()
+	ok, err := u.Locker.TryLock(ctx, config.KVS_TRASH_PURGE_LOCK, token, u.LockTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock: %w", err)
+	}
+	if !ok {
+		// 他のインスタンスが処理中
+		return []models.BlogId{}, nil
+	}
+	defer u.Locker.Unlock(context.WithoutCancel(ctx), config.KVS_TRASH_PURGE_LOCK, token)
+
+	before := uint(u.Clocker.Now().Add(-u.Retention).Unix())
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		ids, err := u.BlogRepository.ListTrashedIdsBefore(ctx, tx, before)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list trashed blogs: %w", err)
+		}
+		for _, id := range ids {
+			if err := u.BlogPurger.Purge(ctx, tx, id); err != nil {
+				return nil, fmt.Errorf("failed to purge blog %d: %w", id, err)
+			}
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge trashed blogs: %w", err)
+	}
+
+	ids, ok := result.([]models.BlogId)
+	if !ok {
+		return nil, fmt.Errorf("failed to type assertion")
+	}
+	return ids, nil
+}
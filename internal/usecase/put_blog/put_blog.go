@@ -2,14 +2,29 @@ package put_blog
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/shoet/blog/internal/infrastructure"
 	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/locale"
+	"github.com/shoet/blog/internal/logging"
 	"github.com/shoet/blog/internal/session"
+	"github.com/shoet/blog/internal/slug"
 	"golang.org/x/exp/slices"
 )
 
+var (
+	ErrResourceNotFound = fmt.Errorf("resource not found")
+	ErrInvalidSlug      = fmt.Errorf("invalid slug")
+	ErrSlugConflict     = fmt.Errorf("slug is already used")
+	ErrInvalidLocale    = fmt.Errorf("invalid locale")
+	ErrLocaleConflict   = fmt.Errorf("default locale already has a translation")
+	// ErrPreconditionFailedは、取得後に他のリクエストでブログが更新されている場合のエラー
+	ErrPreconditionFailed = fmt.Errorf("blog has been modified")
+)
+
 type BlogRepository interface {
 	SelectBlogsTags(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) ([]*models.BlogsTags, error)
 	SelectBlogsTagsByOtherUsingBlog(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) ([]*models.BlogsTags, error)
@@ -18,26 +33,59 @@ type BlogRepository interface {
 	AddBlogTag(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, tagId models.TagId) (int64, error)
 	DeleteTag(ctx context.Context, tx infrastructure.TX, tagId models.TagId) error
 	DeleteBlogsTags(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, tagId models.TagId) error
-	Put(ctx context.Context, tx infrastructure.TX, blog *models.Blog) (models.BlogId, error)
+	Put(ctx context.Context, tx infrastructure.TX, blog *models.Blog, version *int64) (models.BlogId, error)
 	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	UpsertSearchIndex(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error
+	UpsertRendering(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error
+	GetSlugOwner(ctx context.Context, tx infrastructure.TX, slug string) (*models.BlogId, error)
+	AddSlugRedirect(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, slug string) error
+	DeleteSlugRedirect(ctx context.Context, tx infrastructure.TX, slug string) error
+	ListBlogIdsByTags(ctx context.Context, tx infrastructure.TX, tags []string) ([]models.BlogId, error)
+	GetTranslation(
+		ctx context.Context, tx infrastructure.TX, blogId models.BlogId, locale string,
+	) (*models.BlogTranslation, error)
+}
+
+type BlogRevisionRepository interface {
+	Add(ctx context.Context, tx infrastructure.TX, blog *models.Blog) (models.BlogRevisionId, error)
+}
+
+type SitemapCache interface {
+	Invalidate(ctx context.Context) error
+}
+
+type RelatedBlogsCache interface {
+	Invalidate(ctx context.Context, blogIds ...models.BlogId) error
 }
 
 type Usecase struct {
-	DB             infrastructure.DB
-	BlogRepository BlogRepository
+	DB                     infrastructure.DB
+	BlogRepository         BlogRepository
+	BlogRevisionRepository BlogRevisionRepository
+	SitemapCache           SitemapCache
+	RelatedBlogsCache      RelatedBlogsCache
 }
 
 func NewUsecase(
 	db infrastructure.DB,
 	blogRepository BlogRepository,
+	blogRevisionRepository BlogRevisionRepository,
+	sitemapCache SitemapCache,
+	relatedBlogsCache RelatedBlogsCache,
 ) *Usecase {
 	return &Usecase{
-		DB:             db,
-		BlogRepository: blogRepository,
+		DB:                     db,
+		BlogRepository:         blogRepository,
+		BlogRevisionRepository: blogRevisionRepository,
+		SitemapCache:           sitemapCache,
+		RelatedBlogsCache:      relatedBlogsCache,
 	}
 }
 
-func (u *Usecase) Run(ctx context.Context, blog *models.Blog) (*models.Blog, error) {
+// Run は、ブログを更新する
+// versionを指定した場合は、ブログのバージョンが一致する場合のみ更新し
+// 一致しない場合はErrPreconditionFailedを返す
+func (u *Usecase) Run(ctx context.Context, blog *models.Blog, version *int64) (*models.Blog, error) {
 	sessionUserId, err := session.GetUserId(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to session.GetUserId: %w", err)
@@ -45,9 +93,30 @@ func (u *Usecase) Run(ctx context.Context, blog *models.Blog) (*models.Blog, err
 	if sessionUserId != blog.AuthorId {
 		return nil, fmt.Errorf("can't update other user's blog")
 	}
+	// 公開済みのブログは予約公開の対象外
+	if blog.IsPublic {
+		blog.PublishAt = nil
+	}
+	// 自身のIDによるスラッグはそのまま指定できる
+	if blog.Slug != "" && !slug.IsValid(blog.Slug) && !slug.IsIdBased(blog.Slug, int64(blog.Id)) {
+		return nil, ErrInvalidSlug
+	}
+	if blog.DefaultLocale != "" {
+		defaultLocale, err := locale.Normalize(blog.DefaultLocale)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidLocale, err)
+		}
+		blog.DefaultLocale = defaultLocale
+	}
+
+	// タグの変更により関連ブログが変わるブログ
+	var relatedBlogIds []models.BlogId
 
 	transactor := infrastructure.NewTransactionProvider(u.DB)
 	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		relatedBlogIds = nil
+		var changedTags []string
+
 		// このブログに紐づいているタグで、他のブログで使用されているタグを取得する
 		var usingTagsByOtherBlog models.BlogsTagsArray
 		usingTagsByOtherBlog, err = u.BlogRepository.SelectBlogsTagsByOtherUsingBlog(ctx, tx, blog.Id)
@@ -65,6 +134,7 @@ func (u *Usecase) Run(ctx context.Context, blog *models.Blog) (*models.Blog, err
 		// 新規のタグ追加
 		for _, tag := range blog.Tags {
 			if !currentTags.Contains(tag) {
+				changedTags = append(changedTags, tag)
 				tags, err := u.BlogRepository.SelectTags(ctx, tx, tag)
 				if err != nil {
 					return nil, fmt.Errorf("failed to select tag: %w", err)
@@ -91,6 +161,7 @@ func (u *Usecase) Run(ctx context.Context, blog *models.Blog) (*models.Blog, err
 			if slices.Contains(blog.Tags, tag.Name) {
 				continue
 			}
+			changedTags = append(changedTags, tag.Name)
 			if !usingTagsByOtherBlog.Contains(tag.Name) {
 				// 他のブログで使用されていないタグは削除
 				if err := u.BlogRepository.DeleteTag(ctx, tx, tag.TagId); err != nil {
@@ -103,9 +174,31 @@ func (u *Usecase) Run(ctx context.Context, blog *models.Blog) (*models.Blog, err
 			}
 		}
 
+		// スラッグの変更
+		if err := u.changeSlug(ctx, tx, blog); err != nil {
+			return nil, err
+		}
+
+		// 既定の言語の翻訳は持たないため、翻訳がある言語には変更できない
+		if blog.DefaultLocale != "" {
+			translation, err := u.BlogRepository.GetTranslation(ctx, tx, blog.Id, blog.DefaultLocale)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get translation: %w", err)
+			}
+			if translation != nil {
+				return nil, ErrLocaleConflict
+			}
+		}
+
 		// ブログの更新
-		id, err := u.BlogRepository.Put(ctx, tx, blog)
+		id, err := u.BlogRepository.Put(ctx, tx, blog, version)
 		if err != nil {
+			if errors.Is(err, repository.ErrVersionMismatch) {
+				return nil, ErrPreconditionFailed
+			}
+			if errors.Is(err, repository.ErrResourceNotFound) {
+				return nil, ErrResourceNotFound
+			}
 			return nil, fmt.Errorf("failed to put blog: %w", err)
 		}
 
@@ -115,6 +208,30 @@ func (u *Usecase) Run(ctx context.Context, blog *models.Blog) (*models.Blog, err
 			return nil, fmt.Errorf("failed to get blog: %w", err)
 		}
 
+		// 検索インデックスの更新
+		if err := u.BlogRepository.UpsertSearchIndex(ctx, tx, newBlog); err != nil {
+			return nil, fmt.Errorf("failed to upsert search index: %w", err)
+		}
+
+		// 本文のレンダリング結果の更新
+		if err := u.BlogRepository.UpsertRendering(ctx, tx, newBlog); err != nil {
+			return nil, fmt.Errorf("failed to upsert rendering: %w", err)
+		}
+
+		// タグが変更された場合、このブログと、変更されたタグを持つブログの関連ブログを再計算する
+		if len(changedTags) > 0 {
+			ids, err := u.BlogRepository.ListBlogIdsByTags(ctx, tx, changedTags)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list blog ids by tags: %w", err)
+			}
+			relatedBlogIds = append([]models.BlogId{blog.Id}, ids...)
+		}
+
+		// 更新後の内容をリビジョンとして保存
+		if _, err := u.BlogRevisionRepository.Add(ctx, tx, newBlog); err != nil {
+			return nil, fmt.Errorf("failed to add blog revision: %w", err)
+		}
+
 		return newBlog, nil
 	})
 
@@ -122,9 +239,53 @@ func (u *Usecase) Run(ctx context.Context, blog *models.Blog) (*models.Blog, err
 		return nil, fmt.Errorf("failed to update blog: %w", err)
 	}
 
+	// 公開中のブログが変わるため、sitemapのキャッシュを破棄する
+	// ブログの更新は完了しているため、破棄に失敗してもエラーは記録のみとする
+	if err := u.SitemapCache.Invalidate(ctx); err != nil {
+		logging.GetLogger(ctx).Error(fmt.Sprintf("failed to invalidate sitemap cache: %v", err))
+	}
+	if len(relatedBlogIds) > 0 {
+		if err := u.RelatedBlogsCache.Invalidate(ctx, relatedBlogIds...); err != nil {
+			logging.GetLogger(ctx).Error(fmt.Sprintf("failed to invalidate related blogs cache: %v", err))
+		}
+	}
+
 	blog, ok := result.(*models.Blog)
 	if !ok {
 		return nil, fmt.Errorf("failed to type assertion: %w", err)
 	}
 	return blog, nil
 }
+
+// changeSlug は、スラッグが変更された場合に旧スラッグをリダイレクト元として登録する
+// スラッグが未指定の場合は現在のスラッグを維持する
+func (u *Usecase) changeSlug(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error {
+	current, err := u.BlogRepository.Get(ctx, tx, blog.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get blog: %w", err)
+	}
+	if current == nil {
+		return ErrResourceNotFound
+	}
+	if blog.Slug == "" || blog.Slug == current.Slug {
+		blog.Slug = current.Slug
+		return nil
+	}
+
+	// 自身の旧スラッグに戻す場合は許可する
+	owner, err := u.BlogRepository.GetSlugOwner(ctx, tx, blog.Slug)
+	if err != nil {
+		return fmt.Errorf("failed to get slug owner: %w", err)
+	}
+	if owner != nil && *owner != blog.Id {
+		return ErrSlugConflict
+	}
+
+	if err := u.BlogRepository.DeleteSlugRedirect(ctx, tx, blog.Slug); err != nil {
+		return fmt.Errorf("failed to delete slug redirect: %w", err)
+	}
+	if err := u.BlogRepository.AddSlugRedirect(ctx, tx, blog.Id, current.Slug); err != nil {
+		return fmt.Errorf("failed to add slug redirect: %w", err)
+	}
+	return nil
+}
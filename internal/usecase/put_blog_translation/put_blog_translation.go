@@ -0,0 +1,84 @@
+package put_blog_translation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/locale"
+	"github.com/shoet/blog/internal/session"
+)
+
+var (
+	ErrResourceNotFound = fmt.Errorf("resource not found")
+	ErrInvalidLocale    = fmt.Errorf("invalid locale")
+	ErrDefaultLocale    = fmt.Errorf("locale is the default locale of the blog")
+)
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	UpsertTranslation(ctx context.Context, tx infrastructure.TX, translation *models.BlogTranslation) error
+	GetTranslation(
+		ctx context.Context, tx infrastructure.TX, blogId models.BlogId, locale string,
+	) (*models.BlogTranslation, error)
+}
+
+// put_blog_translation.Usecaseは、ブログの言語ごとの翻訳を作成・更新するユースケースです。
+// 既定の言語のタイトル・概要・本文はブログ自体を更新するため、翻訳は作成できません。
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+}
+
+func NewUsecase(db infrastructure.DB, blogRepository BlogRepository) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+	}
+}
+
+func (u *Usecase) Run(ctx context.Context, translation *models.BlogTranslation) (*models.BlogTranslation, error) {
+	sessionUserId, err := session.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+	normalized, err := locale.Normalize(translation.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLocale, err)
+	}
+	translation.Locale = normalized
+
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		blog, err := u.BlogRepository.Get(ctx, tx, translation.BlogId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blog: %w", err)
+		}
+		if blog == nil {
+			return nil, ErrResourceNotFound
+		}
+		if sessionUserId != blog.AuthorId {
+			return nil, fmt.Errorf("can't update other user's blog")
+		}
+		if translation.Locale == blog.DefaultLocale {
+			return nil, ErrDefaultLocale
+		}
+		if err := u.BlogRepository.UpsertTranslation(ctx, tx, translation); err != nil {
+			return nil, fmt.Errorf("failed to upsert translation: %w", err)
+		}
+		newTranslation, err := u.BlogRepository.GetTranslation(ctx, tx, translation.BlogId, translation.Locale)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get translation: %w", err)
+		}
+		return newTranslation, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to put translation: %w", err)
+	}
+	newTranslation, ok := result.(*models.BlogTranslation)
+	if !ok {
+		return nil, fmt.Errorf("failed to type assertion")
+	}
+	return newTranslation, nil
+}
@@ -0,0 +1,137 @@
+package put_tag
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/logging"
+)
+
+var (
+	ErrResourceNotFound = fmt.Errorf("resource not found")
+	ErrTagNameConflict  = fmt.Errorf("tag name is already used")
+)
+
+type BlogRepository interface {
+	GetTag(ctx context.Context, tx infrastructure.TX, tagId models.TagId) (*models.Tag, error)
+	SelectTags(ctx context.Context, tx infrastructure.TX, tag string) ([]*models.Tag, error)
+	PutTag(ctx context.Context, tx infrastructure.TX, tag *models.Tag) error
+	ListBlogIdsByTagId(ctx context.Context, tx infrastructure.TX, tagId models.TagId) ([]models.BlogId, error)
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	UpsertSearchIndex(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error
+}
+
+type SitemapCache interface {
+	Invalidate(ctx context.Context) error
+}
+
+type RelatedBlogsCache interface {
+	Invalidate(ctx context.Context, blogIds ...models.BlogId) error
+}
+
+// put_tag.Usecaseは、タグの名前・説明・表示色を更新するユースケースです。
+// 名前が変わる場合は、タグが付けられたブログの検索インデックスを更新します。
+type Usecase struct {
+	DB                infrastructure.DB
+	BlogRepository    BlogRepository
+	SitemapCache      SitemapCache
+	RelatedBlogsCache RelatedBlogsCache
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	sitemapCache SitemapCache,
+	relatedBlogsCache RelatedBlogsCache,
+) *Usecase {
+	return &Usecase{
+		DB:                db,
+		BlogRepository:    blogRepository,
+		SitemapCache:      sitemapCache,
+		RelatedBlogsCache: relatedBlogsCache,
+	}
+}
+
+func (u *Usecase) Run(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+	tag.Name = strings.TrimSpace(tag.Name)
+
+	// 名前が変わったタグが付けられたブログ
+	var renamedBlogIds []models.BlogId
+
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		renamedBlogIds = nil
+
+		current, err := u.BlogRepository.GetTag(ctx, tx, tag.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tag: %w", err)
+		}
+		if current == nil {
+			return nil, ErrResourceNotFound
+		}
+
+		if tag.Name != current.Name {
+			sameName, err := u.BlogRepository.SelectTags(ctx, tx, tag.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to select tags: %w", err)
+			}
+			if len(sameName) > 0 {
+				return nil, ErrTagNameConflict
+			}
+		}
+
+		if err := u.BlogRepository.PutTag(ctx, tx, tag); err != nil {
+			return nil, fmt.Errorf("failed to put tag: %w", err)
+		}
+
+		// 検索インデックスにはタグ名が含まれるため、名前が変わった場合は作り直す
+		if tag.Name != current.Name {
+			ids, err := u.BlogRepository.ListBlogIdsByTagId(ctx, tx, tag.Id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list blog ids by tag id: %w", err)
+			}
+			for _, id := range ids {
+				blog, err := u.BlogRepository.Get(ctx, tx, id)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get blog: %w", err)
+				}
+				if blog == nil {
+					continue
+				}
+				if err := u.BlogRepository.UpsertSearchIndex(ctx, tx, blog); err != nil {
+					return nil, fmt.Errorf("failed to upsert search index: %w", err)
+				}
+			}
+			renamedBlogIds = ids
+		}
+
+		newTag, err := u.BlogRepository.GetTag(ctx, tx, tag.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tag: %w", err)
+		}
+		return newTag, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tag: %w", err)
+	}
+
+	// sitemapと関連ブログにはタグ名が含まれるため、キャッシュを破棄する
+	// タグの更新は完了しているため、破棄に失敗してもエラーは記録のみとする
+	if len(renamedBlogIds) > 0 {
+		if err := u.SitemapCache.Invalidate(ctx); err != nil {
+			logging.GetLogger(ctx).Error(fmt.Sprintf("failed to invalidate sitemap cache: %v", err))
+		}
+		if err := u.RelatedBlogsCache.Invalidate(ctx, renamedBlogIds...); err != nil {
+			logging.GetLogger(ctx).Error(fmt.Sprintf("failed to invalidate related blogs cache: %v", err))
+		}
+	}
+
+	newTag, ok := result.(*models.Tag)
+	if !ok {
+		return nil, fmt.Errorf("failed to type assertion")
+	}
+	return newTag, nil
+}
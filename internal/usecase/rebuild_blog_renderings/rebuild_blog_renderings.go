@@ -0,0 +1,64 @@
+package rebuild_blog_renderings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type BlogRepository interface {
+	ListAllIds(ctx context.Context, tx infrastructure.TX) ([]models.BlogId, error)
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	UpsertRendering(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error
+}
+
+// rebuild_blog_renderings.Usecaseはすべてのブログ本文を再レンダリングするユースケースです。
+// レンダリング処理の変更後や、既存のブログのレンダリング結果を作成する際に使用します。
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+	}
+}
+
+// Runはレンダリング結果を再作成し、対象となったブログの件数を返す
+func (u *Usecase) Run(ctx context.Context) (int, error) {
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		ids, err := u.BlogRepository.ListAllIds(ctx, tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blog ids: %w", err)
+		}
+		for _, id := range ids {
+			blog, err := u.BlogRepository.Get(ctx, tx, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get blog: %w", err)
+			}
+			if blog == nil {
+				continue
+			}
+			if err := u.BlogRepository.UpsertRendering(ctx, tx, blog); err != nil {
+				return nil, fmt.Errorf("failed to upsert rendering: %w", err)
+			}
+		}
+		return len(ids), nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to rebuild renderings: %w", err)
+	}
+	count, ok := result.(int)
+	if !ok {
+		return 0, fmt.Errorf("failed to type assertion")
+	}
+	return count, nil
+}
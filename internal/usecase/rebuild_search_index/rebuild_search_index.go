@@ -0,0 +1,68 @@
+package rebuild_search_index
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+)
+
+type BlogRepository interface {
+	ListAllIds(ctx context.Context, tx infrastructure.TX) ([]models.BlogId, error)
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	DeleteAllSearchIndex(ctx context.Context, tx infrastructure.TX) error
+	UpsertSearchIndex(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error
+}
+
+// rebuild_search_index.Usecaseはすべてのブログの検索インデックスを再構築するユースケースです。
+// 既存のインデックスを削除してから作り直すため、1つのトランザクションで実行します。
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+	}
+}
+
+// Runはインデックスを再構築し、対象となったブログの件数を返す
+func (u *Usecase) Run(ctx context.Context) (int, error) {
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		if err := u.BlogRepository.DeleteAllSearchIndex(ctx, tx); err != nil {
+			return nil, fmt.Errorf("failed to delete search index: %w", err)
+		}
+		ids, err := u.BlogRepository.ListAllIds(ctx, tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blog ids: %w", err)
+		}
+		for _, id := range ids {
+			blog, err := u.BlogRepository.Get(ctx, tx, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get blog: %w", err)
+			}
+			if blog == nil {
+				continue
+			}
+			if err := u.BlogRepository.UpsertSearchIndex(ctx, tx, blog); err != nil {
+				return nil, fmt.Errorf("failed to upsert search index: %w", err)
+			}
+		}
+		return len(ids), nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to rebuild search index: %w", err)
+	}
+	count, ok := result.(int)
+	if !ok {
+		return 0, fmt.Errorf("failed to type assertion")
+	}
+	return count, nil
+}
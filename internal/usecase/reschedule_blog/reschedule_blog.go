@@ -0,0 +1,48 @@
+package reschedule_blog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shoet/blog/internal/clocker"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+)
+
+var ErrResourceNotFound = fmt.Errorf("unpublished blog not found")
+var ErrInvalidPublishAt = fmt.Errorf("publishAt must be in the future")
+
+type BlogRepository interface {
+	UpdatePublishAt(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, publishAt *uint) (*models.Blog, error)
+}
+
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+	Clocker        clocker.Clocker
+}
+
+func NewUsecase(db infrastructure.DB, blogRepository BlogRepository, clocker clocker.Clocker) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+		Clocker:        clocker,
+	}
+}
+
+// Run は、非公開のブログの公開予定日時を設定する
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId, publishAt uint) (*models.Blog, error) {
+	if int64(publishAt) <= u.Clocker.Now().Unix() {
+		return nil, ErrInvalidPublishAt
+	}
+	blog, err := u.BlogRepository.UpdatePublishAt(ctx, u.DB, blogId, &publishAt)
+	if err != nil {
+		if errors.Is(err, repository.ErrResourceNotFound) {
+			return nil, ErrResourceNotFound
+		}
+		return nil, fmt.Errorf("failed to update publish_at: %w", err)
+	}
+	return blog, nil
+}
@@ -0,0 +1,96 @@
+package restore_blog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/logging"
+	"github.com/shoet/blog/internal/session"
+)
+
+var ErrResourceNotFound = fmt.Errorf("trashed blog not found")
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	GetTrashed(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) (*models.Blog, error)
+	Restore(ctx context.Context, tx infrastructure.TX, blogId models.BlogId) error
+	UpsertSearchIndex(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error
+	UpsertRendering(ctx context.Context, tx infrastructure.TX, blog *models.Blog) error
+}
+
+type SitemapCache interface {
+	Invalidate(ctx context.Context) error
+}
+
+type Usecase struct {
+	DB             infrastructure.DB
+	BlogRepository BlogRepository
+	SitemapCache   SitemapCache
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	sitemapCache SitemapCache,
+) *Usecase {
+	return &Usecase{
+		DB:             db,
+		BlogRepository: blogRepository,
+		SitemapCache:   sitemapCache,
+	}
+}
+
+// Run は、ログインユーザーのゴミ箱にあるブログを元に戻す
+// ゴミ箱にある間に再作成された検索インデックスとレンダリング結果は、元に戻す際に作成し直す
+// シリーズへの所属はゴミ箱にある間も残しているため、元のシリーズに戻る
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId) (*models.Blog, error) {
+	sessionUserId, err := session.GetUserId(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		trashed, err := u.BlogRepository.GetTrashed(ctx, tx, blogId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trashed blog: %w", err)
+		}
+		if trashed == nil {
+			return nil, ErrResourceNotFound
+		}
+		if trashed.AuthorId != sessionUserId {
+			return nil, fmt.Errorf("can't restore other user's blog")
+		}
+		if err := u.BlogRepository.Restore(ctx, tx, blogId); err != nil {
+			return nil, fmt.Errorf("failed to restore blog: %w", err)
+		}
+		blog, err := u.BlogRepository.Get(ctx, tx, blogId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blog: %w", err)
+		}
+		if err := u.BlogRepository.UpsertSearchIndex(ctx, tx, blog); err != nil {
+			return nil, fmt.Errorf("failed to upsert search index: %w", err)
+		}
+		if err := u.BlogRepository.UpsertRendering(ctx, tx, blog); err != nil {
+			return nil, fmt.Errorf("failed to upsert rendering: %w", err)
+		}
+		return blog, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore blog: %w", err)
+	}
+
+	// 公開中のブログが変わるため、sitemapのキャッシュを破棄する
+	// ブログの復元は完了しているため、破棄に失敗してもエラーは記録のみとする
+	if err := u.SitemapCache.Invalidate(ctx); err != nil {
+		logging.GetLogger(ctx).Error(fmt.Sprintf("failed to invalidate sitemap cache: %v", err))
+	}
+
+	blog, ok := result.(*models.Blog)
+	if !ok {
+		return nil, fmt.Errorf("failed to type assertion")
+	}
+	return blog, nil
+}
@@ -0,0 +1,72 @@
+package restore_blog_revision
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+)
+
+var ErrResourceNotFound = fmt.Errorf("blog revision not found")
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+}
+
+type BlogRevisionRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, blogId models.BlogId, revision int64) (*models.BlogRevision, error)
+}
+
+// BlogPutter は、ブログを更新するユースケース(put_blog)
+type BlogPutter interface {
+	Run(ctx context.Context, blog *models.Blog, version *int64) (*models.Blog, error)
+}
+
+type Usecase struct {
+	DB                     infrastructure.DB
+	BlogRepository         BlogRepository
+	BlogRevisionRepository BlogRevisionRepository
+	BlogPutter             BlogPutter
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	blogRevisionRepository BlogRevisionRepository,
+	blogPutter BlogPutter,
+) *Usecase {
+	return &Usecase{
+		DB:                     db,
+		BlogRepository:         blogRepository,
+		BlogRevisionRepository: blogRevisionRepository,
+		BlogPutter:             blogPutter,
+	}
+}
+
+// Run は、指定したリビジョンの内容でブログを更新する
+// 更新はput_blogを通して行うため、タグの整理と新しいリビジョンの保存も行われる
+// 公開状態と予約公開の日時は現在のブログのものを引き継ぎ、公開中のブログを非公開に戻したり予約公開を取り消したりしない
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId, revision int64) (*models.Blog, error) {
+	blogRevision, err := u.BlogRevisionRepository.Get(ctx, u.DB, blogId, revision)
+	if err != nil {
+		if errors.Is(err, repository.ErrResourceNotFound) {
+			return nil, ErrResourceNotFound
+		}
+		return nil, fmt.Errorf("failed to get blog revision: %w", err)
+	}
+	current, err := u.BlogRepository.Get(ctx, u.DB, blogId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog: %w", err)
+	}
+	if current == nil {
+		return nil, ErrResourceNotFound
+	}
+	blog, err := u.BlogPutter.Run(ctx, blogRevision.ApplyTo(current), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put blog: %w", err)
+	}
+	return blog, nil
+}
@@ -0,0 +1,64 @@
+package revoke_preview_token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/services/preview_token_service"
+	"github.com/shoet/blog/internal/session"
+)
+
+var ErrResourceNotFound = fmt.Errorf("preview token not found")
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+}
+
+type PreviewTokenService interface {
+	Revoke(ctx context.Context, blogId models.BlogId, id models.PreviewTokenId) error
+}
+
+type Usecase struct {
+	DB                  infrastructure.DB
+	BlogRepository      BlogRepository
+	PreviewTokenService PreviewTokenService
+}
+
+func NewUsecase(
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	previewTokenService PreviewTokenService,
+) *Usecase {
+	return &Usecase{
+		DB:                  db,
+		BlogRepository:      blogRepository,
+		PreviewTokenService: previewTokenService,
+	}
+}
+
+func (u *Usecase) Run(ctx context.Context, blogId models.BlogId, tokenId models.PreviewTokenId) error {
+	sessionUserId, err := session.GetUserId(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to session.GetUserId: %w", err)
+	}
+	blog, err := u.BlogRepository.Get(ctx, u.DB, blogId)
+	if err != nil {
+		return fmt.Errorf("failed to get blog: %w", err)
+	}
+	if blog == nil {
+		return ErrResourceNotFound
+	}
+	if blog.AuthorId != sessionUserId {
+		return fmt.Errorf("can't revoke preview token for other user's blog")
+	}
+	if err := u.PreviewTokenService.Revoke(ctx, blogId, tokenId); err != nil {
+		if errors.Is(err, preview_token_service.ErrPreviewTokenNotFound) {
+			return ErrResourceNotFound
+		}
+		return fmt.Errorf("failed to revoke preview token: %w", err)
+	}
+	return nil
+}
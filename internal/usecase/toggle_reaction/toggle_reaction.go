@@ -0,0 +1,143 @@
+package toggle_reaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/shoet/blog/internal/config"
+	"github.com/shoet/blog/internal/infrastructure"
+	"github.com/shoet/blog/internal/infrastructure/models"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	ErrResourceNotFound = fmt.Errorf("resource not found")
+	ErrInvalidEmoji     = fmt.Errorf("invalid emoji")
+)
+
+type BlogRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+}
+
+type CommentRepository interface {
+	Get(ctx context.Context, tx infrastructure.TX, commentId models.CommentId) (*models.Comment, error)
+}
+
+type ReactionRepository interface {
+	Add(
+		ctx context.Context, tx infrastructure.TX,
+		target models.ReactionTarget, targetId int64, emoji string, reactor *models.Reactor,
+	) (bool, error)
+	Delete(
+		ctx context.Context, tx infrastructure.TX,
+		target models.ReactionTarget, targetId int64, emoji string, reactor *models.Reactor,
+	) (bool, error)
+	CountByTargets(
+		ctx context.Context, tx infrastructure.TX, target models.ReactionTarget, targetIds []int64,
+	) (map[int64][]*models.ReactionCount, error)
+}
+
+type Usecase struct {
+	emojis             []string
+	DB                 infrastructure.DB
+	BlogRepository     BlogRepository
+	CommentRepository  CommentRepository
+	ReactionRepository ReactionRepository
+}
+
+func NewUsecase(
+	config *config.Config,
+	db infrastructure.DB,
+	blogRepository BlogRepository,
+	commentRepository CommentRepository,
+	reactionRepository ReactionRepository,
+) *Usecase {
+	return &Usecase{
+		emojis:             models.ParseReactionEmojis(config.ReactionEmojis),
+		DB:                 db,
+		BlogRepository:     blogRepository,
+		CommentRepository:  commentRepository,
+		ReactionRepository: reactionRepository,
+	}
+}
+
+type Result struct {
+	Reacted   bool                    `json:"reacted"`
+	Reactions []*models.ReactionCount `json:"reactions"`
+}
+
+// Run は、公開済みのブログ、またはそのコメントへの読者のリアクションを切り替える
+// commentIdがnilの場合はブログへのリアクションとする
+// リアクション済みの場合は取り消し、未リアクションの場合は追加する
+func (u *Usecase) Run(
+	ctx context.Context,
+	blogId models.BlogId,
+	commentId *models.CommentId,
+	emoji string,
+	reactor *models.Reactor,
+) (*Result, error) {
+	if reactor.UserId == nil && reactor.ClientId == nil {
+		return nil, fmt.Errorf("UserID or ClientID is required")
+	}
+	if !slices.Contains(u.emojis, emoji) {
+		return nil, ErrInvalidEmoji
+	}
+
+	transactor := infrastructure.NewTransactionProvider(u.DB)
+	result, err := transactor.DoInTx(ctx, func(tx infrastructure.TX) (interface{}, error) {
+		blog, err := u.BlogRepository.Get(ctx, tx, blogId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blog: %w", err)
+		}
+		if blog == nil || !blog.IsPublic {
+			return nil, ErrResourceNotFound
+		}
+		target := models.ReactionTargetBlog
+		targetId := int64(blogId)
+		if commentId != nil {
+			comment, err := u.CommentRepository.Get(ctx, tx, *commentId)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, ErrResourceNotFound
+				}
+				return nil, fmt.Errorf("failed to get comment: %w", err)
+			}
+			if comment.BlogId != blogId || comment.IsDeleted {
+				return nil, ErrResourceNotFound
+			}
+			target = models.ReactionTargetComment
+			targetId = int64(*commentId)
+		}
+
+		deleted, err := u.ReactionRepository.Delete(ctx, tx, target, targetId, emoji, reactor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete reaction: %w", err)
+		}
+		// 同時に追加された場合もリアクション済みとなるため、追加できたかは問わない
+		if !deleted {
+			if _, err := u.ReactionRepository.Add(ctx, tx, target, targetId, emoji, reactor); err != nil {
+				return nil, fmt.Errorf("failed to add reaction: %w", err)
+			}
+		}
+
+		counts, err := u.ReactionRepository.CountByTargets(ctx, tx, target, []int64{targetId})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count reactions: %w", err)
+		}
+		reactions := counts[targetId]
+		if reactions == nil {
+			reactions = []*models.ReactionCount{}
+		}
+		return &Result{Reacted: !deleted, Reactions: reactions}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to toggle reaction: %w", err)
+	}
+	r, ok := result.(*Result)
+	if !ok {
+		return nil, fmt.Errorf("failed to type assertion")
+	}
+	return r, nil
+}
@@ -2,35 +2,96 @@ package update_public_status
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/shoet/blog/internal/infrastructure"
 	"github.com/shoet/blog/internal/infrastructure/models"
+	"github.com/shoet/blog/internal/infrastructure/repository"
+	"github.com/shoet/blog/internal/logging"
 )
 
+// ErrPreconditionFailedは、取得後に他のリクエストでブログが更新されている場合のエラー
+var ErrPreconditionFailed = fmt.Errorf("blog has been modified")
+
 type BlogRepository interface {
 	UpdatePublicStatus(
-		ctx context.Context, tx infrastructure.TX, blogId models.BlogId, isPublic bool,
+		ctx context.Context, tx infrastructure.TX, blogId models.BlogId, isPublic bool, version *int64,
 	) (*models.Blog, error)
+	Get(ctx context.Context, tx infrastructure.TX, id models.BlogId) (*models.Blog, error)
+	ListBlogIdsByTags(ctx context.Context, tx infrastructure.TX, tags []string) ([]models.BlogId, error)
+}
+
+type SitemapCache interface {
+	Invalidate(ctx context.Context) error
+}
+
+type RelatedBlogsCache interface {
+	Invalidate(ctx context.Context, blogIds ...models.BlogId) error
 }
 
 type Usecase struct {
-	DB             infrastructure.DB
-	blogRepository BlogRepository
+	DB                infrastructure.DB
+	blogRepository    BlogRepository
+	sitemapCache      SitemapCache
+	relatedBlogsCache RelatedBlogsCache
 }
 
 func NewUsecase(
-	db infrastructure.DB, blogRepository BlogRepository) *Usecase {
+	db infrastructure.DB, blogRepository BlogRepository, sitemapCache SitemapCache,
+	relatedBlogsCache RelatedBlogsCache,
+) *Usecase {
 	return &Usecase{
-		DB:             db,
-		blogRepository: blogRepository,
+		DB:                db,
+		blogRepository:    blogRepository,
+		sitemapCache:      sitemapCache,
+		relatedBlogsCache: relatedBlogsCache,
 	}
 }
 
-func (u *Usecase) Run(ctx context.Context, blogId models.BlogId, isPublic bool) (*models.Blog, error) {
-	blog, err := u.blogRepository.UpdatePublicStatus(ctx, u.DB, blogId, isPublic)
+// Run は、ブログの公開状態を更新する
+// ブログが存在しない場合はnilを返す
+// versionを指定した場合は、ブログのバージョンが一致しない場合にErrPreconditionFailedを返す
+func (u *Usecase) Run(
+	ctx context.Context, blogId models.BlogId, isPublic bool, version *int64,
+) (*models.Blog, error) {
+	blog, err := u.blogRepository.UpdatePublicStatus(ctx, u.DB, blogId, isPublic, version)
 	if err != nil {
+		if errors.Is(err, repository.ErrResourceNotFound) {
+			return nil, nil
+		}
+		if errors.Is(err, repository.ErrVersionMismatch) {
+			return nil, ErrPreconditionFailed
+		}
 		return nil, fmt.Errorf("failed to update blog public status: %w", err)
 	}
+
+	// 公開中のブログが変わるため、sitemapのキャッシュを破棄する
+	// 公開状態の更新は完了しているため、破棄に失敗してもエラーは記録のみとする
+	if err := u.sitemapCache.Invalidate(ctx); err != nil {
+		logging.GetLogger(ctx).Error(fmt.Sprintf("failed to invalidate sitemap cache: %v", err))
+	}
+	// 関連ブログの候補も変わるため、このブログとタグを共有するブログの関連ブログのキャッシュを破棄する
+	if err := u.invalidateRelatedBlogs(ctx, blogId); err != nil {
+		logging.GetLogger(ctx).Error(fmt.Sprintf("failed to invalidate related blogs cache: %v", err))
+	}
 	return blog, nil
 }
+
+func (u *Usecase) invalidateRelatedBlogs(ctx context.Context, blogId models.BlogId) error {
+	blog, err := u.blogRepository.Get(ctx, u.DB, blogId)
+	if err != nil {
+		return fmt.Errorf("failed to get blog: %w", err)
+	}
+	if blog == nil {
+		return nil
+	}
+	ids, err := u.blogRepository.ListBlogIdsByTags(ctx, u.DB, blog.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to list blog ids by tags: %w", err)
+	}
+	if err := u.relatedBlogsCache.Invalidate(ctx, append([]models.BlogId{blogId}, ids...)...); err != nil {
+		return fmt.Errorf("failed to invalidate related blogs cache: %w", err)
+	}
+	return nil
+}